@@ -0,0 +1,435 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLimit int
+
+	// auditCommand is the command the audited transactions are sent by (set during PreRun).
+	auditCommand string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the local audit log of admin actions",
+	Long: `Every transaction to the gating contract sent by this CLI (mint, grantAdmin,
+revokeAdmin, setConfig, apply and the daemons and APIs built on them) is appended
+to a local audit log, with the operator, profile, network, decoded call, the state
+before and after and the transaction receipt.
+
+The records are hash-chained: each record contains the hash of the previous one,
+so editing or removing a record breaks the chain, which 'audit verify' detects.
+
+The audit log is audit-<chain ID>.jsonl next to the config file by default
+(override with --audit-log).`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+}
+
+var auditShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the records of the audit log",
+	Args:  cobra.NoArgs,
+	RunE:  runAuditShow,
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the hash chain of the audit log",
+	Args:  cobra.NoArgs,
+	RunE:  runAuditVerify,
+}
+
+func init() {
+	auditShowCmd.Flags().IntVar(&auditLimit, "limit", 0, "Only show the last N records (default: all)")
+
+	auditCmd.AddCommand(auditShowCmd)
+	auditCmd.AddCommand(auditVerifyCmd)
+}
+
+// Audit record states.
+const (
+	auditStatusSuccess  = "success"
+	auditStatusReverted = "reverted"
+	// auditStatusUnconfirmed is a transaction that was sent, but not confirmed. It may still be included.
+	auditStatusUnconfirmed = "unconfirmed"
+)
+
+// auditState is the state touched by an audited call.
+type auditState struct {
+	Balance     string             `json:"balance,omitempty"`
+	HasRole     *bool              `json:"hasRole,omitempty"`
+	Config      *depositTypePolicy `json:"config,omitempty"`
+	CustomGater string             `json:"customGater,omitempty"`
+}
+
+// auditRecord is an audited transaction, as stored in the audit log.
+type auditRecord struct {
+	Seq      uint64      `json:"seq"`
+	Time     time.Time   `json:"time"`
+	Operator string      `json:"operator"`
+	Signer   string      `json:"signer"`
+	Profile  string      `json:"profile,omitempty"`
+	Command  string      `json:"command,omitempty"`
+	ChainID  uint64      `json:"chainId"`
+	Gater    string      `json:"gater"`
+	Method   string      `json:"method"`
+	Call     string      `json:"call"`
+	Before   *auditState `json:"before,omitempty"`
+	After    *auditState `json:"after,omitempty"`
+	TxHash   string      `json:"txHash"`
+	Block    uint64      `json:"block"`
+	GasUsed  uint64      `json:"gasUsed"`
+	Status   string      `json:"status"`
+	PrevHash string      `json:"prevHash"`
+	Hash     string      `json:"hash"`
+}
+
+// auditLogMutex serializes appends within the process, the lock file serializes them between processes.
+var auditLogMutex sync.Mutex
+
+// computeHash returns the hash of a record, covering all fields but the hash itself.
+func (record *auditRecord) computeHash() (string, error) {
+	unhashed := *record
+	unhashed.Hash = ""
+	data, err := json.Marshal(unhashed)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// resolveAuditLogPath returns the log given by --audit-log or the default log of the network.
+func resolveAuditLogPath() (string, error) {
+	if auditLogPath != "" {
+		return auditLogPath, nil
+	}
+	return chainDataPath("audit", "jsonl")
+}
+
+// resolveOperator returns the operator recorded in the audit log: --operator, GATING_CLI_OPERATOR
+// or the OS user.
+func resolveOperator() string {
+	if auditOperator != "" {
+		return auditOperator
+	}
+	if operator := os.Getenv("GATING_CLI_OPERATOR"); operator != "" {
+		return operator
+	}
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return "unknown"
+}
+
+// auditTransaction appends a transaction to the gater to the audit log, with the state the call
+// touches before and after the transaction. It is called by the transactor once the receipt
+// was received, or waiting for it failed. Failing to write the audit log doesn't fail the sent
+// transaction, but is logged as an error.
+func auditTransaction(tx *types.Transaction, receipt *types.Receipt, waitErr error) {
+	if tx.To() == nil || *tx.To() != gaterAddr {
+		return
+	}
+
+	// The command context may be done already (e.g. after a timeout), the RPC calls are
+	// still limited by the per-call timeout
+	ctx := context.Background()
+	record := &auditRecord{
+		Time:     time.Now().UTC(),
+		Operator: resolveOperator(),
+		Signer:   signerAddress.Hex(),
+		Profile:  profileName,
+		Command:  auditCommand,
+		ChainID:  chainID.Uint64(),
+		Gater:    gaterAddr.Hex(),
+		Call:     describeCall(gaterAddr, tx.Data()),
+		TxHash:   tx.Hash().Hex(),
+	}
+
+	var values []interface{}
+	if method, err := gater.ABI.MethodById(tx.Data()); err == nil {
+		record.Method = method.Name
+		values, _ = method.Inputs.Unpack(tx.Data()[4:])
+	}
+
+	if receipt == nil {
+		// The transaction may still change the state, so it is recorded without receipt
+		log.WithError(waitErr).WithField("tx", record.TxHash).Warn("Recording unconfirmed transaction in audit log")
+		record.Status = auditStatusUnconfirmed
+		before, err := readAuditState(ctx, nil, record.Method, values)
+		if err != nil {
+			log.WithError(err).Warn("Failed to read state before transaction for audit log")
+		}
+		record.Before = before
+	} else {
+		record.Block = receipt.BlockNumber.Uint64()
+		record.GasUsed = receipt.GasUsed
+		record.Status = auditStatusSuccess
+		if receipt.Status == types.ReceiptStatusFailed {
+			record.Status = auditStatusReverted
+		}
+		parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+		before, err := readAuditState(ctx, parent, record.Method, values)
+		if err != nil {
+			log.WithError(err).Warn("Failed to read state before transaction for audit log")
+		}
+		record.Before = before
+		after, err := readAuditState(ctx, receipt.BlockNumber, record.Method, values)
+		if err != nil {
+			log.WithError(err).Warn("Failed to read state after transaction for audit log")
+		}
+		record.After = after
+	}
+
+	if err := appendAuditRecord(record); err != nil {
+		log.WithError(err).WithField("tx", record.TxHash).Error("Failed to write audit record")
+	}
+}
+
+// readAuditState reads the state touched by a call at a block (nil for latest).
+// Unknown calls touch no state.
+func readAuditState(ctx context.Context, block *big.Int, method string, values []interface{}) (*auditState, error) {
+	client := gaterClient.At(block)
+	state := &auditState{}
+	switch {
+	case method == "mint" && len(values) == 2:
+		balance, err := client.BalanceOf(ctx, values[0].(common.Address))
+		if err != nil {
+			return nil, err
+		}
+		state.Balance = balance.String()
+	case (method == "grantRole" || method == "revokeRole") && len(values) == 2:
+		hasRole, err := client.HasRole(ctx, common.Hash(values[0].([32]byte)), values[1].(common.Address))
+		if err != nil {
+			return nil, err
+		}
+		state.HasRole = &hasRole
+	case method == "setDepositGateConfig" && len(values) == 3:
+		config, err := client.DepositGateConfig(ctx, values[0].(uint16))
+		if err != nil {
+			return nil, err
+		}
+		state.Config = &depositTypePolicy{Blocked: config.Blocked, NoToken: config.NoToken}
+	case method == "setCustomGater":
+		customGater, err := client.CustomGater(ctx)
+		if err != nil {
+			return nil, err
+		}
+		state.CustomGater = customGater.Hex()
+	default:
+		return nil, nil
+	}
+	return state, nil
+}
+
+// appendAuditRecord chains a record to the last record of the audit log and appends it.
+func appendAuditRecord(record *auditRecord) error {
+	path, err := resolveAuditLogPath()
+	if err != nil {
+		return err
+	}
+
+	auditLogMutex.Lock()
+	defer auditLogMutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	unlock, err := lockStoreFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	records, err := loadAuditLog(path)
+	if err != nil {
+		return err
+	}
+	record.Seq = 1
+	if len(records) > 0 {
+		last := records[len(records)-1]
+		record.Seq = last.Seq + 1
+		record.PrevHash = last.Hash
+	}
+	if record.Hash, err = record.computeHash(); err != nil {
+		return fmt.Errorf("failed to hash audit record: %w", err)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return file.Sync()
+}
+
+// loadAuditLog reads the records of the audit log. A missing file results in no records.
+func loadAuditLog(path string) ([]*auditRecord, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	records := []*auditRecord{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		record := &auditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("failed to parse audit log %s line %d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// verifyAuditLog checks the sequence numbers, hashes and links of the records.
+func verifyAuditLog(records []*auditRecord) error {
+	prevHash := ""
+	for i, record := range records {
+		if record.Seq != uint64(i+1) {
+			return fmt.Errorf("record %d has sequence number %d, expected %d (records removed or reordered)", i+1, record.Seq, i+1)
+		}
+		if record.PrevHash != prevHash {
+			return fmt.Errorf("record #%d does not link to the previous record", record.Seq)
+		}
+		hash, err := record.computeHash()
+		if err != nil {
+			return fmt.Errorf("failed to hash record #%d: %w", record.Seq, err)
+		}
+		if hash != record.Hash {
+			return fmt.Errorf("record #%d was modified (hash mismatch)", record.Seq)
+		}
+		prevHash = record.Hash
+	}
+	return nil
+}
+
+func runAuditShow(cmd *cobra.Command, args []string) error {
+	path, err := resolveAuditLogPath()
+	if err != nil {
+		return err
+	}
+	records, err := loadAuditLog(path)
+	if err != nil {
+		return err
+	}
+	if auditLimit > 0 && len(records) > auditLimit {
+		records = records[len(records)-auditLimit:]
+	}
+
+	if outputFormat == "json" {
+		if records == nil {
+			records = []*auditRecord{}
+		}
+		return printJSON(records)
+	}
+
+	if len(records) == 0 {
+		printInfo("No audit records in %s", path)
+		return nil
+	}
+	for _, record := range records {
+		printAuditRecord(record)
+	}
+	return nil
+}
+
+// printAuditRecord prints an audit record.
+func printAuditRecord(record *auditRecord) {
+	status := colorGreen + record.Status + colorReset
+	if record.Status != auditStatusSuccess {
+		status = colorRed + record.Status + colorReset
+	}
+	fmt.Printf("%s#%d%s %s  %s\n", colorBold, record.Seq, colorReset, record.Time.Local().Format("2006-01-02 15:04:05"), status)
+	fmt.Printf("  %sCall:%s        %s\n", colorCyan, colorReset, record.Call)
+	operator := record.Operator
+	if record.Profile != "" {
+		operator += fmt.Sprintf(" (profile %s)", record.Profile)
+	}
+	fmt.Printf("  %sOperator:%s    %s, signer %s\n", colorCyan, colorReset, operator, record.Signer)
+	if record.Command != "" {
+		fmt.Printf("  %sCommand:%s     %s\n", colorCyan, colorReset, record.Command)
+	}
+	if change := describeAuditChange(record.Before, record.After); change != "" {
+		fmt.Printf("  %sChange:%s      %s\n", colorCyan, colorReset, change)
+	}
+	if record.Status == auditStatusUnconfirmed {
+		fmt.Printf("  %sTransaction:%s %s (not confirmed)\n", colorCyan, colorReset, record.TxHash)
+	} else {
+		fmt.Printf("  %sTransaction:%s %s (block %d, gas %d)\n", colorCyan, colorReset, record.TxHash, record.Block, record.GasUsed)
+	}
+	fmt.Println()
+}
+
+// describeAuditChange describes the state change of a record, e.g. "balance 0 → 5".
+func describeAuditChange(before, after *auditState) string {
+	if before == nil || after == nil {
+		return ""
+	}
+	switch {
+	case after.Balance != "":
+		return fmt.Sprintf("balance %s → %s", before.Balance, after.Balance)
+	case after.HasRole != nil && before.HasRole != nil:
+		return fmt.Sprintf("hasRole %v → %v", *before.HasRole, *after.HasRole)
+	case after.Config != nil && before.Config != nil:
+		return fmt.Sprintf("blocked=%v noToken=%v → blocked=%v noToken=%v",
+			before.Config.Blocked, before.Config.NoToken, after.Config.Blocked, after.Config.NoToken)
+	case after.CustomGater != "":
+		return fmt.Sprintf("customGater %s → %s", before.CustomGater, after.CustomGater)
+	}
+	return ""
+}
+
+func runAuditVerify(cmd *cobra.Command, args []string) error {
+	path, err := resolveAuditLogPath()
+	if err != nil {
+		return err
+	}
+	records, err := loadAuditLog(path)
+	if err != nil {
+		return err
+	}
+	if err := verifyAuditLog(records); err != nil {
+		return fmt.Errorf("audit log %s is broken: %w", path, err)
+	}
+	if len(records) == 0 {
+		printInfo("Audit log %s is empty", path)
+		return nil
+	}
+	last := records[len(records)-1]
+	printSuccess("Audit log is intact: %d record(s), last hash %s", len(records), last.Hash)
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestAuditLog(t *testing.T) {
+	env := newTestEnv(t)
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	env.mustRun(env.chain.AdminKey, "mint", "3", "--to", env.chain.User.Hex(), "--operator", "alice", "--audit-log", logPath, "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x01", "--blocked", "true", "--audit-log", logPath, "--yes")
+	env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--audit-log", logPath, "--yes")
+
+	out := env.mustRun(nil, "audit", "show", "--audit-log", logPath, "--output", "json")
+	var records []*auditRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d audit records, want 3", len(records))
+	}
+	mint := records[0]
+	if mint.Operator != "alice" || mint.Signer != env.chain.Admin.Hex() || mint.ChainID != 1337 || mint.Gater != env.chain.Gater.Hex() ||
+		mint.Method != "mint" || mint.Status != auditStatusSuccess || mint.TxHash == "" || mint.Command != "gating-cli mint" {
+		t.Errorf("unexpected mint record: %+v", mint)
+	}
+	if mint.Before == nil || mint.Before.Balance != "0" || mint.After == nil || mint.After.Balance != "3" {
+		t.Errorf("unexpected mint state: before %+v, after %+v", mint.Before, mint.After)
+	}
+	setConfig := records[1]
+	if setConfig.Before.Config.Blocked || !setConfig.After.Config.Blocked || !strings.Contains(setConfig.Call, "depositType=0x0001") {
+		t.Errorf("unexpected setConfig record: %+v", setConfig)
+	}
+	if grant := records[2]; *grant.Before.HasRole || !*grant.After.HasRole || grant.PrevHash != setConfig.Hash {
+		t.Errorf("unexpected grantAdmin record: %+v", grant)
+	}
+
+	out = env.mustRun(nil, "audit", "show", "--audit-log", logPath, "--limit", "1")
+	assertContains(t, out, "#3", "grantRole(role=DEFAULT_ADMIN_ROLE", "hasRole false → true")
+	out = env.mustRun(nil, "audit", "verify", "--audit-log", logPath)
+	assertContains(t, out, "Audit log is intact: 3 record(s)")
+}
+
+func TestAuditLogUnconfirmed(t *testing.T) {
+	env := newTestEnv(t)
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	// The transaction is sent to a chain that doesn't mine it before the command times out
+	stalled := gatertest.NewChain(t)
+	_, err := env.run(stalled.AdminKey, "mint", "3", "--to", stalled.User.Hex(), "--rpc", stalled.IPCPath,
+		"--deposit-contract", stalled.DepositContract.Hex(), "--timeout", "2s", "--audit-log", logPath, "--yes")
+	assertError(t, err, "failed to wait for transaction")
+
+	out := env.mustRun(nil, "audit", "show", "--audit-log", logPath, "--output", "json")
+	var records []*auditRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(records) != 1 || records[0].Status != auditStatusUnconfirmed || records[0].TxHash == "" || records[0].Method != "mint" || records[0].Before == nil {
+		t.Fatalf("unexpected audit records: %s", out)
+	}
+
+	// It is still mined later
+	stalled.Backend.Commit()
+	if _, err := stalled.Client.TransactionReceipt(t.Context(), common.HexToHash(records[0].TxHash)); err != nil {
+		t.Errorf("unconfirmed transaction was not mined: %v", err)
+	}
+	out = env.mustRun(nil, "audit", "show", "--audit-log", logPath)
+	assertContains(t, out, "unconfirmed", records[0].TxHash+" (not confirmed)")
+}
+
+func TestAuditVerifyTampering(t *testing.T) {
+	env := newTestEnv(t)
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	env.mustRun(env.chain.AdminKey, "mint", "3", "--to", env.chain.User.Hex(), "--audit-log", logPath, "--yes")
+	env.mustRun(env.chain.AdminKey, "mint", "4", "--to", env.chain.User.Hex(), "--audit-log", logPath, "--yes")
+
+	raw, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	lines := strings.SplitAfter(strings.TrimSpace(string(raw)), "\n")
+
+	// Modified record
+	os.WriteFile(logPath, []byte(strings.Replace(string(raw), `"operator":"`, `"operator":"mallory`, 1)), 0o600)
+	_, err = env.run(nil, "audit", "verify", "--audit-log", logPath)
+	assertError(t, err, "record #1 was modified")
+
+	// Removed record
+	os.WriteFile(logPath, []byte(lines[1]+"\n"), 0o600)
+	_, err = env.run(nil, "audit", "verify", "--audit-log", logPath)
+	assertError(t, err, "record 1 has sequence number 2")
+}
@@ -0,0 +1,494 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autorefillConfigPath string
+	autorefillInterval   time.Duration
+	autorefillLogPath    string
+	autorefillDryRun     bool
+	autorefillOnce       bool
+)
+
+// refillCapWindow is the time window of the daily caps.
+const refillCapWindow = 24 * time.Hour
+
+var autorefillCmd = &cobra.Command{
+	Use:   "autorefill",
+	Short: "Keep the token balances of addresses topped up",
+	Long: `Watches the token balances of the addresses in a refill config and mints tokens
+back to their target balance whenever a balance drops below its threshold (e.g.
+after deposits burned tokens).
+
+Example refill config (YAML or JSON):
+
+  dailyCap: 100          # tokens per 24h over all addresses (optional)
+  targets:
+    - name: ci-validators
+      address: "0x..."
+      threshold: 5       # refill when the balance drops below this
+      target: 20         # mint back up to this balance
+      dailyCap: 50       # tokens per 24h for this address (optional)
+
+Mints are capped by the daily caps within a rolling 24h window. Every mint is
+appended to --log, which is loaded on restart, so the caps survive restarts.
+A mint that was sent but not confirmed (e.g. a timeout) counts towards the caps,
+and its address isn't refilled again until its transaction is found or the
+mint leaves the cap window.
+With --dry-run, the balances are checked and the mints that would be sent are
+printed, without sending anything.
+
+Note that --config is the refill config here; the CLI config file is read from
+its default location.`,
+	Args: cobra.NoArgs,
+	RunE: runAutorefill,
+}
+
+func init() {
+	autorefillCmd.Flags().StringVar(&autorefillConfigPath, "config", "", "Path to the refill config (YAML or JSON, required)")
+	autorefillCmd.Flags().DurationVar(&autorefillInterval, "interval", time.Minute, "Interval between balance checks")
+	autorefillCmd.Flags().StringVar(&autorefillLogPath, "log", "", "Mint log file (default: autorefill-log-<chain ID>.jsonl next to the config file)")
+	autorefillCmd.Flags().BoolVar(&autorefillDryRun, "dry-run", false, "Print the mints that would be sent without sending them")
+	autorefillCmd.Flags().BoolVar(&autorefillOnce, "once", false, "Check the balances once and exit")
+}
+
+// refillConfig is the content of a refill config file.
+type refillConfig struct {
+	DailyCap uint64             `yaml:"dailyCap" json:"dailyCap"`
+	Targets  []refillTargetSpec `yaml:"targets" json:"targets"`
+}
+
+// refillTargetSpec is an address to keep topped up, as declared in the refill config.
+type refillTargetSpec struct {
+	Name      string `yaml:"name" json:"name"`
+	Address   string `yaml:"address" json:"address"`
+	Threshold uint64 `yaml:"threshold" json:"threshold"`
+	Target    uint64 `yaml:"target" json:"target"`
+	DailyCap  uint64 `yaml:"dailyCap" json:"dailyCap"`
+}
+
+// refillTarget is a validated refill target.
+type refillTarget struct {
+	name      string
+	address   common.Address
+	threshold *big.Int
+	target    *big.Int
+	dailyCap  uint64 // 0 for unlimited
+}
+
+// label returns the name and address of the target.
+func (target *refillTarget) label() string {
+	if target.name == "" {
+		return target.address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", target.name, target.address.Hex())
+}
+
+// Refill log states.
+const (
+	refillStatusMinted = "minted"
+	refillStatusFailed = "failed"
+	// refillStatusUnconfirmed is a mint that was sent, but not confirmed. It may still be included.
+	refillStatusUnconfirmed = "unconfirmed"
+)
+
+// refillRecord is a mint of the refiller, as stored in the mint log.
+type refillRecord struct {
+	Time    time.Time `json:"time"`
+	Name    string    `json:"name,omitempty"`
+	Address string    `json:"address"`
+	Balance string    `json:"balance"`
+	Amount  string    `json:"amount"`
+	Status  string    `json:"status"`
+	TxHash  string    `json:"txHash,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// tokenRefiller checks the balances of the refill targets and mints them back to their target.
+type tokenRefiller struct {
+	targets  []*refillTarget
+	dailyCap uint64          // 0 for unlimited
+	records  []*refillRecord // mints within the cap window
+	history  *os.File        // nil in dry-run mode
+}
+
+func runAutorefill(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if autorefillConfigPath == "" {
+		return fmt.Errorf("refill config is required (use --config)")
+	}
+	config, err := loadRefillConfig(autorefillConfigPath)
+	if err != nil {
+		return err
+	}
+	targets, err := parseRefillTargets(config)
+	if err != nil {
+		return err
+	}
+
+	if gaterAddr == (common.Address{}) {
+		return fmt.Errorf("no gating contract configured on deposit contract")
+	}
+	if !autorefillDryRun {
+		if err := checkAdminRole(ctx); err != nil {
+			return err
+		}
+	}
+
+	logPath := autorefillLogPath
+	if logPath == "" {
+		if logPath, err = chainDataPath("autorefill-log", "jsonl"); err != nil {
+			return err
+		}
+	}
+	refiller, err := newTokenRefiller(targets, config.DailyCap, logPath, autorefillDryRun)
+	if err != nil {
+		return err
+	}
+	if refiller.history != nil {
+		defer refiller.history.Close()
+	}
+
+	// The refiller mints without asking, so confirm once before starting
+	if !autorefillDryRun {
+		if !assumeYes {
+			fmt.Println()
+			printHeader("═══ Confirm Autorefill ═══")
+			printTransactionContext()
+			for _, target := range targets {
+				fmt.Printf("%sTarget:%s            %s: refill below %s up to %s tokens\n",
+					colorCyan, colorReset, target.label(), target.threshold.String(), target.target.String())
+			}
+			fmt.Println()
+		}
+		confirmed, err := requireConfirmation(fmt.Sprintf("Mint tokens to refill balances on %s", networkLabel()))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("autorefill not confirmed")
+		}
+		batchConfirmed = true
+		defer endTransactionBatch()
+	}
+
+	if autorefillOnce {
+		return refiller.check(ctx)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.WithFields(map[string]interface{}{
+		"targets":  len(targets),
+		"interval": autorefillInterval.String(),
+		"log":      logPath,
+		"dryRun":   autorefillDryRun,
+	}).Info("Watching token balances")
+
+	ticker := time.NewTicker(autorefillInterval)
+	defer ticker.Stop()
+	for {
+		if err := refiller.check(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.WithError(err).Warn("Failed to check balances")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadRefillConfig reads a refill config file.
+func loadRefillConfig(path string) (*refillConfig, error) {
+	config := &refillConfig{}
+	if err := loadYAMLOrJSON(path, "refill config", config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseRefillTargets validates the targets of a refill config.
+func parseRefillTargets(config *refillConfig) ([]*refillTarget, error) {
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("refill config has no targets")
+	}
+
+	seen := map[common.Address]bool{}
+	targets := make([]*refillTarget, 0, len(config.Targets))
+	for _, spec := range config.Targets {
+		if !common.IsHexAddress(spec.Address) {
+			return nil, fmt.Errorf("invalid refill target address: %s", spec.Address)
+		}
+		address := common.HexToAddress(spec.Address)
+		if seen[address] {
+			return nil, fmt.Errorf("duplicate refill target: %s", address.Hex())
+		}
+		seen[address] = true
+
+		if spec.Target == 0 {
+			return nil, fmt.Errorf("refill target %s: target must be at least 1", address.Hex())
+		}
+		if spec.Threshold == 0 || spec.Threshold > spec.Target {
+			return nil, fmt.Errorf("refill target %s: threshold must be between 1 and the target (%d)", address.Hex(), spec.Target)
+		}
+		targets = append(targets, &refillTarget{
+			name:      strings.TrimSpace(spec.Name),
+			address:   address,
+			threshold: new(big.Int).SetUint64(spec.Threshold),
+			target:    new(big.Int).SetUint64(spec.Target),
+			dailyCap:  spec.DailyCap,
+		})
+	}
+	return targets, nil
+}
+
+// newTokenRefiller loads the mint log and opens it for appending. In dry-run mode, the
+// log is only read.
+func newTokenRefiller(targets []*refillTarget, dailyCap uint64, logPath string, dryRun bool) (*tokenRefiller, error) {
+	refiller := &tokenRefiller{
+		targets:  targets,
+		dailyCap: dailyCap,
+	}
+
+	if file, err := os.Open(logPath); err == nil {
+		byTx := map[string]*refillRecord{}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := &refillRecord{}
+			if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("failed to parse autorefill log %s: %w", logPath, err)
+			}
+			// A later record of the same transaction updates its status (see resolveUnconfirmed)
+			if existing, ok := byTx[record.TxHash]; ok && record.TxHash != "" {
+				*existing = *record
+				continue
+			}
+			byTx[record.TxHash] = record
+			refiller.records = append(refiller.records, record)
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read autorefill log %s: %w", logPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to open autorefill log: %w", err)
+	}
+
+	if dryRun {
+		return refiller, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create autorefill log directory: %w", err)
+	}
+	history, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open autorefill log: %w", err)
+	}
+	refiller.history = history
+	return refiller, nil
+}
+
+// check reads the balances of all targets at the latest block and refills the ones below
+// their threshold. A failed mint is logged and doesn't stop the other targets.
+//
+// Unconfirmed mints are resolved before the balances are read: a mint found to be included
+// is part of the balances, and a mint included after it was checked is skipped as unconfirmed,
+// so it is never counted as both missing from the balance and still pending.
+func (refiller *tokenRefiller) check(ctx context.Context) error {
+	refiller.forgetExpired()
+	unconfirmed := refiller.resolveUnconfirmed(ctx)
+
+	latest, err := gaterClient.AtLatest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	balances := make([]*big.Int, len(refiller.targets))
+	calls := make([]*gater.Call, len(refiller.targets))
+	for i, target := range refiller.targets {
+		calls[i] = gater.NewCall(&balances[i], "balanceOf", target.address)
+	}
+	if err := latest.Batch(ctx, calls); err != nil {
+		return fmt.Errorf("failed to read balances: %w", err)
+	}
+
+	for i, target := range refiller.targets {
+		balance := balances[i]
+		if balance.Cmp(target.threshold) >= 0 {
+			continue
+		}
+		if tx, ok := unconfirmed[target.address]; ok {
+			log.WithFields(map[string]interface{}{
+				"target": target.label(),
+				"tx":     tx,
+			}).Warn("Previous refill is not confirmed yet, skipping")
+			continue
+		}
+
+		amount := new(big.Int).Sub(target.target, balance)
+		if capped := refiller.capAmount(target, amount); capped.Cmp(amount) < 0 {
+			log.WithFields(map[string]interface{}{
+				"target": target.label(),
+				"needed": amount.String(),
+				"capped": capped.String(),
+			}).Warn("Daily cap reached, refilling less than needed")
+			amount = capped
+		}
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		if autorefillDryRun {
+			printInfo("[dry run] Would mint %s tokens to %s (balance %s, threshold %s, target %s)",
+				amount.String(), target.label(), balance.String(), target.threshold.String(), target.target.String())
+			continue
+		}
+		refiller.refill(ctx, target, balance, amount)
+	}
+	return nil
+}
+
+// refill mints tokens to a target and appends the mint to the log.
+func (refiller *tokenRefiller) refill(ctx context.Context, target *refillTarget, balance, amount *big.Int) {
+	record := &refillRecord{
+		Time:    time.Now().UTC(),
+		Name:    target.name,
+		Address: target.address.Hex(),
+		Balance: balance.String(),
+		Amount:  amount.String(),
+		Status:  refillStatusMinted,
+	}
+	receipt, err := mintTokens(ctx, target.address, amount)
+	var unconfirmed *gater.UnconfirmedError
+	if errors.As(err, &unconfirmed) {
+		log.WithError(err).WithField("target", target.label()).Warn("Refill was sent but not confirmed, it counts towards the daily caps")
+		record.Status = refillStatusUnconfirmed
+		record.TxHash = unconfirmed.Tx.Hash().Hex()
+		record.Error = err.Error()
+	} else if err != nil {
+		log.WithError(err).WithField("target", target.label()).Warn("Refill failed")
+		record.Status = refillStatusFailed
+		record.Error = err.Error()
+	} else {
+		record.TxHash = receipt.TxHash.Hex()
+		printSuccess("Minted %s tokens to %s (balance was %s) in %s", amount.String(), target.label(), balance.String(), record.TxHash)
+	}
+
+	refiller.records = append(refiller.records, record)
+	refiller.writeRecord(record)
+}
+
+// writeRecord appends a record to the mint log. In dry-run mode, nothing is written.
+func (refiller *tokenRefiller) writeRecord(record *refillRecord) {
+	if refiller.history == nil {
+		return
+	}
+	line, _ := json.Marshal(record)
+	if _, err := refiller.history.Write(append(line, '\n')); err != nil {
+		log.WithError(err).Error("Failed to write autorefill log")
+	}
+}
+
+// resolveUnconfirmed checks the receipts of the unconfirmed mints within the cap window and
+// updates their status. The new status is appended to the log as a copy of the record, which
+// replaces the unconfirmed record when the log is loaded. It returns the transactions of the
+// mints that are still unconfirmed, by address.
+func (refiller *tokenRefiller) resolveUnconfirmed(ctx context.Context) map[common.Address]string {
+	pending := map[common.Address]string{}
+	for _, record := range refiller.records {
+		if record.Status != refillStatusUnconfirmed {
+			continue
+		}
+		receipt, err := ethClient.TransactionReceipt(ctx, common.HexToHash(record.TxHash))
+		switch {
+		case err != nil:
+			if !errors.Is(err, ethereum.NotFound) {
+				log.WithError(err).WithField("tx", record.TxHash).Warn("Failed to check unconfirmed refill")
+			}
+			pending[common.HexToAddress(record.Address)] = record.TxHash
+		case receipt.Status == types.ReceiptStatusFailed:
+			record.Status = refillStatusFailed
+			record.Error = fmt.Sprintf("transaction %s reverted", record.TxHash)
+			refiller.writeRecord(record)
+		default:
+			record.Status = refillStatusMinted
+			record.Error = ""
+			refiller.writeRecord(record)
+		}
+	}
+	return pending
+}
+
+// forgetExpired drops the records outside the cap window.
+func (refiller *tokenRefiller) forgetExpired() {
+	cutoff := time.Now().Add(-refillCapWindow)
+	records := refiller.records[:0]
+	for _, record := range refiller.records {
+		if record.Time.After(cutoff) {
+			records = append(records, record)
+		}
+	}
+	refiller.records = records
+}
+
+// capAmount limits a refill to what is left of the daily caps of the target and of all targets.
+func (refiller *tokenRefiller) capAmount(target *refillTarget, amount *big.Int) *big.Int {
+	total, forTarget := new(big.Int), new(big.Int)
+	for _, record := range refiller.records {
+		// Unconfirmed mints may still be included, so they count
+		if record.Status == refillStatusFailed {
+			continue
+		}
+		minted, ok := new(big.Int).SetString(record.Amount, 10)
+		if !ok {
+			continue
+		}
+		total.Add(total, minted)
+		if strings.EqualFold(record.Address, target.address.Hex()) {
+			forTarget.Add(forTarget, minted)
+		}
+	}
+
+	capped := new(big.Int).Set(amount)
+	limit := func(dailyCap uint64, used *big.Int) {
+		if dailyCap == 0 {
+			return
+		}
+		left := new(big.Int).Sub(new(big.Int).SetUint64(dailyCap), used)
+		if left.Sign() < 0 {
+			left.SetInt64(0)
+		}
+		if left.Cmp(capped) < 0 {
+			capped.Set(left)
+		}
+	}
+	limit(target.dailyCap, forTarget)
+	limit(refiller.dailyCap, total)
+	return capped
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// writeRefillConfig writes a refill config with a per-address cap of 7 on the first target.
+func writeRefillConfig(t *testing.T, path string, first, second common.Address, firstTarget int) {
+	t.Helper()
+	config := fmt.Sprintf(`dailyCap: 100
+targets:
+  - name: ci
+    address: "%s"
+    threshold: 2
+    target: %d
+    dailyCap: 7
+  - address: "%s"
+    threshold: 1
+    target: 3
+`, first.Hex(), firstTarget, second.Hex())
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("failed to write refill config: %v", err)
+	}
+}
+
+// readRefillLog returns the records of the mint log.
+func readRefillLog(t *testing.T, path string) []*refillRecord {
+	t.Helper()
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("failed to open autorefill log: %v", err)
+	}
+	defer file.Close()
+	records := []*refillRecord{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &refillRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatalf("invalid log line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAutorefill(t *testing.T) {
+	env := newTestEnv(t)
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "refill.yaml")
+	logPath := filepath.Join(dir, "refill.jsonl")
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	second := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	writeRefillConfig(t, configFile, first, second, 5)
+
+	// A dry run sends and logs nothing
+	out := env.mustRun(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--dry-run")
+	assertContains(t, out, "Would mint 5 tokens to ci ("+first.Hex()+")", "Would mint 3 tokens to "+second.Hex())
+	if balance := env.balanceOf(first); balance.Sign() != 0 {
+		t.Errorf("balance after dry run = %s, want 0", balance)
+	}
+	if records := readRefillLog(t, logPath); len(records) != 0 {
+		t.Errorf("dry run wrote %d log records", len(records))
+	}
+
+	env.mustRun(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--yes")
+	if balance := env.balanceOf(first); balance.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("first balance = %s, want 5", balance)
+	}
+	if balance := env.balanceOf(second); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("second balance = %s, want 3", balance)
+	}
+	records := readRefillLog(t, logPath)
+	if len(records) != 2 || records[0].Status != refillStatusMinted || records[0].Name != "ci" || records[0].Amount != "5" || records[0].TxHash == "" {
+		t.Fatalf("unexpected log records: %+v", records)
+	}
+
+	// Balances above the threshold are left alone
+	out = env.mustRun(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--dry-run")
+	if out != "" {
+		t.Errorf("unexpected output for balances above threshold:\n%s", out)
+	}
+}
+
+func TestAutorefillDailyCap(t *testing.T) {
+	env := newTestEnv(t)
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "refill.yaml")
+	logPath := filepath.Join(dir, "refill.jsonl")
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	second := common.HexToAddress("0x1000000000000000000000000000000000000002")
+
+	// 5 tokens minted earlier today count towards the cap of 7
+	record, _ := json.Marshal(refillRecord{Time: time.Now().UTC(), Address: first.Hex(), Balance: "0", Amount: "5", Status: refillStatusMinted})
+	if err := os.WriteFile(logPath, append(record, '\n'), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	writeRefillConfig(t, configFile, first, second, 5)
+
+	env.mustRun(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--yes")
+	if balance := env.balanceOf(first); balance.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("capped balance = %s, want 2", balance)
+	}
+	records := readRefillLog(t, logPath)
+	if len(records) != 3 || records[1].Amount != "2" {
+		t.Errorf("unexpected log records: %+v", records)
+	}
+}
+
+func TestAutorefillUnconfirmed(t *testing.T) {
+	env := newTestEnv(t)
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "refill.yaml")
+	logPath := filepath.Join(dir, "refill.jsonl")
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	second := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	writeRefillConfig(t, configFile, first, second, 5)
+
+	// A refill of the first target was sent, but is still unknown; one of the second was included later
+	receipt, err := env.adminGater().Mint(t.Context(), second, big.NewInt(3))
+	if err != nil {
+		t.Fatalf("mint failed: %v", err)
+	}
+	var lines []byte
+	for _, record := range []refillRecord{
+		{Time: time.Now().UTC(), Address: first.Hex(), Balance: "0", Amount: "5", Status: refillStatusUnconfirmed, TxHash: common.HexToHash("0x01").Hex()},
+		{Time: time.Now().UTC(), Address: second.Hex(), Balance: "0", Amount: "3", Status: refillStatusUnconfirmed, TxHash: receipt.TxHash.Hex()},
+	} {
+		line, _ := json.Marshal(record)
+		lines = append(append(lines, line...), '\n')
+	}
+	if err := os.WriteFile(logPath, lines, 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	env.mustRun(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--yes")
+	if balance := env.balanceOf(first); balance.Sign() != 0 {
+		t.Errorf("balance with unconfirmed refill = %s, want 0", balance)
+	}
+	// The included mint is recorded with its new status
+	records := readRefillLog(t, logPath)
+	if len(records) != 3 || records[2].TxHash != receipt.TxHash.Hex() || records[2].Status != refillStatusMinted {
+		t.Errorf("unexpected log records: %+v", records)
+	}
+
+	// After a restart, the included mint is known, and the unconfirmed mint counts towards the daily cap of 7
+	refiller, err := newTokenRefiller(nil, 0, logPath, true)
+	if err != nil {
+		t.Fatalf("failed to load log: %v", err)
+	}
+	if len(refiller.records) != 2 || refiller.records[0].Status != refillStatusUnconfirmed || refiller.records[1].Status != refillStatusMinted {
+		t.Errorf("unexpected loaded records: %+v", refiller.records)
+	}
+	target := &refillTarget{address: first, dailyCap: 7}
+	if capped := refiller.capAmount(target, big.NewInt(5)); capped.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("capped amount = %s, want 2", capped)
+	}
+}
+
+func TestAutorefillErrors(t *testing.T) {
+	env := newTestEnv(t)
+	configFile := filepath.Join(t.TempDir(), "refill.yaml")
+	logPath := filepath.Join(t.TempDir(), "refill.jsonl")
+
+	_, err := env.run(env.chain.AdminKey, "autorefill", "--once")
+	assertError(t, err, "refill config is required")
+
+	os.WriteFile(configFile, []byte("targets:\n  - address: \"0x1000000000000000000000000000000000000001\"\n    threshold: 5\n    target: 2\n"), 0o600)
+	_, err = env.run(env.chain.AdminKey, "autorefill", "--config", configFile, "--once")
+	assertError(t, err, "threshold must be between 1 and the target")
+
+	writeRefillConfig(t, configFile, common.HexToAddress("0x01"), common.HexToAddress("0x02"), 5)
+	_, err = env.run(env.chain.UserKey, "autorefill", "--config", configFile, "--log", logPath, "--once", "--yes")
+	assertError(t, err, "does not have admin role")
+
+	_, err = env.run(env.chain.AdminKey, "autorefill", "--config", configFile, "--log", logPath, "--once")
+	assertError(t, err, "refusing to send transactions without confirmation")
+}
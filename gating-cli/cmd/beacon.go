@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// beaconGenesis is the genesis information reported by a beacon node.
+type beaconGenesis struct {
+	GenesisTime           uint64
+	GenesisValidatorsRoot common.Hash
+	GenesisForkVersion    [4]byte
+}
+
+// fetchBeaconGenesis queries /eth/v1/beacon/genesis of a beacon node.
+func fetchBeaconGenesis(ctx context.Context, beaconURL string) (*beaconGenesis, error) {
+	url := strings.TrimRight(beaconURL, "/") + "/eth/v1/beacon/genesis"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query beacon node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("beacon node returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data struct {
+			GenesisTime           string `json:"genesis_time"`
+			GenesisValidatorsRoot string `json:"genesis_validators_root"`
+			GenesisForkVersion    string `json:"genesis_fork_version"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode beacon genesis: %w", err)
+	}
+
+	genesisTime, err := strconv.ParseUint(response.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis time: %w", err)
+	}
+	forkVersion, err := parseForkVersion(response.Data.GenesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &beaconGenesis{
+		GenesisTime:           genesisTime,
+		GenesisValidatorsRoot: common.HexToHash(response.Data.GenesisValidatorsRoot),
+		GenesisForkVersion:    forkVersion,
+	}, nil
+}
+
+// parseForkVersion parses a 4 byte fork version (hex, with or without 0x prefix).
+func parseForkVersion(input string) ([4]byte, error) {
+	var version [4]byte
+	data, err := decodeHexField("fork version", input, 4)
+	if err != nil {
+		return version, err
+	}
+	copy(version[:], data)
+	return version, nil
+}
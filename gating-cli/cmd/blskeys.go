@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The BLS keys are handled with gnark-crypto instead of blst, as blst requires cgo
+// and the release binaries are built with CGO_ENABLED=0.
+
+// blsSignatureDST is the domain separation tag of the Ethereum consensus BLS signature scheme.
+var blsSignatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// domainDeposit is the signature domain type of deposit messages.
+var domainDeposit = [4]byte{0x03, 0x00, 0x00, 0x00}
+
+// blsKey is a BLS12-381 secret key with its compressed public key.
+type blsKey struct {
+	secret *big.Int
+	pubkey [48]byte
+}
+
+// newBLSKey creates a key from a secret scalar.
+func newBLSKey(secret *big.Int) *blsKey {
+	var pubkey bls12381.G1Affine
+	pubkey.ScalarMultiplicationBase(secret)
+	return &blsKey{
+		secret: secret,
+		pubkey: pubkey.Bytes(),
+	}
+}
+
+// sign signs a 32 byte signing root and returns the compressed signature.
+func (key *blsKey) sign(signingRoot common.Hash) ([96]byte, error) {
+	point, err := bls12381.HashToG2(signingRoot[:], blsSignatureDST)
+	if err != nil {
+		return [96]byte{}, fmt.Errorf("failed to hash to curve: %w", err)
+	}
+	var signature bls12381.G2Affine
+	signature.ScalarMultiplication(&point, key.secret)
+	return signature.Bytes(), nil
+}
+
+// mnemonicToSeed derives the BIP-39 seed from a mnemonic.
+// The mnemonic is expected to be NFKD normalized already, which is always the case for english mnemonics.
+func mnemonicToSeed(mnemonic string, password string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("invalid mnemonic: expected 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+	return pbkdf2.Key(sha512.New, strings.Join(words, " "), []byte("mnemonic"+password), 2048, 64)
+}
+
+// deriveBLSKey derives the key at an EIP-2334 path (e.g. m/12381/3600/0/0/0) from a seed using EIP-2333.
+func deriveBLSKey(seed []byte, path string) (*blsKey, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 1 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid key path: %s", path)
+	}
+
+	secret, err := deriveMasterSK(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts[1:] {
+		var index uint32
+		if _, err := fmt.Sscanf(part, "%d", &index); err != nil {
+			return nil, fmt.Errorf("invalid key path segment %q: %w", part, err)
+		}
+		if secret, err = deriveChildSK(secret, index); err != nil {
+			return nil, err
+		}
+	}
+	return newBLSKey(secret), nil
+}
+
+// signingKeyPath returns the EIP-2334 signing key path of a validator.
+func signingKeyPath(index uint32) string {
+	return fmt.Sprintf("m/12381/3600/%d/0/0", index)
+}
+
+// withdrawalKeyPath returns the EIP-2334 withdrawal key path of a validator.
+func withdrawalKeyPath(index uint32) string {
+	return fmt.Sprintf("m/12381/3600/%d/0", index)
+}
+
+// deriveMasterSK implements EIP-2333 derive_master_SK.
+func deriveMasterSK(seed []byte) (*big.Int, error) {
+	if len(seed) < 32 {
+		return nil, fmt.Errorf("seed must be at least 32 bytes")
+	}
+	return hkdfModR(seed)
+}
+
+// deriveChildSK implements EIP-2333 derive_child_SK.
+func deriveChildSK(parentSK *big.Int, index uint32) (*big.Int, error) {
+	lamportPK, err := parentSKToLamportPK(parentSK, index)
+	if err != nil {
+		return nil, err
+	}
+	return hkdfModR(lamportPK)
+}
+
+// hkdfModR implements EIP-2333 HKDF_mod_r with an empty key_info.
+func hkdfModR(ikm []byte) (*big.Int, error) {
+	const l = 48
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	secret := new(big.Int)
+	for secret.Sign() == 0 {
+		hashedSalt := sha256.Sum256(salt)
+		salt = hashedSalt[:]
+
+		prk, err := hkdf.Extract(sha256.New, append(append([]byte{}, ikm...), 0), salt)
+		if err != nil {
+			return nil, err
+		}
+		okm, err := hkdf.Expand(sha256.New, prk, string([]byte{0, l}), l)
+		if err != nil {
+			return nil, err
+		}
+		secret.SetBytes(okm)
+		secret.Mod(secret, fr.Modulus())
+	}
+	return secret, nil
+}
+
+// parentSKToLamportPK implements EIP-2333 parent_SK_to_lamport_PK.
+func parentSKToLamportPK(parentSK *big.Int, index uint32) ([]byte, error) {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+
+	ikm := make([]byte, 32)
+	parentSK.FillBytes(ikm)
+	notIKM := make([]byte, 32)
+	for i := range ikm {
+		notIKM[i] = ^ikm[i]
+	}
+
+	lamport0, err := ikmToLamportSK(ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	lamport1, err := ikmToLamportSK(notIKM, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	lamportPK := make([]byte, 0, 2*255*32)
+	for _, chunk := range append(lamport0, lamport1...) {
+		hashed := sha256.Sum256(chunk)
+		lamportPK = append(lamportPK, hashed[:]...)
+	}
+	compressed := sha256.Sum256(lamportPK)
+	return compressed[:], nil
+}
+
+// ikmToLamportSK implements EIP-2333 IKM_to_lamport_SK.
+func ikmToLamportSK(ikm []byte, salt []byte) ([][]byte, error) {
+	okm, err := hkdf.Key(sha256.New, ikm, salt, "", 32*255)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([][]byte, 255)
+	for i := range chunks {
+		chunks[i] = okm[i*32 : (i+1)*32]
+	}
+	return chunks, nil
+}
+
+// depositMessageRoot computes the hash tree root of a DepositMessage (amount in gwei).
+func depositMessageRoot(pubkey, withdrawalCredentials []byte, amount uint64) common.Hash {
+	var amountLeaf [32]byte
+	binary.LittleEndian.PutUint64(amountLeaf[:], amount)
+
+	pubkeyRoot := sha256Concat(pubkey, make([]byte, 16))
+	left := sha256Concat(pubkeyRoot[:], withdrawalCredentials)
+	right := sha256Concat(amountLeaf[:], make([]byte, 32))
+	return sha256Concat(left[:], right[:])
+}
+
+// computeDepositDomain computes the deposit signature domain for a genesis fork version.
+// Deposits are always signed with an empty genesis validators root, so they stay valid across forks.
+func computeDepositDomain(forkVersion [4]byte) [32]byte {
+	var versionLeaf [32]byte
+	copy(versionLeaf[:], forkVersion[:])
+	forkDataRoot := sha256Concat(versionLeaf[:], make([]byte, 32))
+
+	var domain [32]byte
+	copy(domain[:4], domainDeposit[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain
+}
+
+// computeSigningRoot computes the signing root of an object root in a domain.
+func computeSigningRoot(objectRoot common.Hash, domain [32]byte) common.Hash {
+	return sha256Concat(objectRoot[:], domain[:])
+}
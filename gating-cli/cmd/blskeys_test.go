@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	// BIP-39 test vector, also the seed of EIP-2333 test case 0
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+	seed, err := mnemonicToSeed(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("mnemonicToSeed failed: %v", err)
+	}
+	if expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"; common.Bytes2Hex(seed) != expected {
+		t.Errorf("got seed %x, want %s", seed, expected)
+	}
+}
+
+func TestEIP2333(t *testing.T) {
+	// Test vectors of EIP-2333
+	for _, test := range []struct {
+		seed     string
+		masterSK string
+		index    uint32
+		childSK  string
+	}{
+		{
+			seed:     "0xc55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			masterSK: "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			index:    0,
+			childSK:  "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		},
+		{
+			seed:     "0x3141592653589793238462643383279502884197169399375105820974944592",
+			masterSK: "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			index:    3141592653,
+			childSK:  "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+		{
+			seed:     "0x0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00",
+			masterSK: "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+			index:    4294967295,
+			childSK:  "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+		},
+		{
+			seed:     "0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			masterSK: "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+			index:    42,
+			childSK:  "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+		},
+	} {
+		masterSK, err := deriveMasterSK(common.FromHex(test.seed))
+		if err != nil {
+			t.Fatalf("deriveMasterSK failed: %v", err)
+		}
+		if masterSK.String() != test.masterSK {
+			t.Errorf("seed %s: got master SK %s, want %s", test.seed, masterSK, test.masterSK)
+		}
+		childSK, err := deriveChildSK(masterSK, test.index)
+		if err != nil {
+			t.Fatalf("deriveChildSK failed: %v", err)
+		}
+		if childSK.String() != test.childSK {
+			t.Errorf("seed %s: got child SK %s at index %d, want %s", test.seed, childSK, test.index, test.childSK)
+		}
+	}
+}
+
+func TestBLSSign(t *testing.T) {
+	// Test vector of the consensus spec tests (bls/sign)
+	secret, _ := new(big.Int).SetString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3", 16)
+	key := newBLSKey(secret)
+	if expected := "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a"; common.Bytes2Hex(key.pubkey[:]) != expected {
+		t.Errorf("got pubkey %x, want %s", key.pubkey, expected)
+	}
+	signature, err := key.sign(common.Hash{})
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	if expected := "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"; common.Bytes2Hex(signature[:]) != expected {
+		t.Errorf("got signature %x, want %s", signature, expected)
+	}
+}
+
+func TestDepositSignature(t *testing.T) {
+	// Deposit domain of mainnet (genesis fork version 0x00000000)
+	domain := computeDepositDomain([4]byte{})
+	if expected := "03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"; common.Bytes2Hex(domain[:]) != expected {
+		t.Errorf("got deposit domain %x, want %s", domain, expected)
+	}
+
+	seed, err := mnemonicToSeed(strings.Repeat("abandon ", 11)+"about", "")
+	if err != nil {
+		t.Fatalf("mnemonicToSeed failed: %v", err)
+	}
+	key, err := deriveBLSKey(seed, signingKeyPath(0))
+	if err != nil {
+		t.Fatalf("deriveBLSKey failed: %v", err)
+	}
+	credentials := common.FromHex("0x010000000000000000000000deaddeaddeaddeaddeaddeaddeaddeaddeaddead")
+	signingRoot := computeSigningRoot(depositMessageRoot(key.pubkey[:], credentials, 32e9), domain)
+	signature, err := key.sign(signingRoot)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	// The signature verifies against the pubkey: e(pubkey, H(signing root)) == e(G1, signature)
+	var pubkeyPoint bls12381.G1Affine
+	var signaturePoint bls12381.G2Affine
+	if _, err := pubkeyPoint.SetBytes(key.pubkey[:]); err != nil {
+		t.Fatalf("invalid pubkey: %v", err)
+	}
+	if _, err := signaturePoint.SetBytes(signature[:]); err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+	messagePoint, err := bls12381.HashToG2(signingRoot[:], blsSignatureDST)
+	if err != nil {
+		t.Fatalf("failed to hash to curve: %v", err)
+	}
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	valid, err := bls12381.PairingCheck([]bls12381.G1Affine{pubkeyPoint, negG1}, []bls12381.G2Affine{messagePoint, signaturePoint})
+	if err != nil || !valid {
+		t.Errorf("deposit signature doesn't verify (err: %v)", err)
+	}
+
+	// The deposit data file entry is accepted with its deposit_data_root
+	dataRoot := depositDataRoot(key.pubkey[:], credentials, 32e9, signature[:])
+	entry := &depositDataEntry{
+		Pubkey:                common.Bytes2Hex(key.pubkey[:]),
+		WithdrawalCredentials: common.Bytes2Hex(credentials),
+		Amount:                32e9,
+		Signature:             common.Bytes2Hex(signature[:]),
+		DepositDataRoot:       common.Bytes2Hex(dataRoot[:]),
+	}
+	if _, err := parseDepositDataEntry(entry); err != nil {
+		t.Errorf("generated deposit is invalid: %v", err)
+	}
+}
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkSender      string
+	checkPubkey      string
+	checkCredentials string
+	checkSignature   string
+	checkAmount      uint64
+	checkFile        string
+)
+
+var checkDepositCmd = &cobra.Command{
+	Use:   "check-deposit",
+	Short: "Check whether a deposit will pass the gate",
+	Long: `Simulates TokenDepositGater.check_deposit via eth_call (with the deposit contract
+as caller) and explains the result: custom gater acceptance, top-up detection,
+blocked deposit types and missing tokens.
+
+Check a single deposit with --pubkey, --credentials, --signature and --amount,
+or all deposits of a deposit_data-*.json file with --file. Omitting both
+--credentials and --signature checks a top-up deposit (all-zero signature and
+withdrawal credentials).
+
+When checking a file, tokens burned by earlier deposits of the same sender are
+taken into account. The command fails if any deposit would be rejected.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runCheckDeposit,
+}
+
+func init() {
+	checkDepositCmd.Flags().StringVarP(&checkSender, "sender", "s", "", "Address sending the deposit (defaults to signer address)")
+	checkDepositCmd.Flags().StringVar(&checkPubkey, "pubkey", "", "Validator pubkey (hex)")
+	checkDepositCmd.Flags().StringVar(&checkCredentials, "credentials", "", "Withdrawal credentials (hex)")
+	checkDepositCmd.Flags().StringVar(&checkSignature, "signature", "", "Deposit signature (hex)")
+	checkDepositCmd.Flags().Uint64Var(&checkAmount, "amount", 32000000000, "Deposit amount in gwei")
+	checkDepositCmd.Flags().StringVarP(&checkFile, "file", "f", "", "Check all deposits of a deposit_data-*.json file")
+}
+
+// executionRevertedCode is the JSON-RPC error code of a call that reverted.
+const executionRevertedCode = 3
+
+// depositCheckResult describes whether and why a deposit passes the gate.
+type depositCheckResult struct {
+	Passed              bool
+	Reason              string
+	Config              gater.DepositGateConfig
+	CustomGaterAccepted bool
+	BurnsToken          bool
+}
+
+// depositCheckEnv holds the gater state shared by all checked deposits.
+type depositCheckEnv struct {
+	client       *gater.Client
+	blockNum     *big.Int
+	customGater  common.Address
+	roleGranted  bool
+	overrides    map[common.Address]gethclient.OverrideAccount
+	tokenBalance *big.Int
+	configs      map[uint16]gater.DepositGateConfig
+	callerCode   map[common.Address]bool // whether a caller has code at the pinned block
+}
+
+func runCheckDeposit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if gaterAddr == (common.Address{}) {
+		return fmt.Errorf("no gating contract configured on deposit contract")
+	}
+
+	// Determine sender
+	sender := signerAddress
+	if checkSender != "" {
+		if !common.IsHexAddress(checkSender) {
+			return fmt.Errorf("invalid sender address: %s", checkSender)
+		}
+		sender = common.HexToAddress(checkSender)
+	} else if sender == (common.Address{}) {
+		return fmt.Errorf("sender is required (use --sender or provide a private key)")
+	}
+
+	// Load deposits
+	var deposits []*validatorDeposit
+	if checkFile != "" {
+		loaded, err := loadDepositDataFile(checkFile)
+		if err != nil {
+			return err
+		}
+		deposits = loaded
+	} else {
+		deposit, err := parseCheckDepositFlags()
+		if err != nil {
+			return err
+		}
+		deposits = []*validatorDeposit{deposit}
+	}
+
+	env, err := newDepositCheckEnv(ctx, sender)
+	if err != nil {
+		return err
+	}
+
+	printHeader("═══ Deposit Check ═══")
+	fmt.Println()
+	fmt.Printf("%sSender:%s            %s\n", colorCyan, colorReset, sender.Hex())
+	fmt.Printf("%sGating Contract:%s   %s\n", colorCyan, colorReset, gaterAddr.Hex())
+	fmt.Printf("%sCustom Gater:%s      %s\n", colorCyan, colorReset, formatOptionalAddress(env.customGater))
+	fmt.Printf("%sToken Balance:%s     %s\n", colorCyan, colorReset, env.tokenBalance.String())
+	fmt.Printf("%sBlock:%s             %s\n", colorCyan, colorReset, env.blockNum.String())
+	fmt.Println()
+
+	if !env.roleGranted {
+		printError("Deposit contract %s does not have DEPOSIT_CONTRACT_ROLE on the gater, all deposits will revert.", depositAddr.Hex())
+		printInfo("Simulating with a state override that grants the role to explain the remaining checks.")
+		fmt.Println()
+	}
+
+	tokensUsed := int64(0)
+	failed := 0
+	for i, deposit := range deposits {
+		result, err := env.check(ctx, sender, deposit)
+		if err != nil {
+			return fmt.Errorf("failed to check deposit #%d: %w", i, err)
+		}
+
+		// Each simulation runs against the same state, so account for tokens burned by earlier deposits
+		if result.Passed && result.BurnsToken {
+			tokensUsed++
+			if env.tokenBalance.Cmp(big.NewInt(tokensUsed)) < 0 {
+				result.Passed = false
+				result.Reason = "not enough tokens: tokens are used up by earlier deposits in this batch"
+			}
+		}
+		if result.Passed && !env.roleGranted {
+			result.Passed = false
+			result.Reason = "deposit contract is not allowed to call the gater (missing DEPOSIT_CONTRACT_ROLE)"
+		}
+		if !result.Passed {
+			failed++
+		}
+
+		if len(deposits) == 1 {
+			printDepositCheckDetails(deposit, result)
+		} else {
+			printDepositCheckRow(i, deposit, result)
+		}
+	}
+
+	if len(deposits) > 1 {
+		fmt.Println()
+		fmt.Printf("%sPassing:%s           %d\n", colorCyan, colorReset, len(deposits)-failed)
+		fmt.Printf("%sFailing:%s           %d\n", colorCyan, colorReset, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deposits will not pass the gate", failed, len(deposits))
+	}
+	return nil
+}
+
+// parseCheckDepositFlags builds a deposit from the single-deposit flags.
+func parseCheckDepositFlags() (*validatorDeposit, error) {
+	if checkPubkey == "" {
+		return nil, fmt.Errorf("pubkey is required (use --pubkey or --file)")
+	}
+	pubkey, err := decodeHexField("pubkey", checkPubkey, 48)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]byte, 32)
+	signature := make([]byte, 96)
+	if checkCredentials != "" || checkSignature != "" {
+		if credentials, err = decodeHexField("withdrawal_credentials", checkCredentials, 32); err != nil {
+			return nil, err
+		}
+		if signature, err = decodeHexField("signature", checkSignature, 96); err != nil {
+			return nil, err
+		}
+	}
+
+	if checkAmount < minDepositAmount {
+		return nil, fmt.Errorf("amount %d gwei is below the minimum of 1 ETH", checkAmount)
+	}
+
+	return &validatorDeposit{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: credentials,
+		Amount:                checkAmount,
+		Signature:             signature,
+		DepositDataRoot:       depositDataRoot(pubkey, credentials, checkAmount, signature),
+		DepositType:           classifyDeposit(credentials, signature),
+	}, nil
+}
+
+// newDepositCheckEnv reads the gater state needed to check deposits of the sender.
+func newDepositCheckEnv(ctx context.Context, sender common.Address) (*depositCheckEnv, error) {
+	latest, err := gaterClient.AtLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &depositCheckEnv{
+		client:     latest,
+		blockNum:   latest.Block(),
+		configs:    map[uint16]gater.DepositGateConfig{},
+		overrides:  map[common.Address]gethclient.OverrideAccount{},
+		callerCode: map[common.Address]bool{},
+	}
+
+	if env.customGater, err = latest.CustomGater(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get custom gater: %w", err)
+	}
+	if env.tokenBalance, err = latest.BalanceOf(ctx, sender); err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+	if env.roleGranted, err = latest.HasRole(ctx, gater.DepositContractRole, depositAddr); err != nil {
+		return nil, fmt.Errorf("failed to check deposit contract role: %w", err)
+	}
+
+	if !env.roleGranted {
+		// SimpleAccessControl stores roles at key (12 byte role prefix | 20 byte account)
+		var roleKey common.Hash
+		copy(roleKey[:12], gater.DepositContractRole[:12])
+		copy(roleKey[12:], depositAddr[:])
+		env.overrides[gaterAddr] = gethclient.OverrideAccount{
+			StateDiff: map[common.Hash]common.Hash{roleKey: common.BigToHash(big.NewInt(1))},
+		}
+	}
+
+	return env, nil
+}
+
+// check simulates check_deposit for a single deposit and explains the result.
+func (env *depositCheckEnv) check(ctx context.Context, sender common.Address, deposit *validatorDeposit) (*depositCheckResult, error) {
+	config, ok := env.configs[deposit.DepositType]
+	if !ok {
+		var err error
+		config, err = env.client.DepositGateConfig(ctx, deposit.DepositType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config for deposit type 0x%04x: %w", deposit.DepositType, err)
+		}
+		env.configs[deposit.DepositType] = config
+	}
+
+	data, err := gater.ABI.Pack("check_deposit", sender, deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Signature, deposit.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack check_deposit call: %w", err)
+	}
+
+	result := &depositCheckResult{Config: config}
+
+	// The custom gater is asked first and skips all other checks if it accepts the deposit
+	if env.customGater != (common.Address{}) {
+		output, err := env.call(ctx, gaterAddr, env.customGater, data)
+		if err == nil {
+			var accepted bool
+			if err := gater.ABI.UnpackIntoInterface(&accepted, "check_deposit", output); err == nil && accepted {
+				result.CustomGaterAccepted = true
+			}
+		} else {
+			log.WithError(err).Debug("Custom gater rejected deposit")
+		}
+	}
+
+	_, err = env.call(ctx, depositAddr, gaterAddr, data)
+	if err != nil {
+		result.Reason = explainRevert(revertReason(err), deposit.DepositType)
+		return result, nil
+	}
+
+	result.Passed = true
+	switch {
+	case result.CustomGaterAccepted:
+		result.Reason = "accepted by custom gater"
+	case config.NoToken:
+		result.Reason = "accepted without token"
+	default:
+		result.BurnsToken = true
+		result.Reason = "accepted, burns 1 token"
+	}
+	return result, nil
+}
+
+// call runs an eth_call against the pinned block, retrying with the caller's code removed
+// for RPC clients that reject calls from contract addresses (EIP-3607).
+func (env *depositCheckEnv) call(ctx context.Context, from common.Address, to common.Address, data []byte) ([]byte, error) {
+	msg := ethereum.CallMsg{
+		From: from,
+		To:   &to,
+		Data: data,
+	}
+
+	overrides := env.overrides
+	for attempt := 0; attempt < 2; attempt++ {
+		var result []byte
+		var err error
+		if len(overrides) == 0 {
+			result, err = ethClient.CallContract(ctx, msg, env.blockNum)
+		} else {
+			result, err = ethClient.CallContractWithOverrides(ctx, msg, env.blockNum, &overrides)
+		}
+		if err == nil || !env.rejectedContractCaller(ctx, from, err, overrides) {
+			return result, err
+		}
+
+		log.WithError(err).Debug("RPC rejected call from contract address, retrying with state override")
+		overrides = make(map[common.Address]gethclient.OverrideAccount, len(env.overrides)+1)
+		for addr, override := range env.overrides {
+			overrides[addr] = override
+		}
+		fromOverride := overrides[from]
+		fromOverride.Code = []byte{}
+		overrides[from] = fromOverride
+	}
+	return nil, fmt.Errorf("RPC rejected call from %s", from.Hex())
+}
+
+// rejectedContractCaller reports whether a failed call may have been rejected because the
+// caller is a contract (EIP-3607): the call didn't revert, and the caller has code at the
+// pinned block that isn't overridden yet.
+func (env *depositCheckEnv) rejectedContractCaller(ctx context.Context, from common.Address, err error, overrides map[common.Address]gethclient.OverrideAccount) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == executionRevertedCode {
+		return false
+	}
+	if override, ok := overrides[from]; ok && override.Code != nil {
+		return false
+	}
+
+	hasCode, ok := env.callerCode[from]
+	if !ok {
+		code, codeErr := ethClient.CodeAt(ctx, from, env.blockNum)
+		if codeErr != nil {
+			log.WithError(codeErr).Debug("Failed to get code of caller")
+			return false
+		}
+		hasCode = len(code) > 0
+		env.callerCode[from] = hasCode
+	}
+	return hasCode
+}
+
+// explainRevert translates gater revert reasons into explanations.
+func explainRevert(reason string, depositType uint16) string {
+	switch reason {
+	case "Deposit type is blocked":
+		return fmt.Sprintf("deposit type %s is blocked", depositTypeLabel(depositType))
+	case "Not enough tokens":
+		return "sender holds no deposit token"
+	case "Only deposit contract can call this function":
+		return "deposit contract is not allowed to call the gater (missing DEPOSIT_CONTRACT_ROLE)"
+	case "Invalid withdrawal credentials":
+		return "invalid withdrawal credentials"
+	default:
+		return "reverted: " + reason
+	}
+}
+
+// printDepositCheckDetails prints a detailed explanation for a single deposit.
+func printDepositCheckDetails(deposit *validatorDeposit, result *depositCheckResult) {
+	typeDesc := depositTypeLabel(deposit.DepositType)
+	if deposit.DepositType == gater.TopUpDepositType {
+		typeDesc += " (all-zero signature and withdrawal credentials)"
+	}
+
+	fmt.Printf("%sPubkey:%s            0x%x\n", colorCyan, colorReset, deposit.Pubkey)
+	fmt.Printf("%sAmount:%s            %s\n", colorCyan, colorReset, formatGwei(deposit.Amount))
+	fmt.Printf("%sDeposit Type:%s      %s\n", colorCyan, colorReset, typeDesc)
+	fmt.Printf("%sBlocked:%s           %s\n", colorCyan, colorReset, formatBool(result.Config.Blocked))
+	fmt.Printf("%sNoToken:%s           %s\n", colorCyan, colorReset, formatBool(result.Config.NoToken))
+	if result.CustomGaterAccepted {
+		fmt.Printf("%sCustom Gater:%s      %saccepts this deposit%s\n", colorCyan, colorReset, colorGreen, colorReset)
+	}
+	fmt.Println()
+
+	if result.Passed {
+		printSuccess("PASS: %s", result.Reason)
+	} else {
+		fmt.Printf("%sFAIL: %s%s\n", colorRed, result.Reason, colorReset)
+	}
+}
+
+// printDepositCheckRow prints a single result line when checking a deposit file.
+func printDepositCheckRow(index int, deposit *validatorDeposit, result *depositCheckResult) {
+	status := colorGreen + "PASS" + colorReset
+	if !result.Passed {
+		status = colorRed + "FAIL" + colorReset
+	}
+	fmt.Printf("  #%-4d %-22s %-7s %s  %s\n", index, shortHex(deposit.Pubkey), depositTypeLabel(deposit.DepositType), status, result.Reason)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	checkPubkeyHex      = "0x" + strings.Repeat("11", 48)
+	checkCredentialsHex = "0x01" + strings.Repeat("00", 11) + strings.Repeat("11", 20)
+	checkSignatureHex   = "0x" + strings.Repeat("22", 96)
+)
+
+// startEOAOnlyProxy serves the JSON-RPC API of the simulated chain over HTTP and rejects
+// eth_call requests from contract addresses (EIP-3607) with a generic error, unless the
+// code of the caller is overridden. It returns the URL and the number of rejected calls.
+func startEOAOnlyProxy(t *testing.T, env *testEnv, contract common.Address) (string, *atomic.Int32) {
+	client, err := rpc.Dial(env.chain.IPCPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	type request struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	rejected := &atomic.Int32{}
+	handle := func(r *http.Request, req request) map[string]interface{} {
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if req.Method == "eth_call" && len(req.Params) > 0 {
+			var msg struct {
+				From common.Address `json:"from"`
+			}
+			var overrides map[common.Address]struct {
+				Code *string `json:"code"`
+			}
+			_ = json.Unmarshal(req.Params[0], &msg)
+			if len(req.Params) > 2 {
+				_ = json.Unmarshal(req.Params[2], &overrides)
+			}
+			if msg.From == contract && overrides[contract].Code == nil {
+				rejected.Add(1)
+				response["error"] = map[string]interface{}{"code": -32000, "message": "invalid sender"}
+				return response
+			}
+		}
+
+		params := make([]interface{}, len(req.Params))
+		for i, param := range req.Params {
+			params[i] = param
+		}
+		var result json.RawMessage
+		if err := client.CallContext(r.Context(), &result, req.Method, params...); err != nil {
+			rpcErr := map[string]interface{}{"code": -32000, "message": err.Error()}
+			if codeErr, ok := err.(rpc.Error); ok {
+				rpcErr["code"] = codeErr.ErrorCode()
+			}
+			if dataErr, ok := err.(rpc.DataError); ok {
+				rpcErr["data"] = dataErr.ErrorData()
+			}
+			response["error"] = rpcErr
+			return response
+		}
+		response["result"] = result
+		return response
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(strings.TrimSpace(string(raw)), "[") {
+			var batch []request
+			_ = json.Unmarshal(raw, &batch)
+			responses := make([]map[string]interface{}, len(batch))
+			for i, req := range batch {
+				responses[i] = handle(r, req)
+			}
+			_ = json.NewEncoder(w).Encode(responses)
+			return
+		}
+		var req request
+		_ = json.Unmarshal(raw, &req)
+		_ = json.NewEncoder(w).Encode(handle(r, req))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, rejected
+}
+
+func TestCheckDeposit(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "1", "--to", env.chain.User.Hex(), "--yes")
+
+	args := []string{"check-deposit", "--pubkey", checkPubkeyHex, "--credentials", checkCredentialsHex, "--signature", checkSignatureHex}
+	out := env.mustRun(nil, append(args, "--sender", env.chain.User.Hex())...)
+	assertContains(t, out, "PASS: accepted, burns 1 token")
+
+	out, err := env.run(nil, append(args, "--sender", env.chain.Admin.Hex())...)
+	assertError(t, err, "1 of 1 deposits will not pass the gate")
+	assertContains(t, out, "sender holds no deposit token")
+}
+
+func TestCheckDepositContractCaller(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "1", "--to", env.chain.User.Hex(), "--yes")
+	proxyURL, rejected := startEOAOnlyProxy(t, env, env.chain.DepositContract)
+
+	// Calls from the deposit contract are rejected by the RPC and retried without its code
+	args := []string{"check-deposit", "--rpc", proxyURL, "--pubkey", checkPubkeyHex, "--credentials", checkCredentialsHex, "--signature", checkSignatureHex}
+	out := env.mustRun(nil, append(args, "--sender", env.chain.User.Hex())...)
+	assertContains(t, out, "PASS: accepted, burns 1 token")
+	if rejected.Load() != 1 {
+		t.Errorf("expected 1 rejected call, got %d", rejected.Load())
+	}
+
+	// A revert of the retried call is explained, not retried again
+	out, err := env.run(nil, append(args, "--sender", env.chain.Admin.Hex())...)
+	assertError(t, err, "1 of 1 deposits will not pass the gate")
+	assertContains(t, out, "sender holds no deposit token")
+	if rejected.Load() != 2 {
+		t.Errorf("expected 2 rejected calls, got %d", rejected.Load())
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cliConfig is the CLI config file (~/.config/gating-cli/config.yaml).
+type cliConfig struct {
+	CurrentProfile string                     `yaml:"currentProfile,omitempty"`
+	Profiles       map[string]*networkProfile `yaml:"profiles,omitempty"`
+}
+
+// networkProfile is a named set of connection settings.
+type networkProfile struct {
+	RPC             string `yaml:"rpc,omitempty" json:"rpc,omitempty"`
+	DepositContract string `yaml:"depositContract,omitempty" json:"depositContract,omitempty"`
+	Gater           string `yaml:"gater,omitempty" json:"gater,omitempty"`
+	Signer          string `yaml:"signer,omitempty" json:"signer,omitempty"`
+	ChainID         uint64 `yaml:"chainId,omitempty" json:"chainId,omitempty"`
+	Output          string `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// defaultConfigPath returns the default config file path ($XDG_CONFIG_HOME/gating-cli/config.yaml).
+func defaultConfigPath() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine home directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "gating-cli", "config.yaml"), nil
+}
+
+// resolveConfigPath returns the config file path given by --config or the default path.
+func resolveConfigPath() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
+	return defaultConfigPath()
+}
+
+// chainDataPath returns the path of a per-network data file next to the config file, e.g.
+// faucet-history-1337.jsonl for the name "faucet-history" and extension "jsonl".
+func chainDataPath(name, ext string) (string, error) {
+	configFile, err := resolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configFile), fmt.Sprintf("%s-%s.%s", name, chainID.String(), ext)), nil
+}
+
+// loadCLIConfig reads the config file. A missing file results in an empty config.
+func loadCLIConfig(path string) (*cliConfig, error) {
+	config := &cliConfig{Profiles: map[string]*networkProfile{}}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(raw, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]*networkProfile{}
+	}
+	return config, nil
+}
+
+// saveCLIConfig writes the config file, creating its directory if needed.
+func saveCLIConfig(path string, config *cliConfig) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data := buf.Bytes()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// presetProfile returns the built-in profile of a well-known network.
+func presetProfile(name string) *networkProfile {
+	for _, network := range knownNetworks {
+		if network.Name == name {
+			return &networkProfile{
+				DepositContract: network.DepositContract,
+				ChainID:         network.ChainID,
+			}
+		}
+	}
+	return nil
+}
+
+// findProfile looks up a profile in the config file, falling back to the built-in presets.
+func (config *cliConfig) findProfile(name string) *networkProfile {
+	if profile, ok := config.Profiles[name]; ok {
+		return profile
+	}
+	return presetProfile(name)
+}
+
+// profileNames returns the names of all profiles in the config file and all presets, sorted.
+func (config *cliConfig) profileNames() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for name := range config.Profiles {
+		seen[name] = true
+		names = append(names, name)
+	}
+	for _, network := range knownNetworks {
+		if !seen[network.Name] {
+			names = append(names, network.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validate checks the values of a profile.
+func (profile *networkProfile) validate() error {
+	if profile.Output != "" {
+		if err := validateOutputFormat(profile.Output); err != nil {
+			return err
+		}
+	}
+	if profile.Signer != "" {
+		scheme, _, _ := strings.Cut(profile.Signer, ":")
+		if scheme != "env" && scheme != "file" {
+			return fmt.Errorf("unsupported signer %q (use env:<VAR> or file:<path>)", profile.Signer)
+		}
+	}
+	return nil
+}
+
+// loadProfileSigner reads the private key from a profile signer backend.
+//
+// Supported backends:
+//   - env:<VAR>   read the key from an environment variable
+//   - file:<path> read the key from a file
+func loadProfileSigner(signer string) (string, error) {
+	scheme, value, _ := strings.Cut(signer, ":")
+	switch scheme {
+	case "env":
+		key := os.Getenv(value)
+		if key == "" {
+			return "", fmt.Errorf("signer environment variable %s is not set", value)
+		}
+		return key, nil
+	case "file":
+		path := value
+		if strings.HasPrefix(path, "~/") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to determine home directory: %w", err)
+			}
+			path = filepath.Join(homeDir, path[2:])
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read signer key file: %w", err)
+		}
+		return strings.TrimSpace(string(raw)), nil
+	default:
+		return "", fmt.Errorf("unsupported signer %q (use env:<VAR> or file:<path>)", signer)
+	}
+}
+
+// validateOutputFormat checks an output format value.
+func validateOutputFormat(format string) error {
+	switch format {
+	case "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (use text or json)", format)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// configUndoEntry is the last config change of a deposit type, with the config it replaced.
+type configUndoEntry struct {
+	Gater       string            `json:"gater"`
+	DepositType uint16            `json:"depositType"`
+	Previous    depositTypePolicy `json:"previous"`
+	Config      depositTypePolicy `json:"config"`
+	TxHash      string            `json:"txHash"`
+	Time        time.Time         `json:"time"`
+}
+
+// configUndoStore is the content of the undo store file, one entry per gater and deposit type.
+type configUndoStore struct {
+	Entries []*configUndoEntry `json:"entries"`
+}
+
+// configUndoStoreFile is the undo store file.
+var configUndoStoreFile = &jsonStore[configUndoStore]{
+	name:  "undo store",
+	empty: func() *configUndoStore { return &configUndoStore{} },
+}
+
+// configUndoStorePath returns the undo store of the network.
+func configUndoStorePath() (string, error) {
+	return chainDataPath("setconfig-undo", "json")
+}
+
+// find returns the entry of a deposit type of a gater, or nil.
+func (store *configUndoStore) find(gaterAddress common.Address, depositType uint16) *configUndoEntry {
+	for _, entry := range store.Entries {
+		if strings.EqualFold(entry.Gater, gaterAddress.Hex()) && entry.DepositType == depositType {
+			return entry
+		}
+	}
+	return nil
+}
+
+// latest returns the most recent entry of a gater, or nil.
+func (store *configUndoStore) latest(gaterAddress common.Address) *configUndoEntry {
+	var latest *configUndoEntry
+	for _, entry := range store.Entries {
+		if strings.EqualFold(entry.Gater, gaterAddress.Hex()) && (latest == nil || entry.Time.After(latest.Time)) {
+			latest = entry
+		}
+	}
+	return latest
+}
+
+// recordConfigChange stores the config a change replaced, replacing the previous entry of
+// the deposit type.
+func recordConfigChange(entry *configUndoEntry) error {
+	path, err := configUndoStorePath()
+	if err != nil {
+		return err
+	}
+	return configUndoStoreFile.update(path, func(store *configUndoStore) error {
+		if existing := store.find(common.HexToAddress(entry.Gater), entry.DepositType); existing != nil {
+			*existing = *entry
+		} else {
+			store.Entries = append(store.Entries, entry)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	depositFile   string
+	depositDryRun bool
+)
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Submit validator deposits through the gated deposit contract",
+	Long: `Submit validator deposits from a staking-deposit-cli deposit_data-*.json file.
+
+Before sending anything, each entry is validated locally (field lengths and
+deposit_data_root) and checked against the gate configuration of its deposit
+type. Deposits that require a token are only sent while the signer still holds
+deposit tokens, so a batch stops early instead of reverting mid-way.`,
+	RunE: runDeposit,
+}
+
+func init() {
+	depositCmd.Flags().StringVarP(&depositFile, "file", "f", "", "Path to a deposit_data-*.json file")
+	depositCmd.Flags().BoolVar(&depositDryRun, "dry-run", false, "Validate and check the deposits without sending them")
+}
+
+func runDeposit(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Determine deposit file
+	if depositFile == "" && interactive {
+		input, err := promptInput("Enter path to deposit_data-*.json file: ")
+		if err != nil {
+			return fmt.Errorf("failed to read file path: %w", err)
+		}
+		depositFile = input
+	}
+	if depositFile == "" {
+		return fmt.Errorf("deposit file is required (use --file)")
+	}
+
+	deposits, err := loadDepositDataFile(depositFile)
+	if err != nil {
+		return err
+	}
+
+	totalValue := new(big.Int)
+	for _, deposit := range deposits {
+		totalValue.Add(totalValue, deposit.Value())
+	}
+
+	printHeader("═══ Validator Deposits ═══")
+	fmt.Println()
+	fmt.Printf("%sDeposit File:%s      %s\n", colorCyan, colorReset, depositFile)
+	fmt.Printf("%sDeposits:%s          %d\n", colorCyan, colorReset, len(deposits))
+	fmt.Printf("%sTotal Value:%s       %s\n", colorCyan, colorReset, formatWei(totalValue))
+	fmt.Println()
+
+	// Check the gate configuration for all deposit types in the file
+	configs := map[uint16]gater.DepositGateConfig{}
+	customGater := common.Address{}
+	tokenBalance := new(big.Int)
+	if gaterAddr != (common.Address{}) {
+		latest, err := gaterClient.AtLatest(ctx)
+		if err != nil {
+			return err
+		}
+		customGater, err = latest.CustomGater(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get custom gater: %w", err)
+		}
+
+		for _, deposit := range deposits {
+			if _, ok := configs[deposit.DepositType]; ok {
+				continue
+			}
+			config, err := latest.DepositGateConfig(ctx, deposit.DepositType)
+			if err != nil {
+				return fmt.Errorf("failed to get config for deposit type 0x%04x: %w", deposit.DepositType, err)
+			}
+			configs[deposit.DepositType] = config
+		}
+
+		tokenBalance, err = latest.BalanceOf(ctx, signerAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get token balance: %w", err)
+		}
+	} else {
+		log.Warn("No gating contract configured, deposits are not gated")
+	}
+
+	tokensNeeded := int64(0)
+	for i, deposit := range deposits {
+		config := configs[deposit.DepositType]
+		if config.Blocked {
+			if customGater == (common.Address{}) {
+				return fmt.Errorf("deposit #%d (pubkey %s) has blocked deposit type %s", i, shortHex(deposit.Pubkey), depositTypeLabel(deposit.DepositType))
+			}
+			log.WithField("index", i).Warn("Deposit type is blocked, relying on custom gater to accept it")
+		}
+		if gaterAddr != (common.Address{}) && !config.NoToken {
+			tokensNeeded++
+		}
+	}
+
+	if gaterAddr != (common.Address{}) {
+		fmt.Printf("%sCustom Gater:%s      %s\n", colorCyan, colorReset, formatOptionalAddress(customGater))
+		fmt.Printf("%sTokens Needed:%s     %d\n", colorCyan, colorReset, tokensNeeded)
+		fmt.Printf("%sToken Balance:%s     %s\n", colorCyan, colorReset, tokenBalance.String())
+		fmt.Println()
+
+		if tokenBalance.Cmp(big.NewInt(tokensNeeded)) < 0 {
+			if customGater == (common.Address{}) {
+				printInfo("Not enough deposit tokens for all deposits, the batch will stop when tokens run out.")
+			} else {
+				printInfo("Not enough deposit tokens for all deposits, unless the custom gater accepts them.")
+			}
+		}
+	}
+
+	ethBalance, err := ethClient.BalanceAt(ctx, signerAddress, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get signer balance: %w", err)
+	}
+	if ethBalance.Cmp(totalValue) < 0 {
+		return fmt.Errorf("signer balance %s is below the total deposit value %s", formatWei(ethBalance), formatWei(totalValue))
+	}
+
+	if depositDryRun {
+		printSuccess("Dry run: all %d deposits are valid", len(deposits))
+		return nil
+	}
+
+	confirmed, err := confirmTransactionBatch(len(deposits), fmt.Sprintf("%d deposits, %s total", len(deposits), formatWei(totalValue)))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		printInfo("Aborted.")
+		return nil
+	}
+	defer endTransactionBatch()
+
+	// Submit deposits
+	submitted := 0
+	for i, deposit := range deposits {
+		requiresToken := gaterAddr != (common.Address{}) && !configs[deposit.DepositType].NoToken
+		if requiresToken && customGater == (common.Address{}) {
+			latest, err := gaterClient.AtLatest(ctx)
+			if err != nil {
+				return err
+			}
+			balance, err := latest.BalanceOf(ctx, signerAddress)
+			if err != nil {
+				return fmt.Errorf("failed to get token balance: %w", err)
+			}
+			if balance.Sign() <= 0 {
+				return fmt.Errorf("out of deposit tokens: stopped after %d of %d deposits", submitted, len(deposits))
+			}
+		}
+
+		log.WithFields(map[string]interface{}{
+			"index":  i,
+			"pubkey": shortHex(deposit.Pubkey),
+			"type":   depositTypeLabel(deposit.DepositType),
+			"amount": formatGwei(deposit.Amount),
+		}).Info("Submitting deposit")
+
+		// Pack transaction data
+		data, err := parsedDepositABI.Pack("deposit", deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Signature, deposit.DepositDataRoot)
+		if err != nil {
+			return fmt.Errorf("failed to pack deposit call: %w", err)
+		}
+
+		// Send transaction
+		receipt, err := transactor.Send(ctx, depositAddr, deposit.Value(), data)
+		if err != nil {
+			return fmt.Errorf("deposit #%d failed after %d of %d deposits: %w", i, submitted, len(deposits), err)
+		}
+		submitted++
+
+		printSuccess("Deposit #%d submitted (pubkey %s)", i, shortHex(deposit.Pubkey))
+		fmt.Printf("%sTransaction:%s %s\n", colorCyan, colorReset, receipt.TxHash.Hex())
+	}
+
+	fmt.Println()
+	printSuccess("Successfully submitted %d deposits", submitted)
+	return nil
+}
+
+// formatWei formats a wei amount as ETH.
+func formatWei(amount *big.Int) string {
+	gwei := new(big.Int).Div(amount, big.NewInt(1e9))
+	if !gwei.IsUint64() {
+		return amount.String() + " wei"
+	}
+	return formatGwei(gwei.Uint64())
+}
+
+// formatOptionalAddress formats an address, showing "None" for the zero address.
+func formatOptionalAddress(addr common.Address) string {
+	if addr == (common.Address{}) {
+		return "None"
+	}
+	return addr.Hex()
+}
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+// DepositContract ABI (relevant functions and events only)
+const depositContractABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "internalType": "bytes", "name": "pubkey", "type": "bytes"},
+			{"indexed": false, "internalType": "bytes", "name": "withdrawal_credentials", "type": "bytes"},
+			{"indexed": false, "internalType": "bytes", "name": "amount", "type": "bytes"},
+			{"indexed": false, "internalType": "bytes", "name": "signature", "type": "bytes"},
+			{"indexed": false, "internalType": "bytes", "name": "index", "type": "bytes"}
+		],
+		"name": "DepositEvent",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{"internalType": "bytes", "name": "pubkey", "type": "bytes"},
+			{"internalType": "bytes", "name": "withdrawal_credentials", "type": "bytes"},
+			{"internalType": "bytes", "name": "signature", "type": "bytes"},
+			{"internalType": "bytes32", "name": "deposit_data_root", "type": "bytes32"}
+		],
+		"name": "deposit",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "get_deposit_count",
+		"outputs": [{"internalType": "bytes", "name": "", "type": "bytes"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "get_deposit_root",
+		"outputs": [{"internalType": "bytes32", "name": "", "type": "bytes32"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var parsedDepositABI abi.ABI
+
+func init() {
+	var err error
+	parsedDepositABI, err = abi.JSON(strings.NewReader(depositContractABI))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse deposit contract ABI: %v", err))
+	}
+}
+
+// knownDepositTypes lists the deposit types with a well-known meaning.
+var knownDepositTypes = []struct {
+	typeID uint16
+	name   string
+}{
+	{0x00, "BLS withdrawal credentials (0x00)"},
+	{0x01, "Execution withdrawal credentials (0x01)"},
+	{0x02, "Compounding credentials (0x02)"},
+	{0x03, "ePBS builder credentials (0x03)"},
+	{gater.TopUpDepositType, "Top-up deposits (0xffff)"},
+}
+
+// depositTypeLabel returns a short label for a deposit type.
+func depositTypeLabel(depositType uint16) string {
+	if depositType == gater.TopUpDepositType {
+		return "top-up"
+	}
+	return fmt.Sprintf("0x%02x", depositType)
+}
+
+// classifyDeposit determines the deposit type exactly like TokenDepositGater.check_deposit:
+// a deposit with an all-zero signature and all-zero withdrawal credentials is a top-up,
+// otherwise the first byte of the withdrawal credentials is the deposit type.
+func classifyDeposit(withdrawalCredentials []byte, signature []byte) uint16 {
+	if isAllZero(signature, 96) && isAllZero(withdrawalCredentials, 32) {
+		return gater.TopUpDepositType
+	}
+	if len(withdrawalCredentials) == 0 {
+		return 0
+	}
+	return uint16(withdrawalCredentials[0])
+}
+
+// isAllZero checks if data has the expected length and contains only zero bytes.
+func isAllZero(data []byte, expectedLength int) bool {
+	if len(data) != expectedLength {
+		return false
+	}
+	return bytes.Count(data, []byte{0}) == expectedLength
+}
+
+// depositEvent is a decoded DepositEvent log of the deposit contract.
+type depositEvent struct {
+	Index                 uint64
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                uint64 // in gwei
+	Signature             []byte
+	DepositType           uint16
+	BlockNumber           uint64
+	TxHash                common.Hash
+	LogIndex              uint
+}
+
+// tokenBurn is a decoded Transfer log of the gater token to the zero address.
+type tokenBurn struct {
+	From        common.Address
+	Amount      *big.Int
+	BlockNumber uint64
+	TxHash      common.Hash
+	LogIndex    uint
+}
+
+// decodeDepositEvent decodes a DepositEvent log.
+func decodeDepositEvent(vLog types.Log) (*depositEvent, error) {
+	var output struct {
+		Pubkey                []byte
+		WithdrawalCredentials []byte
+		Amount                []byte
+		Signature             []byte
+		Index                 []byte
+	}
+	if err := parsedDepositABI.UnpackIntoInterface(&output, "DepositEvent", vLog.Data); err != nil {
+		return nil, fmt.Errorf("failed to unpack DepositEvent: %w", err)
+	}
+	if len(output.Amount) != 8 || len(output.Index) != 8 {
+		return nil, fmt.Errorf("invalid DepositEvent encoding in tx %s", vLog.TxHash.Hex())
+	}
+
+	return &depositEvent{
+		Index:                 binary.LittleEndian.Uint64(output.Index),
+		Pubkey:                output.Pubkey,
+		WithdrawalCredentials: output.WithdrawalCredentials,
+		Amount:                binary.LittleEndian.Uint64(output.Amount),
+		Signature:             output.Signature,
+		DepositType:           classifyDeposit(output.WithdrawalCredentials, output.Signature),
+		BlockNumber:           vLog.BlockNumber,
+		TxHash:                vLog.TxHash,
+		LogIndex:              vLog.Index,
+	}, nil
+}
+
+// filterLogsChunked fetches logs in block ranges of at most chunkSize blocks,
+// as most RPC providers limit the range of a single eth_getLogs request.
+func filterLogsChunked(ctx context.Context, query ethereum.FilterQuery, fromBlock, toBlock, chunkSize uint64) ([]types.Log, error) {
+	if chunkSize == 0 {
+		chunkSize = toBlock - fromBlock + 1
+	}
+
+	var logs []types.Log
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+		chunk, err := ethClient.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch logs for blocks %d-%d: %w", start, end, err)
+		}
+		log.WithFields(map[string]interface{}{
+			"from": start,
+			"to":   end,
+			"logs": len(chunk),
+		}).Debug("Fetched logs")
+		logs = append(logs, chunk...)
+
+		if end == toBlock {
+			break
+		}
+	}
+	return logs, nil
+}
+
+// fetchDepositEvents fetches and decodes all DepositEvent logs of the deposit contract in the given block range.
+func fetchDepositEvents(ctx context.Context, fromBlock, toBlock, chunkSize uint64) ([]*depositEvent, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{depositAddr},
+		Topics:    [][]common.Hash{{parsedDepositABI.Events["DepositEvent"].ID}},
+	}
+	logs, err := filterLogsChunked(ctx, query, fromBlock, toBlock, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	deposits := make([]*depositEvent, 0, len(logs))
+	for _, vLog := range logs {
+		if vLog.Removed {
+			continue
+		}
+		deposit, err := decodeDepositEvent(vLog)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, deposit)
+	}
+	return deposits, nil
+}
+
+// fetchTokenBurns fetches all gater token burns (Transfer logs to the zero address) in the given block range.
+func fetchTokenBurns(ctx context.Context, fromBlock, toBlock, chunkSize uint64) ([]*tokenBurn, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{gaterAddr},
+		Topics: [][]common.Hash{
+			{gater.ABI.Events["Transfer"].ID},
+			nil,
+			{common.Hash{}},
+		},
+	}
+	logs, err := filterLogsChunked(ctx, query, fromBlock, toBlock, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	burns := make([]*tokenBurn, 0, len(logs))
+	for _, vLog := range logs {
+		if vLog.Removed || len(vLog.Topics) < 3 {
+			continue
+		}
+		burns = append(burns, &tokenBurn{
+			From:        common.BytesToAddress(vLog.Topics[1].Bytes()),
+			Amount:      new(big.Int).SetBytes(vLog.Data),
+			BlockNumber: vLog.BlockNumber,
+			TxHash:      vLog.TxHash,
+			LogIndex:    vLog.Index,
+		})
+	}
+	return burns, nil
+}
+
+// correlateTokenBurns maps each deposit to the token burn that paid for it.
+// The gater is called before the deposit contract emits its DepositEvent, so the burn
+// for a deposit is the last burn in the same transaction between the previous deposit
+// and the deposit itself. Deposits that didn't burn a token are not included in the result.
+func correlateTokenBurns(deposits []*depositEvent, burns []*tokenBurn) map[uint64]*tokenBurn {
+	burnsByTx := map[common.Hash][]*tokenBurn{}
+	for _, burn := range burns {
+		burnsByTx[burn.TxHash] = append(burnsByTx[burn.TxHash], burn)
+	}
+
+	result := map[uint64]*tokenBurn{}
+	lastLogIndex := map[common.Hash]uint{}
+	for _, deposit := range deposits {
+		prevLogIndex, hasPrev := lastLogIndex[deposit.TxHash]
+		lastLogIndex[deposit.TxHash] = deposit.LogIndex
+
+		var match *tokenBurn
+		for _, burn := range burnsByTx[deposit.TxHash] {
+			if burn.LogIndex > deposit.LogIndex || (hasPrev && burn.LogIndex < prevLogIndex) {
+				continue
+			}
+			if match == nil || burn.LogIndex > match.LogIndex {
+				match = burn
+			}
+		}
+		if match != nil {
+			result[deposit.Index] = match
+		}
+	}
+	return result
+}
+
+// shortHex shortens a hex string for tabular output.
+func shortHex(data []byte) string {
+	hex := common.Bytes2Hex(data)
+	if len(hex) <= 16 {
+		return "0x" + hex
+	}
+	return "0x" + hex[:8] + "…" + hex[len(hex)-8:]
+}
+
+// getDepositRoot gets the deposit root of the deposit contract at the given block.
+func getDepositRoot(ctx context.Context, blockNum *big.Int) (common.Hash, error) {
+	data, err := parsedDepositABI.Pack("get_deposit_root")
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack get_deposit_root call: %w", err)
+	}
+
+	result, err := ethClient.CallContract(ctx, ethereum.CallMsg{
+		To:   &depositAddr,
+		Data: data,
+	}, blockNum)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to call get_deposit_root: %w", err)
+	}
+
+	var root [32]byte
+	if err := parsedDepositABI.UnpackIntoInterface(&root, "get_deposit_root", result); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to unpack get_deposit_root result: %w", err)
+	}
+	return common.Hash(root), nil
+}
+
+// getDepositCount gets the deposit count of the deposit contract at the given block.
+func getDepositCount(ctx context.Context, blockNum *big.Int) (uint64, error) {
+	data, err := parsedDepositABI.Pack("get_deposit_count")
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack get_deposit_count call: %w", err)
+	}
+
+	result, err := ethClient.CallContract(ctx, ethereum.CallMsg{
+		To:   &depositAddr,
+		Data: data,
+	}, blockNum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call get_deposit_count: %w", err)
+	}
+
+	var count []byte
+	if err := parsedDepositABI.UnpackIntoInterface(&count, "get_deposit_count", result); err != nil {
+		return 0, fmt.Errorf("failed to unpack get_deposit_count result: %w", err)
+	}
+	if len(count) != 8 {
+		return 0, fmt.Errorf("invalid deposit count length: %d", len(count))
+	}
+	return binary.LittleEndian.Uint64(count), nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minDepositAmount is the minimum deposit amount accepted by the deposit contract (1 ETH in gwei).
+const minDepositAmount uint64 = 1e9
+
+// depositDataEntry is a single entry of a staking-deposit-cli deposit_data-*.json file.
+type depositDataEntry struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name,omitempty"`
+	DepositCliVersion     string `json:"deposit_cli_version,omitempty"`
+}
+
+// validatorDeposit is a validated deposit ready to be submitted to the deposit contract.
+type validatorDeposit struct {
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                uint64 // in gwei
+	Signature             []byte
+	DepositDataRoot       common.Hash
+	DepositType           uint16
+}
+
+// Value returns the transaction value (in wei) for the deposit.
+func (d *validatorDeposit) Value() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(d.Amount), big.NewInt(1e9))
+}
+
+// loadDepositDataFile reads and validates a staking-deposit-cli deposit_data-*.json file.
+func loadDepositDataFile(path string) ([]*validatorDeposit, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deposit data file: %w", err)
+	}
+
+	var entries []depositDataEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse deposit data file: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("deposit data file %s contains no deposits", path)
+	}
+
+	deposits := make([]*validatorDeposit, len(entries))
+	for i, entry := range entries {
+		deposit, err := parseDepositDataEntry(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deposit #%d: %w", i, err)
+		}
+		deposits[i] = deposit
+	}
+	return deposits, nil
+}
+
+// parseDepositDataEntry decodes a deposit data entry and verifies its deposit_data_root.
+func parseDepositDataEntry(entry *depositDataEntry) (*validatorDeposit, error) {
+	pubkey, err := decodeHexField("pubkey", entry.Pubkey, 48)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := decodeHexField("withdrawal_credentials", entry.WithdrawalCredentials, 32)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := decodeHexField("signature", entry.Signature, 96)
+	if err != nil {
+		return nil, err
+	}
+	root, err := decodeHexField("deposit_data_root", entry.DepositDataRoot, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Amount < minDepositAmount {
+		return nil, fmt.Errorf("amount %d gwei is below the minimum of 1 ETH", entry.Amount)
+	}
+
+	deposit := &validatorDeposit{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: credentials,
+		Amount:                entry.Amount,
+		Signature:             signature,
+		DepositDataRoot:       common.BytesToHash(root),
+		DepositType:           classifyDeposit(credentials, signature),
+	}
+
+	computedRoot := depositDataRoot(pubkey, credentials, entry.Amount, signature)
+	if computedRoot != deposit.DepositDataRoot {
+		return nil, fmt.Errorf("deposit_data_root mismatch for pubkey 0x%x: file has %s, computed %s",
+			pubkey, deposit.DepositDataRoot.Hex(), computedRoot.Hex())
+	}
+
+	return deposit, nil
+}
+
+// decodeHexField decodes a hex field (with or without 0x prefix) and checks its length.
+func decodeHexField(name string, value string, expectedLength int) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s hex: %w", name, err)
+	}
+	if len(data) != expectedLength {
+		return nil, fmt.Errorf("invalid %s length: expected %d bytes, got %d", name, expectedLength, len(data))
+	}
+	return data, nil
+}
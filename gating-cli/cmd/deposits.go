@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	depositsFromBlock uint64
+	depositsToBlock   uint64
+	depositsChunkSize uint64
+	depositsType      string
+)
+
+var depositsCmd = &cobra.Command{
+	Use:   "deposits",
+	Short: "Inspect deposits made to the deposit contract",
+	Long: `Inspect deposits made to the deposit contract by indexing its DepositEvent logs.
+
+These commands are read-only and don't require a private key.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+}
+
+var depositsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deposits and the token burns that paid for them",
+	Long: `Lists all deposits made to the deposit contract in the given block range.
+
+Each deposit is classified into the same deposit type the gater uses:
+  0x00 - 0x03 - Withdrawal credential prefix
+  top-up      - All-zero signature and withdrawal_credentials (gate type 0xffff)
+
+Deposits are correlated with the token burn in the same transaction to show
+which depositor paid a token for it.`,
+	RunE: runDepositsList,
+}
+
+func init() {
+	depositsCmd.PersistentFlags().Uint64Var(&depositsFromBlock, "from-block", 0, "First block to index")
+	depositsCmd.PersistentFlags().Uint64Var(&depositsToBlock, "to-block", 0, "Last block to index (defaults to latest block)")
+	depositsCmd.PersistentFlags().Uint64Var(&depositsChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+
+	depositsListCmd.Flags().StringVarP(&depositsType, "type", "t", "", "Only show deposits of this type (e.g., 0x01, 0xffff)")
+
+	depositsCmd.AddCommand(depositsListCmd)
+}
+
+// resolveDepositsBlockRange returns the block range selected by the deposits flags.
+func resolveDepositsBlockRange(ctx context.Context) (uint64, uint64, error) {
+	toBlock := depositsToBlock
+	if toBlock == 0 {
+		latest, err := getLatestBlockNumber(ctx)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get latest block: %w", err)
+		}
+		toBlock = latest.Uint64()
+	}
+	if depositsFromBlock > toBlock {
+		return 0, 0, fmt.Errorf("from-block %d is after to-block %d", depositsFromBlock, toBlock)
+	}
+	return depositsFromBlock, toBlock, nil
+}
+
+func runDepositsList(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var filterType *uint16
+	if depositsType != "" {
+		parsed, err := parseDepositType(depositsType)
+		if err != nil {
+			return err
+		}
+		filterType = &parsed
+	}
+
+	fromBlock, toBlock, err := resolveDepositsBlockRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"from": fromBlock,
+		"to":   toBlock,
+	}).Info("Indexing deposit events")
+
+	deposits, err := fetchDepositEvents(ctx, fromBlock, toBlock, depositsChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deposit events: %w", err)
+	}
+
+	var burns map[uint64]*tokenBurn
+	if gaterAddr != (common.Address{}) {
+		tokenBurns, err := fetchTokenBurns(ctx, fromBlock, toBlock, depositsChunkSize)
+		if err != nil {
+			return fmt.Errorf("failed to fetch token burns: %w", err)
+		}
+		burns = correlateTokenBurns(deposits, tokenBurns)
+	}
+
+	printHeader("═══ Deposits ═══")
+	fmt.Println()
+	fmt.Printf("%sDeposit Contract:%s  %s\n", colorCyan, colorReset, depositAddr.Hex())
+	fmt.Printf("%sBlock Range:%s       %d - %d\n", colorCyan, colorReset, fromBlock, toBlock)
+	fmt.Println()
+
+	typeCounts := map[uint16]int{}
+	paidCount := 0
+	shown := 0
+	for _, deposit := range deposits {
+		if filterType != nil && deposit.DepositType != *filterType {
+			continue
+		}
+		if shown == 0 {
+			fmt.Printf("  %-8s %-10s %-7s %-14s %-22s %s\n", "Index", "Block", "Type", "Amount", "Pubkey", "Token paid by")
+		}
+		shown++
+		typeCounts[deposit.DepositType]++
+
+		payer := colorYellow + "no token" + colorReset
+		if burn, ok := burns[deposit.Index]; ok {
+			payer = burn.From.Hex()
+			paidCount++
+		}
+
+		fmt.Printf("  %-8d %-10d %-7s %-14s %-22s %s\n",
+			deposit.Index,
+			deposit.BlockNumber,
+			depositTypeLabel(deposit.DepositType),
+			formatGwei(deposit.Amount),
+			shortHex(deposit.Pubkey),
+			payer,
+		)
+	}
+
+	if shown == 0 {
+		printInfo("No deposits found.")
+		return nil
+	}
+
+	fmt.Println()
+	printHeader("═══ Summary ═══")
+	fmt.Println()
+	fmt.Printf("%sDeposits:%s          %d\n", colorCyan, colorReset, shown)
+	fmt.Printf("%sPaid with token:%s   %d\n", colorCyan, colorReset, paidCount)
+
+	depositTypes := make([]uint16, 0, len(typeCounts))
+	for depositType := range typeCounts {
+		depositTypes = append(depositTypes, depositType)
+	}
+	sort.Slice(depositTypes, func(i, j int) bool { return depositTypes[i] < depositTypes[j] })
+	for _, depositType := range depositTypes {
+		fmt.Printf("  %-8s %d\n", depositTypeLabel(depositType)+":", typeCounts[depositType])
+	}
+
+	return nil
+}
+
+// formatGwei formats a gwei amount as ETH.
+func formatGwei(amount uint64) string {
+	whole := amount / 1e9
+	frac := amount % 1e9
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10) + " ETH"
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%09d", frac), "0")
+	return strconv.FormatUint(whole, 10) + "." + fracStr + " ETH"
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+)
+
+var depositsVerifyRootCmd = &cobra.Command{
+	Use:   "verify-root",
+	Short: "Rebuild the deposit Merkle tree and verify the deposit root",
+	Long: `Replays all DepositEvent logs, recomputes each DepositData hash tree root exactly
+like the deposit contract does, rebuilds the incremental 32-level Merkle tree and
+compares the result against get_deposit_root() and get_deposit_count() at the
+same block.
+
+A mismatch indicates missing or duplicate events (e.g. an RPC that drops logs)
+or a deposit contract that doesn't behave like the gated deposit contract.
+--from-block must be at or before the block the deposit contract was deployed in.`,
+	RunE: runDepositsVerifyRoot,
+}
+
+func init() {
+	depositsCmd.AddCommand(depositsVerifyRootCmd)
+}
+
+func runDepositsVerifyRoot(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	fromBlock, toBlock, err := resolveDepositsBlockRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"from": fromBlock,
+		"to":   toBlock,
+	}).Info("Replaying deposit events")
+
+	deposits, err := fetchDepositEvents(ctx, fromBlock, toBlock, depositsChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deposit events: %w", err)
+	}
+
+	// Rebuild the tree, making sure the event indexes are contiguous
+	tree := newDepositTree()
+	for _, deposit := range deposits {
+		if deposit.Index != tree.count {
+			return fmt.Errorf("deposit index gap: expected index %d, got %d in block %d (tx %s)",
+				tree.count, deposit.Index, deposit.BlockNumber, deposit.TxHash.Hex())
+		}
+		if len(deposit.Pubkey) != 48 || len(deposit.WithdrawalCredentials) != 32 || len(deposit.Signature) != 96 {
+			return fmt.Errorf("deposit %d has invalid field lengths", deposit.Index)
+		}
+
+		node := depositDataRoot(deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature)
+		log.WithFields(map[string]interface{}{
+			"index": deposit.Index,
+			"root":  node.Hex(),
+		}).Debug("Computed deposit data root")
+		tree.push(node)
+	}
+	computedRoot := tree.root()
+
+	// Read the on-chain state at the same block
+	blockNum := new(big.Int).SetUint64(toBlock)
+	onchainRoot, err := getDepositRoot(ctx, blockNum)
+	if err != nil {
+		return err
+	}
+	onchainCount, err := getDepositCount(ctx, blockNum)
+	if err != nil {
+		return err
+	}
+
+	printHeader("═══ Deposit Root Verification ═══")
+	fmt.Println()
+	fmt.Printf("%sDeposit Contract:%s  %s\n", colorCyan, colorReset, depositAddr.Hex())
+	fmt.Printf("%sBlock Range:%s       %d - %d\n", colorCyan, colorReset, fromBlock, toBlock)
+	fmt.Println()
+	fmt.Printf("%sComputed Count:%s    %d\n", colorCyan, colorReset, tree.count)
+	fmt.Printf("%sOn-chain Count:%s    %d\n", colorCyan, colorReset, onchainCount)
+	fmt.Printf("%sComputed Root:%s     %s\n", colorCyan, colorReset, computedRoot.Hex())
+	fmt.Printf("%sOn-chain Root:%s     %s\n", colorCyan, colorReset, onchainRoot.Hex())
+	fmt.Println()
+
+	if tree.count != onchainCount {
+		return fmt.Errorf("deposit count mismatch: indexed %d deposits, contract reports %d", tree.count, onchainCount)
+	}
+	if computedRoot != onchainRoot {
+		return fmt.Errorf("deposit root mismatch: computed %s, contract reports %s", computedRoot.Hex(), onchainRoot.Hex())
+	}
+
+	printSuccess("Deposit root verified (%d deposits)", tree.count)
+	return nil
+}
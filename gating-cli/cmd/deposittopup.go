@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topUpPubkey    string
+	topUpAmount    uint64
+	topUpFromBlock uint64
+	topUpChunkSize uint64
+)
+
+var depositTopUpCmd = &cobra.Command{
+	Use:   "topup",
+	Short: "Top up an existing validator",
+	Long: `Submit a top-up deposit for an existing validator.
+
+The gater treats a deposit as top-up (deposit type 0xffff) only if the signature
+is 96 zero bytes and the withdrawal credentials are 32 zero bytes. This command
+builds exactly that payload, computes the matching deposit_data_root and checks
+that the pubkey has been deposited before (by scanning DepositEvent logs).`,
+	RunE: runDepositTopUp,
+}
+
+func init() {
+	depositTopUpCmd.Flags().StringVar(&topUpPubkey, "pubkey", "", "Validator pubkey to top up (hex)")
+	depositTopUpCmd.Flags().Uint64Var(&topUpAmount, "amount", 0, "Top-up amount in gwei (at least 1000000000)")
+	depositTopUpCmd.Flags().Uint64Var(&topUpFromBlock, "from-block", 0, "First block to scan for the initial deposit")
+	depositTopUpCmd.Flags().Uint64Var(&topUpChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+
+	depositCmd.AddCommand(depositTopUpCmd)
+}
+
+func runDepositTopUp(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Determine pubkey
+	if topUpPubkey == "" && interactive {
+		input, err := promptInput("Enter validator pubkey: ")
+		if err != nil {
+			return fmt.Errorf("failed to read pubkey: %w", err)
+		}
+		topUpPubkey = input
+	}
+	if topUpPubkey == "" {
+		return fmt.Errorf("pubkey is required (use --pubkey)")
+	}
+	pubkey, err := decodeHexField("pubkey", topUpPubkey, 48)
+	if err != nil {
+		return err
+	}
+
+	// Determine amount
+	if topUpAmount == 0 && interactive {
+		input, err := promptInput("Enter top-up amount in gwei: ")
+		if err != nil {
+			return fmt.Errorf("failed to read amount: %w", err)
+		}
+		if _, err := fmt.Sscanf(input, "%d", &topUpAmount); err != nil {
+			return fmt.Errorf("invalid amount: %s", input)
+		}
+	}
+	if topUpAmount == 0 {
+		return fmt.Errorf("amount is required (use --amount)")
+	}
+	if topUpAmount < minDepositAmount {
+		return fmt.Errorf("amount %d gwei is below the minimum of 1 ETH", topUpAmount)
+	}
+
+	// Build the top-up payload
+	deposit := &validatorDeposit{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                topUpAmount,
+		Signature:             make([]byte, 96),
+	}
+	deposit.DepositDataRoot = depositDataRoot(deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature)
+	deposit.DepositType = classifyDeposit(deposit.WithdrawalCredentials, deposit.Signature)
+
+	// Make sure the validator has been deposited before
+	latest, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	log.WithField("from", topUpFromBlock).Info("Looking up existing deposits for pubkey")
+	events, err := fetchDepositEvents(ctx, topUpFromBlock, latest.Uint64(), topUpChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deposit events: %w", err)
+	}
+	var initialDeposit *depositEvent
+	for _, event := range events {
+		if bytes.Equal(event.Pubkey, pubkey) && event.DepositType != gater.TopUpDepositType {
+			initialDeposit = event
+			break
+		}
+	}
+	if initialDeposit == nil {
+		return fmt.Errorf("no previous deposit found for pubkey 0x%x, top-ups are only possible for existing validators", pubkey)
+	}
+
+	printHeader("═══ Top-up Deposit ═══")
+	fmt.Println()
+	fmt.Printf("%sPubkey:%s            0x%x\n", colorCyan, colorReset, pubkey)
+	fmt.Printf("%sAmount:%s            %s\n", colorCyan, colorReset, formatGwei(topUpAmount))
+	fmt.Printf("%sInitial Deposit:%s   #%d in block %d\n", colorCyan, colorReset, initialDeposit.Index, initialDeposit.BlockNumber)
+	fmt.Printf("%sDeposit Data Root:%s %s\n", colorCyan, colorReset, deposit.DepositDataRoot.Hex())
+	fmt.Println()
+
+	// Show the gate config for top-ups
+	if gaterAddr != (common.Address{}) {
+		latestGater := gaterClient.At(latest)
+		config, err := latestGater.DepositGateConfig(ctx, gater.TopUpDepositType)
+		if err != nil {
+			return fmt.Errorf("failed to get top-up config: %w", err)
+		}
+		customGater, err := latestGater.CustomGater(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get custom gater: %w", err)
+		}
+		balance, err := latestGater.BalanceOf(ctx, signerAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get token balance: %w", err)
+		}
+
+		fmt.Printf("%sGate config for 0x%04x:%s\n", colorCyan, gater.TopUpDepositType, colorReset)
+		fmt.Printf("  Blocked:  %s\n", formatBool(config.Blocked))
+		fmt.Printf("  NoToken:  %s\n", formatBool(config.NoToken))
+		if config.NoToken {
+			fmt.Printf("  Token:    %sNo token required%s\n", colorYellow, colorReset)
+		} else {
+			fmt.Printf("  Token:    Requires 1 token (balance: %s)\n", balance.String())
+		}
+		fmt.Println()
+
+		if customGater == (common.Address{}) {
+			if config.Blocked {
+				return fmt.Errorf("top-up deposits are config.Blocked")
+			}
+			if !config.NoToken && balance.Sign() <= 0 {
+				return fmt.Errorf("signer %s holds no deposit token", signerAddress.Hex())
+			}
+		} else if config.Blocked || (!config.NoToken && balance.Sign() <= 0) {
+			log.WithField("customGater", customGater.Hex()).Warn("Top-up relies on the custom gater to accept it")
+		}
+	}
+
+	log.WithFields(map[string]interface{}{
+		"pubkey": shortHex(pubkey),
+		"amount": formatGwei(topUpAmount),
+	}).Info("Submitting top-up deposit")
+
+	// Pack transaction data
+	data, err := parsedDepositABI.Pack("deposit", deposit.Pubkey, deposit.WithdrawalCredentials, deposit.Signature, deposit.DepositDataRoot)
+	if err != nil {
+		return fmt.Errorf("failed to pack deposit call: %w", err)
+	}
+
+	// Send transaction
+	receipt, err := transactor.Send(ctx, depositAddr, deposit.Value(), data)
+	if err != nil {
+		return fmt.Errorf("top-up failed: %w", err)
+	}
+
+	printSuccess("Successfully topped up 0x%x with %s", pubkey, formatGwei(topUpAmount))
+	fmt.Printf("%sTransaction:%s %s\n", colorCyan, colorReset, receipt.TxHash.Hex())
+	fmt.Printf("%sGas used:%s    %d\n", colorCyan, colorReset, receipt.GasUsed)
+
+	return nil
+}
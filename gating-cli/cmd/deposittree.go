@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// depositContractTreeDepth is the depth of the deposit contract's incremental Merkle tree.
+const depositContractTreeDepth = 32
+
+// depositDataRoot computes the DepositData hash tree root exactly like GatedDepositContract.deposit.
+// The amount is given in gwei.
+func depositDataRoot(pubkey, withdrawalCredentials []byte, amount uint64, signature []byte) common.Hash {
+	var amountLE [8]byte
+	binary.LittleEndian.PutUint64(amountLE[:], amount)
+
+	pubkeyRoot := sha256Concat(pubkey, make([]byte, 16))
+	signatureLeft := sha256Concat(signature[:64])
+	signatureRight := sha256Concat(signature[64:], make([]byte, 32))
+	signatureRoot := sha256Concat(signatureLeft[:], signatureRight[:])
+
+	left := sha256Concat(pubkeyRoot[:], withdrawalCredentials)
+	right := sha256Concat(amountLE[:], make([]byte, 24), signatureRoot[:])
+	return sha256Concat(left[:], right[:])
+}
+
+// sha256Concat hashes the concatenation of all given byte slices.
+func sha256Concat(parts ...[]byte) common.Hash {
+	hasher := sha256.New()
+	for _, part := range parts {
+		hasher.Write(part)
+	}
+	return common.BytesToHash(hasher.Sum(nil))
+}
+
+// depositTree is an incremental Merkle tree that mirrors the deposit contract's storage.
+type depositTree struct {
+	branch     [depositContractTreeDepth]common.Hash
+	zeroHashes [depositContractTreeDepth]common.Hash
+	count      uint64
+}
+
+// newDepositTree creates an empty deposit tree.
+func newDepositTree() *depositTree {
+	tree := &depositTree{}
+	for height := 0; height < depositContractTreeDepth-1; height++ {
+		tree.zeroHashes[height+1] = sha256Concat(tree.zeroHashes[height][:], tree.zeroHashes[height][:])
+	}
+	return tree
+}
+
+// push adds a deposit data root to the tree (same algorithm as the contract's deposit function).
+func (tree *depositTree) push(node common.Hash) {
+	tree.count++
+	size := tree.count
+	for height := 0; height < depositContractTreeDepth; height++ {
+		if size&1 == 1 {
+			tree.branch[height] = node
+			return
+		}
+		node = sha256Concat(tree.branch[height][:], node[:])
+		size /= 2
+	}
+}
+
+// root computes the deposit root (same algorithm as the contract's get_deposit_root function).
+func (tree *depositTree) root() common.Hash {
+	var node common.Hash
+	size := tree.count
+	for height := 0; height < depositContractTreeDepth; height++ {
+		if size&1 == 1 {
+			node = sha256Concat(tree.branch[height][:], node[:])
+		} else {
+			node = sha256Concat(node[:], tree.zeroHashes[height][:])
+		}
+		size /= 2
+	}
+
+	var countLE [8]byte
+	binary.LittleEndian.PutUint64(countLE[:], tree.count)
+	return sha256Concat(node[:], countLE[:], make([]byte, 24))
+}
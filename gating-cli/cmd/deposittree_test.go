@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testDepositData is a deposit with its known deposit_data_root.
+type testDepositData struct {
+	pubkey, withdrawalCredentials, signature []byte
+	root                                     common.Hash
+}
+
+// Deposits of the contract tests (test/GatedDepositContract.test.js), 32 ETH each.
+var (
+	testDeposit = testDepositData{
+		pubkey:                common.FromHex(strings.Repeat("aa", 48)),
+		withdrawalCredentials: common.FromHex("0x010000000000000000000000deaddeaddeaddeaddeaddeaddeaddeaddeaddead"),
+		signature:             common.FromHex(strings.Repeat("bb", 96)),
+		root:                  common.HexToHash("0x44d02d2231ac4360374404cec35d277baad3a135f6228c3a0b64924832325576"),
+	}
+	testTopUpDeposit = testDepositData{
+		pubkey:                common.FromHex(strings.Repeat("aa", 48)),
+		withdrawalCredentials: make([]byte, 32),
+		signature:             make([]byte, 96),
+		root:                  common.HexToHash("0x4c96bb32f9feff56062a34087a2bb5243023f9aeb87ee199c3276ecc148fdf69"),
+	}
+)
+
+func TestDepositDataRoot(t *testing.T) {
+	for _, deposit := range []testDepositData{testDeposit, testTopUpDeposit} {
+		if root := depositDataRoot(deposit.pubkey, deposit.withdrawalCredentials, 32e9, deposit.signature); root != deposit.root {
+			t.Errorf("got deposit data root %s, want %s", root.Hex(), deposit.root.Hex())
+		}
+	}
+}
+
+func TestDepositTreeRoot(t *testing.T) {
+	tree := newDepositTree()
+	// get_deposit_root of an empty deposit contract, e.g. on mainnet before the first deposit
+	if root := tree.root(); root != common.HexToHash("0xd70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e") {
+		t.Errorf("got empty tree root %s", root.Hex())
+	}
+
+	// get_deposit_root of the deposit contract after each deposit
+	for i, step := range []struct {
+		leaf common.Hash
+		root string
+	}{
+		{testDeposit.root, "0xef3782ff227d25f54410129490094c85117bafcb2cc41be08c4cc1563fc30e71"},
+		{testTopUpDeposit.root, "0xcaacc8a84dbabe64e1cdb3c490e29affe06b98ee7660eee2499bbbd3cdd14aba"},
+		{testDeposit.root, "0x60f811a349169adf5884c6462ddbf87a98dc94445f97a9876796e775fbcbaf6b"},
+	} {
+		tree.push(step.leaf)
+		if root := tree.root(); root != common.HexToHash(step.root) {
+			t.Errorf("got tree root %s after %d deposit(s), want %s", root.Hex(), i+1, step.root)
+		}
+	}
+}
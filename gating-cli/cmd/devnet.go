@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// depositCliVersion is reported in generated deposit data files.
+// The launchpad and other tools only accept files from known staking-deposit-cli versions.
+const depositCliVersion = "2.7.0"
+
+var (
+	genDepositsCount             uint32
+	genDepositsStartIndex        uint32
+	genDepositsMnemonic          string
+	genDepositsMnemonicPassword  string
+	genDepositsCredentialsType   string
+	genDepositsWithdrawalAddress string
+	genDepositsAmount            uint64
+	genDepositsForkVersion       string
+	genDepositsBeaconAPI         string
+	genDepositsOutputDir         string
+)
+
+var devnetCmd = &cobra.Command{
+	Use:         "devnet",
+	Short:       "Tools for devnets using the gated deposit contract",
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+}
+
+var devnetGenDepositsCmd = &cobra.Command{
+	Use:   "gen-deposits",
+	Short: "Generate validator keys and deposit data",
+	Long: `Derives validator keys from a mnemonic (EIP-2333/EIP-2334), signs the deposit
+messages with the deposit domain of the connected chain and writes a
+staking-deposit-cli compatible deposit_data-*.json file.
+
+Credential types:
+  0x00 - BLS withdrawal credentials (derived from the withdrawal key m/12381/3600/i/0)
+  0x01 - Execution withdrawal credentials (requires --withdrawal-address)
+  0x02 - Compounding credentials (requires --withdrawal-address)
+  0x03 - ePBS builder credentials (requires --withdrawal-address)
+
+The genesis fork version is taken from --fork-version, the beacon node given by
+--beacon-api or the well-known network matching the chain ID, in that order.
+
+The mnemonic is not validated against the BIP-39 word list, so double-check it.
+Only use this for devnets and testnets.`,
+	RunE: runDevnetGenDeposits,
+}
+
+func init() {
+	devnetGenDepositsCmd.Flags().Uint32VarP(&genDepositsCount, "count", "n", 1, "Number of validators")
+	devnetGenDepositsCmd.Flags().Uint32Var(&genDepositsStartIndex, "start-index", 0, "Index of the first validator key")
+	devnetGenDepositsCmd.Flags().StringVar(&genDepositsMnemonic, "mnemonic", "", "Mnemonic to derive keys from (or MNEMONIC env var)")
+	devnetGenDepositsCmd.Flags().StringVar(&genDepositsMnemonicPassword, "mnemonic-password", "", "Optional BIP-39 mnemonic password")
+	devnetGenDepositsCmd.Flags().StringVarP(&genDepositsCredentialsType, "credentials-type", "c", "0x01", "Withdrawal credentials type (0x00, 0x01, 0x02 or 0x03)")
+	devnetGenDepositsCmd.Flags().StringVarP(&genDepositsWithdrawalAddress, "withdrawal-address", "w", "", "Withdrawal address for 0x01/0x02/0x03 credentials")
+	devnetGenDepositsCmd.Flags().Uint64Var(&genDepositsAmount, "amount", 32000000000, "Deposit amount per validator in gwei")
+	devnetGenDepositsCmd.Flags().StringVar(&genDepositsForkVersion, "fork-version", "", "Genesis fork version (e.g., 0x10000910)")
+	devnetGenDepositsCmd.Flags().StringVar(&genDepositsBeaconAPI, "beacon-api", "", "Beacon node API URL to fetch the genesis fork version from")
+	devnetGenDepositsCmd.Flags().StringVarP(&genDepositsOutputDir, "output-dir", "o", ".", "Directory to write the deposit data file to")
+
+	devnetCmd.AddCommand(devnetGenDepositsCmd)
+}
+
+func runDevnetGenDeposits(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Mnemonic
+	if genDepositsMnemonic == "" {
+		genDepositsMnemonic = os.Getenv("MNEMONIC")
+	}
+	if genDepositsMnemonic == "" && interactive {
+		input, err := promptPassword("Mnemonic")
+		if err != nil {
+			return fmt.Errorf("failed to read mnemonic: %w", err)
+		}
+		genDepositsMnemonic = input
+	}
+	if genDepositsMnemonic == "" {
+		return fmt.Errorf("mnemonic is required (use --mnemonic or MNEMONIC env var)")
+	}
+
+	// Credentials type
+	credentialsType, err := parseDepositType(genDepositsCredentialsType)
+	if err != nil {
+		return err
+	}
+	if credentialsType > 0x03 {
+		return fmt.Errorf("unsupported credentials type: %s (use 0x00, 0x01, 0x02 or 0x03)", genDepositsCredentialsType)
+	}
+
+	var withdrawalAddress common.Address
+	if credentialsType != 0x00 {
+		if !common.IsHexAddress(genDepositsWithdrawalAddress) {
+			return fmt.Errorf("valid withdrawal address is required for %s credentials (use --withdrawal-address)", depositTypeLabel(credentialsType))
+		}
+		withdrawalAddress = common.HexToAddress(genDepositsWithdrawalAddress)
+	}
+
+	if genDepositsAmount < minDepositAmount {
+		return fmt.Errorf("amount %d gwei is below the minimum of 1 ETH", genDepositsAmount)
+	}
+	if genDepositsCount == 0 {
+		return fmt.Errorf("count must be at least 1")
+	}
+
+	// Determine the genesis fork version of the connected chain
+	forkVersion, networkName, err := resolveGenesisForkVersion(ctx)
+	if err != nil {
+		return err
+	}
+	domain := computeDepositDomain(forkVersion)
+
+	seed, err := mnemonicToSeed(genDepositsMnemonic, genDepositsMnemonicPassword)
+	if err != nil {
+		return err
+	}
+
+	printHeader("═══ Generating Deposits ═══")
+	fmt.Println()
+	fmt.Printf("%sNetwork:%s           %s (chain ID %s)\n", colorCyan, colorReset, networkName, chainID.String())
+	fmt.Printf("%sFork Version:%s      0x%x\n", colorCyan, colorReset, forkVersion)
+	fmt.Printf("%sCredentials:%s       %s\n", colorCyan, colorReset, depositTypeLabel(credentialsType))
+	fmt.Printf("%sValidators:%s        %d - %d\n", colorCyan, colorReset, genDepositsStartIndex, genDepositsStartIndex+genDepositsCount-1)
+	fmt.Println()
+
+	entries := make([]depositDataEntry, 0, genDepositsCount)
+	for index := genDepositsStartIndex; index < genDepositsStartIndex+genDepositsCount; index++ {
+		signingKey, err := deriveBLSKey(seed, signingKeyPath(index))
+		if err != nil {
+			return fmt.Errorf("failed to derive signing key %d: %w", index, err)
+		}
+
+		credentials := make([]byte, 32)
+		credentials[0] = byte(credentialsType)
+		if credentialsType == 0x00 {
+			withdrawalKey, err := deriveBLSKey(seed, withdrawalKeyPath(index))
+			if err != nil {
+				return fmt.Errorf("failed to derive withdrawal key %d: %w", index, err)
+			}
+			pubkeyHash := sha256.Sum256(withdrawalKey.pubkey[:])
+			copy(credentials[1:], pubkeyHash[1:])
+		} else {
+			copy(credentials[12:], withdrawalAddress[:])
+		}
+
+		messageRoot := depositMessageRoot(signingKey.pubkey[:], credentials, genDepositsAmount)
+		signature, err := signingKey.sign(computeSigningRoot(messageRoot, domain))
+		if err != nil {
+			return fmt.Errorf("failed to sign deposit %d: %w", index, err)
+		}
+		dataRoot := depositDataRoot(signingKey.pubkey[:], credentials, genDepositsAmount, signature[:])
+
+		entries = append(entries, depositDataEntry{
+			Pubkey:                hex.EncodeToString(signingKey.pubkey[:]),
+			WithdrawalCredentials: hex.EncodeToString(credentials),
+			Amount:                genDepositsAmount,
+			Signature:             hex.EncodeToString(signature[:]),
+			DepositMessageRoot:    hex.EncodeToString(messageRoot[:]),
+			DepositDataRoot:       hex.EncodeToString(dataRoot[:]),
+			ForkVersion:           hex.EncodeToString(forkVersion[:]),
+			NetworkName:           networkName,
+			DepositCliVersion:     depositCliVersion,
+		})
+		fmt.Printf("  #%-5d 0x%s\n", index, entries[len(entries)-1].Pubkey)
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode deposit data: %w", err)
+	}
+	if err := os.MkdirAll(genDepositsOutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	outputPath := filepath.Join(genDepositsOutputDir, fmt.Sprintf("deposit_data-%d.json", time.Now().Unix()))
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write deposit data: %w", err)
+	}
+
+	fmt.Println()
+	printSuccess("Wrote %d deposits to %s", len(entries), outputPath)
+	return nil
+}
+
+// resolveGenesisForkVersion determines the genesis fork version and network name for the connected chain.
+func resolveGenesisForkVersion(ctx context.Context) ([4]byte, string, error) {
+	network := findNetworkByChainID(chainID.Uint64())
+	networkName := fmt.Sprintf("devnet-%s", chainID.String())
+	if network != nil {
+		networkName = network.Name
+	}
+
+	switch {
+	case genDepositsForkVersion != "":
+		forkVersion, err := parseForkVersion(genDepositsForkVersion)
+		return forkVersion, networkName, err
+	case genDepositsBeaconAPI != "":
+		genesis, err := fetchBeaconGenesis(ctx, genDepositsBeaconAPI)
+		if err != nil {
+			return [4]byte{}, "", err
+		}
+		return genesis.GenesisForkVersion, networkName, nil
+	case network != nil:
+		return network.GenesisForkVersion, networkName, nil
+	default:
+		return [4]byte{}, "", fmt.Errorf("unknown genesis fork version for chain ID %s (use --fork-version or --beacon-api)", chainID.String())
+	}
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of the drift command.
+const (
+	driftExitMatch = 0
+	driftExitDrift = 1
+	driftExitError = 2
+)
+
+var (
+	driftFile      string
+	driftFromBlock uint64
+	driftChunkSize uint64
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Check the gater state for drift from a policy file",
+	Long: `Compares the deposit type configs, admin set, deposit contract role holders and
+custom gater declared in a policy file with the chain state and prints the
+differences as JSON. Token balances are not compared, as they change with every
+deposit.
+
+Only the sections present in the policy file are compared. Admins listed under
+stickyAdmins must also be sticky on chain; admins listed under admins may be
+either.
+
+Exit codes:
+  0 - the chain state matches the policy
+  1 - drift detected
+  2 - error (invalid policy, RPC failure, ...)`,
+	Annotations:       map[string]string{annotationSignerOptional: "true"},
+	SilenceUsage:      true,
+	PersistentPreRunE: driftPreRun,
+	RunE:              runDrift,
+}
+
+func init() {
+	driftCmd.Flags().StringVarP(&driftFile, "file", "f", "", "Path to the expected policy file (YAML or JSON)")
+	driftCmd.Flags().Uint64Var(&driftFromBlock, "from-block", 0, "First block to scan for RoleGranted logs")
+	driftCmd.Flags().Uint64Var(&driftChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+
+	driftCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitCodeError{code: driftExitError, err: err}
+	})
+}
+
+// driftDifference is a single difference between the policy and the chain state.
+type driftDifference struct {
+	Kind     string `json:"kind"`
+	Subject  string `json:"subject"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// driftReport is the JSON output of the drift command.
+type driftReport struct {
+	Drift       bool               `json:"drift"`
+	PolicyFile  string             `json:"policyFile"`
+	ChainID     uint64             `json:"chainId"`
+	Gater       string             `json:"gater"`
+	Block       uint64             `json:"block"`
+	BlockHash   string             `json:"blockHash"`
+	Differences []*driftDifference `json:"differences"`
+}
+
+// driftPreRun runs the root pre-run and maps its errors (e.g. RPC connection failures) to the error exit code.
+func driftPreRun(cmd *cobra.Command, args []string) error {
+	if err := persistentPreRun(cmd, args); err != nil {
+		return &exitCodeError{code: driftExitError, err: err}
+	}
+	return nil
+}
+
+func runDrift(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	report, err := checkDrift(ctx)
+	if err != nil {
+		return &exitCodeError{code: driftExitError, err: err}
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return &exitCodeError{code: driftExitError, err: fmt.Errorf("failed to encode drift report: %w", err)}
+	}
+	fmt.Fprintln(os.Stdout, string(output))
+
+	if report.Drift {
+		return &exitCodeError{code: driftExitDrift, err: fmt.Errorf("drift detected: %d difference(s)", len(report.Differences))}
+	}
+	return nil
+}
+
+// checkDrift compares the policy file given by --file with the chain state at the latest block.
+func checkDrift(ctx context.Context) (*driftReport, error) {
+	if gaterAddr == (common.Address{}) {
+		return nil, fmt.Errorf("no gating contract configured on deposit contract")
+	}
+	if driftFile == "" {
+		return nil, fmt.Errorf("policy file is required (use --file)")
+	}
+
+	policy, err := loadPolicyFile(driftFile)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := resolveBlockHeader(ctx, "latest")
+	if err != nil {
+		return nil, err
+	}
+	blockNum := header.Number
+
+	report := &driftReport{
+		PolicyFile:  driftFile,
+		ChainID:     chainID.Uint64(),
+		Gater:       gaterAddr.Hex(),
+		Block:       blockNum.Uint64(),
+		BlockHash:   header.Hash().Hex(),
+		Differences: []*driftDifference{},
+	}
+	addDifference := func(kind, subject, expected, actual string) {
+		report.Differences = append(report.Differences, &driftDifference{
+			Kind:     kind,
+			Subject:  subject,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+
+	// Deposit type configs
+	depositTypes := make([]uint16, 0, len(policy.depositTypes))
+	for depositType := range policy.depositTypes {
+		depositTypes = append(depositTypes, depositType)
+	}
+	sort.Slice(depositTypes, func(i, j int) bool { return depositTypes[i] < depositTypes[j] })
+	configs := make([]gater.DepositGateConfig, len(depositTypes))
+	calls := make([]*gater.Call, len(depositTypes))
+	for i, depositType := range depositTypes {
+		calls[i] = gater.NewCall(&configs[i], "getDepositGateConfig", depositType)
+	}
+	if err := gaterClient.At(blockNum).Batch(ctx, calls); err != nil {
+		return nil, fmt.Errorf("failed to get deposit type configs: %w", err)
+	}
+	for i, depositType := range depositTypes {
+		actual := configs[i]
+		if expected := policy.depositTypes[depositType]; actual != expected {
+			addDifference("depositType", formatPolicyDepositType(depositType), formatGateConfig(expected), formatGateConfig(actual))
+		}
+	}
+
+	// Admins
+	if policy.manageAdmins {
+		differences, err := diffRoleMembers(ctx, gater.DefaultAdminRole, policy.admins, policy.stickyAdmins, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, difference := range differences {
+			difference.Kind = "admin"
+		}
+		report.Differences = append(report.Differences, differences...)
+	}
+
+	// Deposit contract role holders
+	if policy.manageDepositContracts {
+		differences, err := diffRoleMembers(ctx, gater.DepositContractRole, policy.depositContracts, nil, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, difference := range differences {
+			difference.Kind = "depositContract"
+		}
+		report.Differences = append(report.Differences, differences...)
+	}
+
+	// Custom gater
+	if policy.customGater != nil {
+		customGater, err := gaterClient.At(blockNum).CustomGater(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom gater: %w", err)
+		}
+		if customGater != *policy.customGater {
+			addDifference("customGater", "customGater", policy.customGater.Hex(), customGater.Hex())
+		}
+	}
+
+	report.Drift = len(report.Differences) > 0
+	return report, nil
+}
+
+// diffRoleMembers compares the holders of a role at a block with the expected set.
+// Members in expectedSticky must also hold the role as sticky role.
+func diffRoleMembers(ctx context.Context, role common.Hash, expected []common.Address, expectedSticky map[common.Address]bool, blockNum *big.Int) ([]*driftDifference, error) {
+	current, err := fetchRoleMembers(ctx, role, expected, driftFromBlock, blockNum.Uint64(), driftChunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	roleState := func(account common.Address, isMember bool) (string, error) {
+		if !isMember {
+			return "none", nil
+		}
+		isSticky, err := gaterClient.At(blockNum).IsStickyRole(ctx, role, account)
+		if err != nil {
+			return "", fmt.Errorf("failed to check sticky status of %s: %w", account.Hex(), err)
+		}
+		if isSticky {
+			return "sticky", nil
+		}
+		return "granted", nil
+	}
+
+	isCurrent := map[common.Address]bool{}
+	for _, account := range current {
+		isCurrent[account] = true
+	}
+	isExpected := map[common.Address]bool{}
+	differences := []*driftDifference{}
+	for _, account := range expected {
+		isExpected[account] = true
+		actual, err := roleState(account, isCurrent[account])
+		if err != nil {
+			return nil, err
+		}
+		expectedState := "granted"
+		if expectedSticky[account] {
+			expectedState = "sticky"
+		}
+		if actual == "none" || (expectedSticky[account] && actual != "sticky") {
+			differences = append(differences, &driftDifference{Subject: account.Hex(), Expected: expectedState, Actual: actual})
+		}
+	}
+	for _, account := range current {
+		if isExpected[account] {
+			continue
+		}
+		actual, err := roleState(account, true)
+		if err != nil {
+			return nil, err
+		}
+		differences = append(differences, &driftDifference{Subject: account.Hex(), Expected: "none", Actual: actual})
+	}
+	return differences, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+func TestDrift(t *testing.T) {
+	env := newTestEnv(t)
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	env.mustRun(nil, "export", "--file", path)
+
+	drift := func(expectedCode int) *driftReport {
+		t.Helper()
+		out, err := env.run(nil, "drift", "--file", path)
+		code := driftExitMatch
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			code = exitErr.code
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code != expectedCode {
+			t.Fatalf("exit code = %d, want %d (%v)\n%s", code, expectedCode, err, out)
+		}
+		var report driftReport
+		if err := json.Unmarshal([]byte(out), &report); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out)
+		}
+		return &report
+	}
+
+	report := drift(driftExitMatch)
+	if report.Drift || len(report.Differences) != 0 || report.Gater != env.chain.Gater.Hex() {
+		t.Errorf("expected no drift right after the export: %+v", report)
+	}
+
+	// Out-of-band changes, not made with the CLI
+	if _, err := env.adminGater().GrantRole(context.Background(), gater.DefaultAdminRole, env.chain.User); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.adminGater().SetDepositGateConfig(context.Background(), 0x01, gater.DepositGateConfig{Blocked: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	report = drift(driftExitDrift)
+	if !report.Drift || len(report.Differences) != 2 {
+		t.Fatalf("expected 2 differences, got %+v", report.Differences)
+	}
+	found := map[string]*driftDifference{}
+	for _, difference := range report.Differences {
+		found[difference.Kind] = difference
+	}
+	if admin := found["admin"]; admin == nil || admin.Subject != env.chain.User.Hex() || admin.Expected != "none" || admin.Actual != "granted" {
+		t.Errorf("unexpected admin difference: %+v", admin)
+	}
+	if depositType := found["depositType"]; depositType == nil || depositType.Subject != "0x01" {
+		t.Errorf("unexpected deposit type difference: %+v", depositType)
+	}
+}
+
+func TestDriftErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(nil, "drift")
+	var exitErr *exitCodeError
+	if !errors.As(err, &exitErr) || exitErr.code != driftExitError {
+		t.Fatalf("expected exit code %d without a policy file, got %v", driftExitError, err)
+	}
+	assertError(t, err, "policy file is required")
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// mainnetDepositContract is the deposit contract address the gater grants the deposit contract role to on deployment.
+var mainnetDepositContract = common.HexToAddress(mainnetDepositContractAddress)
+
+var (
+	exportFile      string
+	exportFormat    string
+	exportBlock     string
+	exportFromBlock uint64
+	exportChunkSize uint64
+	exportSweep     bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the gater state as a policy file",
+	Long: `Reads the gater state at a pinned block and writes it as a policy file
+(YAML or JSON) that can be restored with 'gating-cli apply'.
+
+The snapshot contains:
+  - the config of all well-known deposit types and every other deposit type
+    with a non-default config (found via DepositGateConfigChanged logs, or by
+    checking all 65536 deposit types with --sweep)
+  - all admins, with sticky admins listed separately
+  - all holders of the deposit contract role
+  - the custom gater
+  - all non-zero token balances (holders found via Transfer logs)
+
+Role holders and token holders are found by scanning logs, so --from-block should
+be at or before the gater deployment.
+
+When restoring onto a freshly deployed gater, sticky admins are granted as
+regular admins, as sticky roles cannot be granted by transactions.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Path to write the policy file to (default: stdout)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "Output format: yaml or json (default: from file extension, yaml otherwise)")
+	exportCmd.Flags().StringVar(&exportBlock, "block", "latest", "Block to read the state at (number or 'latest')")
+	exportCmd.Flags().Uint64Var(&exportFromBlock, "from-block", 0, "First block to scan for logs")
+	exportCmd.Flags().Uint64Var(&exportChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+	exportCmd.Flags().BoolVar(&exportSweep, "sweep", false, "Check the config of all 65536 deposit types instead of relying on logs")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if gaterAddr == (common.Address{}) {
+		return fmt.Errorf("no gating contract configured on deposit contract")
+	}
+
+	format, err := resolveExportFormat()
+	if err != nil {
+		return err
+	}
+
+	header, err := resolveBlockHeader(ctx, exportBlock)
+	if err != nil {
+		return err
+	}
+	if header.Number.Uint64() < exportFromBlock {
+		return fmt.Errorf("block %d is before --from-block %d", header.Number.Uint64(), exportFromBlock)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"block": header.Number.Uint64(),
+		"hash":  header.Hash().Hex(),
+	}).Info("Exporting gater state")
+
+	policy, err := exportPolicy(ctx, header)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if format == "json" {
+		data, err = json.MarshalIndent(policy, "", "  ")
+		data = append(data, '\n')
+	} else {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(policy)
+		data = buf.Bytes()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode policy: %w", err)
+	}
+
+	if exportFile == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write policy file: %w", err)
+	}
+
+	printSuccess("Exported gater state at block %d to %s", header.Number.Uint64(), exportFile)
+	fmt.Printf("%sDeposit Types:%s     %d\n", colorCyan, colorReset, len(policy.DepositTypes))
+	fmt.Printf("%sAdmins:%s            %d (%d sticky)\n", colorCyan, colorReset, len(policy.Admins)+len(policy.StickyAdmins), len(policy.StickyAdmins))
+	fmt.Printf("%sDeposit Contracts:%s %d\n", colorCyan, colorReset, len(policy.DepositContracts))
+	fmt.Printf("%sToken Holders:%s     %d\n", colorCyan, colorReset, len(policy.Balances))
+
+	return nil
+}
+
+// resolveExportFormat determines the output format from --format or the file extension.
+func resolveExportFormat() (string, error) {
+	format := strings.ToLower(exportFormat)
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(exportFile), ".json") {
+			format = "json"
+		}
+	}
+	switch format {
+	case "yaml", "yml":
+		return "yaml", nil
+	case "json":
+		return "json", nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use yaml or json)", exportFormat)
+	}
+}
+
+// resolveBlockHeader fetches the header of a block given as number or 'latest'.
+func resolveBlockHeader(ctx context.Context, block string) (*types.Header, error) {
+	var blockNum *big.Int
+	if block != "" && block != "latest" {
+		number, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block: %s", block)
+		}
+		blockNum = new(big.Int).SetUint64(number)
+	}
+	header, err := ethClient.HeaderByNumber(ctx, blockNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", block, err)
+	}
+	return header, nil
+}
+
+// exportPolicy reads the gater state at the given block as a policy.
+func exportPolicy(ctx context.Context, header *types.Header) (*gatePolicy, error) {
+	blockNum := header.Number
+	toBlock := blockNum.Uint64()
+
+	policy := &gatePolicy{
+		Version: policyVersion,
+		Source: &policySource{
+			ChainID:         chainID.Uint64(),
+			Gater:           gaterAddr.Hex(),
+			DepositContract: depositAddr.Hex(),
+			Block:           toBlock,
+			BlockHash:       header.Hash().Hex(),
+			ExportedAt:      time.Now().UTC().Format(time.RFC3339),
+		},
+		DepositTypes:     map[string]depositTypePolicy{},
+		Admins:           []string{},
+		StickyAdmins:     []string{},
+		DepositContracts: []string{},
+		Balances:         map[string]uint64{},
+	}
+
+	// Deposit type configs
+	depositTypes, err := exportDepositTypeCandidates(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	isKnown := map[uint16]bool{}
+	for _, known := range knownDepositTypes {
+		isKnown[known.typeID] = true
+	}
+	if exportSweep {
+		log.WithField("count", len(depositTypes)).Info("Sweeping deposit type configs")
+	}
+	configs := make([]gater.DepositGateConfig, len(depositTypes))
+	calls := make([]*gater.Call, len(depositTypes))
+	for i, depositType := range depositTypes {
+		calls[i] = gater.NewCall(&configs[i], "getDepositGateConfig", depositType)
+	}
+	if err := gaterClient.At(blockNum).Batch(ctx, calls); err != nil {
+		return nil, fmt.Errorf("failed to get deposit type configs: %w", err)
+	}
+	for i, depositType := range depositTypes {
+		config := configs[i]
+		if !isKnown[depositType] && !config.Blocked && !config.NoToken {
+			continue
+		}
+		policy.DepositTypes[formatPolicyDepositType(depositType)] = depositTypePolicy(config)
+	}
+
+	// Admins
+	log.Info("Collecting role holders")
+	var extraAdmins []common.Address
+	if signerAddress != (common.Address{}) {
+		extraAdmins = append(extraAdmins, signerAddress)
+	}
+	admins, err := fetchRoleMembers(ctx, gater.DefaultAdminRole, extraAdmins, exportFromBlock, toBlock, exportChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	sortAddresses(admins)
+	isSticky := make([]bool, len(admins))
+	stickyCalls := make([]*gater.Call, len(admins))
+	for i, admin := range admins {
+		stickyCalls[i] = gater.NewCall(&isSticky[i], "isStickyRole", gater.DefaultAdminRole, admin)
+	}
+	if err := gaterClient.At(blockNum).Batch(ctx, stickyCalls); err != nil {
+		return nil, fmt.Errorf("failed to check sticky status of admins: %w", err)
+	}
+	for i, admin := range admins {
+		if isSticky[i] {
+			policy.StickyAdmins = append(policy.StickyAdmins, admin.Hex())
+		} else {
+			policy.Admins = append(policy.Admins, admin.Hex())
+		}
+	}
+
+	// Deposit contract role holders
+	depositContracts, err := fetchRoleMembers(ctx, gater.DepositContractRole, []common.Address{depositAddr, mainnetDepositContract}, exportFromBlock, toBlock, exportChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	sortAddresses(depositContracts)
+	for _, depositContract := range depositContracts {
+		policy.DepositContracts = append(policy.DepositContracts, depositContract.Hex())
+	}
+
+	// Custom gater
+	customGater, err := gaterClient.At(blockNum).CustomGater(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom gater: %w", err)
+	}
+	customGaterHex := customGater.Hex()
+	policy.CustomGater = &customGaterHex
+
+	// Token balances
+	log.Info("Collecting token holders")
+	holders, err := fetchTokenHolders(ctx, exportFromBlock, toBlock, exportChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]*big.Int, len(holders))
+	balanceCalls := make([]*gater.Call, len(holders))
+	for i, holder := range holders {
+		balanceCalls[i] = gater.NewCall(&balances[i], "balanceOf", holder)
+	}
+	if err := gaterClient.At(blockNum).Batch(ctx, balanceCalls); err != nil {
+		return nil, fmt.Errorf("failed to get token balances: %w", err)
+	}
+	for i, holder := range holders {
+		balance := balances[i]
+		if balance.Sign() == 0 {
+			continue
+		}
+		if !balance.IsUint64() {
+			return nil, fmt.Errorf("balance of %s is too large to export: %s", holder.Hex(), balance.String())
+		}
+		policy.Balances[holder.Hex()] = balance.Uint64()
+	}
+
+	return policy, nil
+}
+
+// exportDepositTypeCandidates returns the deposit types whose config is read during an export:
+// the well-known deposit types and all deposit types with a DepositGateConfigChanged log, or
+// all deposit types when sweeping.
+func exportDepositTypeCandidates(ctx context.Context, toBlock uint64) ([]uint16, error) {
+	if exportSweep {
+		depositTypes := make([]uint16, 0, 0x10000)
+		for depositType := 0; depositType <= 0xffff; depositType++ {
+			depositTypes = append(depositTypes, uint16(depositType))
+		}
+		return depositTypes, nil
+	}
+
+	seen := map[uint16]bool{}
+	for _, known := range knownDepositTypes {
+		seen[known.typeID] = true
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{gaterAddr},
+		Topics:    [][]common.Hash{{gater.ABI.Events["DepositGateConfigChanged"].ID}},
+	}
+	logs, err := filterLogsChunked(ctx, query, exportFromBlock, toBlock, exportChunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch DepositGateConfigChanged logs: %w", err)
+	}
+	for _, vLog := range logs {
+		if !vLog.Removed && len(vLog.Topics) >= 2 {
+			seen[uint16(new(big.Int).SetBytes(vLog.Topics[1].Bytes()).Uint64())] = true
+		}
+	}
+
+	depositTypes := make([]uint16, 0, len(seen))
+	for depositType := range seen {
+		depositTypes = append(depositTypes, depositType)
+	}
+	sort.Slice(depositTypes, func(i, j int) bool { return depositTypes[i] < depositTypes[j] })
+	return depositTypes, nil
+}
+
+// fetchTokenHolders returns all accounts that received gater tokens in the given block range.
+func fetchTokenHolders(ctx context.Context, fromBlock, toBlock, chunkSize uint64) ([]common.Address, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{gaterAddr},
+		Topics:    [][]common.Hash{{gater.ABI.Events["Transfer"].ID}},
+	}
+	logs, err := filterLogsChunked(ctx, query, fromBlock, toBlock, chunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Transfer logs: %w", err)
+	}
+
+	seen := map[common.Address]bool{}
+	holders := []common.Address{}
+	for _, vLog := range logs {
+		if vLog.Removed || len(vLog.Topics) < 3 {
+			continue
+		}
+		holder := common.BytesToAddress(vLog.Topics[2].Bytes())
+		if holder != (common.Address{}) && !seen[holder] {
+			seen[holder] = true
+			holders = append(holders, holder)
+		}
+	}
+	sortAddresses(holders)
+	return holders, nil
+}
+
+// formatPolicyDepositType formats a deposit type as a policy key (0x00 style for credential types).
+func formatPolicyDepositType(depositType uint16) string {
+	if depositType <= 0xff {
+		return fmt.Sprintf("0x%02x", depositType)
+	}
+	return fmt.Sprintf("0x%04x", depositType)
+}
+
+// sortAddresses sorts addresses in place.
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool { return bytes.Compare(addresses[i][:], addresses[j][:]) < 0 })
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestExport(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "3", "--to", env.chain.User.Hex(), "--yes")
+	before, err := env.chain.Client.BlockNumber(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x42", "--blocked", "true", "--yes")
+
+	exportJSON := func(args ...string) *gatePolicy {
+		t.Helper()
+		out := env.mustRun(nil, append([]string{"export", "--format", "json"}, args...)...)
+		var policy gatePolicy
+		if err := json.Unmarshal([]byte(out), &policy); err != nil {
+			t.Fatalf("invalid JSON output: %v\n%s", err, out)
+		}
+		return &policy
+	}
+
+	policy := exportJSON()
+	if policy.Source == nil || policy.Source.ChainID != 1337 || policy.Source.Gater != env.chain.Gater.Hex() || policy.Source.DepositContract != env.chain.DepositContract.Hex() {
+		t.Errorf("unexpected source: %+v", policy.Source)
+	}
+	if config, ok := policy.DepositTypes["0x42"]; !ok || !config.Blocked || config.NoToken {
+		t.Errorf("config of 0x42 = %+v, want blocked", config)
+	}
+	if config, ok := policy.DepositTypes["0x00"]; !ok || config.Blocked || config.NoToken {
+		t.Errorf("config of 0x00 = %+v, want the default config", config)
+	}
+	admins := []string{env.chain.Admin.Hex(), env.chain.User.Hex()}
+	slices.SortFunc(admins, func(a, b string) int { return common.HexToAddress(a).Cmp(common.HexToAddress(b)) })
+	if !slices.Equal(policy.Admins, admins) || len(policy.StickyAdmins) != 0 {
+		t.Errorf("admins = %v (sticky %v), want %v", policy.Admins, policy.StickyAdmins, admins)
+	}
+	// The gater grants the role to the mainnet deposit contract on deployment
+	depositContracts := []string{mainnetDepositContract.Hex(), env.chain.DepositContract.Hex()}
+	slices.SortFunc(depositContracts, func(a, b string) int { return common.HexToAddress(a).Cmp(common.HexToAddress(b)) })
+	if !slices.Equal(policy.DepositContracts, depositContracts) {
+		t.Errorf("deposit contracts = %v, want %v", policy.DepositContracts, depositContracts)
+	}
+	if policy.CustomGater == nil || *policy.CustomGater != (common.Address{}).Hex() {
+		t.Errorf("custom gater = %v, want the zero address", policy.CustomGater)
+	}
+	if len(policy.Balances) != 1 || policy.Balances[env.chain.User.Hex()] != 3 {
+		t.Errorf("balances = %v, want 3 tokens of %s", policy.Balances, env.chain.User.Hex())
+	}
+
+	// The state is read at the pinned block
+	policy = exportJSON("--block", strconv.FormatUint(before, 10))
+	if _, ok := policy.DepositTypes["0x42"]; ok || len(policy.Admins) != 1 || policy.Source.Block != before {
+		t.Errorf("unexpected export at block %d: %+v", before, policy)
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "3", "--to", env.chain.User.Hex(), "--yes")
+
+	for _, name := range []string{"policy.yaml", "policy.json"} {
+		path := filepath.Join(t.TempDir(), name)
+		env.mustRun(nil, "export", "--file", path)
+
+		// Empty lists are written as well, so all sections stay managed
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Ext(name) == ".json" {
+			assertContains(t, string(raw), `"admins": [`, `"stickyAdmins": [`, `"depositContracts": [`)
+		} else {
+			assertContains(t, string(raw), "admins:", "stickyAdmins:", "depositContracts:")
+		}
+
+		policy, err := loadPolicyFile(path)
+		if err != nil {
+			t.Fatalf("failed to parse exported %s: %v", name, err)
+		}
+		if !policy.manageAdmins || !policy.manageDepositContracts {
+			t.Errorf("expected exported %s to manage admins and deposit contracts", name)
+		}
+		if len(policy.admins) != 1 || policy.admins[0] != env.chain.Admin {
+			t.Errorf("expected exported %s to contain the admin, got %v", name, policy.admins)
+		}
+
+		out := env.mustRun(nil, "plan", "--file", path)
+		assertContains(t, out, "No changes")
+	}
+}
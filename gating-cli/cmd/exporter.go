@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exporterListen    string
+	exporterInterval  time.Duration
+	exporterWatch     []string
+	exporterFromBlock string
+	exporterChunkSize uint64
+	exporterConfirms  uint64
+	exporterOnce      bool
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve Prometheus metrics of the gater and deposit contract",
+	Long: `Periodically reads the gater and the deposit contract and serves the state as
+Prometheus metrics on /metrics:
+
+  gating_token_total_supply                  Total token supply
+  gating_token_balance{address}              Token balance of each --watch address
+  gating_deposit_type_blocked{deposit_type}  1 if deposits of the type are blocked
+  gating_deposit_type_no_token{deposit_type} 1 if deposits of the type don't require a token
+  gating_admin_count                         Number of admins (only with --from-block)
+  gating_custom_gater_set                    1 if a custom gater is set
+  gating_deposit_count                       Deposit count of the deposit contract
+  gating_token_burns_total                   Tokens burned since --from-block
+  gating_deposits_total{deposit_type}        Deposits per type since --from-block
+
+Deposit type gauges cover the well-known deposit types and every type with a
+DepositGateConfigChanged log. Logs are scanned incrementally, starting at
+--from-block (default: the latest block). Set it to the deployment block of the
+gater to include the admins and deposit types of earlier logs. Logs are only
+counted once their block has --confirmations confirmations, as the counters
+can't be decreased again if a block is reorged out.
+
+The admin count is only exported with --from-block, as it would otherwise miss
+the admins granted before the exporter was started. Admins are collected from
+RoleGranted logs (plus the --watch addresses) and checked with hasRole.
+
+With --once, the metrics are collected once and printed in the Prometheus text
+format (e.g. for the node_exporter textfile collector).
+
+This command is read-only and doesn't require a private key.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runExporter,
+}
+
+func init() {
+	exporterCmd.Flags().StringVar(&exporterListen, "listen", ":9090", "Address to serve the metrics on")
+	exporterCmd.Flags().DurationVar(&exporterInterval, "interval", 30*time.Second, "Interval between metric updates")
+	exporterCmd.Flags().StringSliceVar(&exporterWatch, "watch", nil, "Addresses to export the token balance of (repeat or comma-separate)")
+	exporterCmd.Flags().StringVar(&exporterFromBlock, "from-block", "latest", "First block to scan for logs (number or 'latest')")
+	exporterCmd.Flags().Uint64Var(&exporterChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+	exporterCmd.Flags().Uint64Var(&exporterConfirms, "confirmations", 6, "Number of confirmations before the logs of a block are counted")
+	exporterCmd.Flags().BoolVar(&exporterOnce, "once", false, "Collect the metrics once, print them and exit")
+}
+
+// gaterExporter collects the gater metrics. Logs are scanned incrementally, so each
+// update only fetches the logs of the blocks since the previous update.
+type gaterExporter struct {
+	registry *prometheus.Registry
+	watch    []common.Address
+
+	// Log scan state
+	nextBlock       uint64
+	adminCandidates map[common.Address]bool // nil if admins aren't counted
+	depositTypes    map[uint16]bool
+
+	blockNumber        prometheus.Gauge
+	totalSupply        prometheus.Gauge
+	balance            *prometheus.GaugeVec
+	depositTypeBlocked *prometheus.GaugeVec
+	depositTypeNoToken *prometheus.GaugeVec
+	adminCount         prometheus.Gauge // nil if admins aren't counted
+	customGaterSet     prometheus.Gauge
+	depositCount       prometheus.Gauge
+	tokenBurns         prometheus.Counter
+	deposits           *prometheus.CounterVec
+	lastUpdate         prometheus.Gauge
+	updateSuccess      prometheus.Gauge
+	updateErrors       prometheus.Counter
+}
+
+// newGaterExporter creates an exporter that scans the logs from fromBlock. The admin count is
+// only exported with countAdmins, i.e. if the scan covers all RoleGranted logs of the gater.
+func newGaterExporter(watch []common.Address, fromBlock uint64, countAdmins bool) *gaterExporter {
+	exporter := &gaterExporter{
+		registry:     prometheus.NewRegistry(),
+		watch:        watch,
+		nextBlock:    fromBlock,
+		depositTypes: map[uint16]bool{},
+
+		blockNumber: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_block_number",
+			Help: "Block the metrics were read at.",
+		}),
+		totalSupply: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_token_total_supply",
+			Help: "Total supply of the deposit token.",
+		}),
+		balance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gating_token_balance",
+			Help: "Deposit token balance of a watched address.",
+		}, []string{"address"}),
+		depositTypeBlocked: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gating_deposit_type_blocked",
+			Help: "1 if deposits of the type are blocked.",
+		}, []string{"deposit_type"}),
+		depositTypeNoToken: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "gating_deposit_type_no_token",
+			Help: "1 if deposits of the type don't require a token.",
+		}, []string{"deposit_type"}),
+		customGaterSet: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_custom_gater_set",
+			Help: "1 if a custom gater is set.",
+		}),
+		depositCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_deposit_count",
+			Help: "Deposit count of the deposit contract (get_deposit_count).",
+		}),
+		tokenBurns: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gating_token_burns_total",
+			Help: "Deposit tokens burned since the first scanned block.",
+		}),
+		deposits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gating_deposits_total",
+			Help: "Deposits since the first scanned block, by deposit type.",
+		}, []string{"deposit_type"}),
+		lastUpdate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_exporter_last_update_timestamp_seconds",
+			Help: "Time of the last successful update.",
+		}),
+		updateSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_exporter_update_success",
+			Help: "1 if the last update succeeded.",
+		}),
+		updateErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gating_exporter_update_errors_total",
+			Help: "Failed updates.",
+		}),
+	}
+
+	info := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gating_info",
+		Help: "Contracts the metrics are exported for.",
+		ConstLabels: prometheus.Labels{
+			"chain_id":         chainID.String(),
+			"deposit_contract": depositAddr.Hex(),
+			"gater":            gaterAddr.Hex(),
+		},
+	})
+	info.Set(1)
+
+	exporter.registry.MustRegister(
+		info,
+		exporter.blockNumber,
+		exporter.totalSupply,
+		exporter.balance,
+		exporter.depositTypeBlocked,
+		exporter.depositTypeNoToken,
+		exporter.customGaterSet,
+		exporter.depositCount,
+		exporter.tokenBurns,
+		exporter.deposits,
+		exporter.lastUpdate,
+		exporter.updateSuccess,
+		exporter.updateErrors,
+	)
+
+	if countAdmins {
+		exporter.adminCount = prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gating_admin_count",
+			Help: "Number of accounts with the admin role.",
+		})
+		exporter.registry.MustRegister(exporter.adminCount)
+		exporter.adminCandidates = map[common.Address]bool{}
+		for _, account := range watch {
+			exporter.adminCandidates[account] = true
+		}
+	}
+
+	for _, known := range knownDepositTypes {
+		exporter.depositTypes[known.typeID] = true
+	}
+	return exporter
+}
+
+func runExporter(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if gaterAddr == (common.Address{}) {
+		return fmt.Errorf("no gating contract configured on deposit contract")
+	}
+
+	watch := []common.Address{}
+	for _, entry := range exporterWatch {
+		if !common.IsHexAddress(entry) {
+			return fmt.Errorf("invalid watch address: %s", entry)
+		}
+		watch = append(watch, common.HexToAddress(entry))
+	}
+
+	var fromBlock uint64
+	if exporterFromBlock == "latest" {
+		latest, err := getLatestBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %w", err)
+		}
+		// Start with the first block that isn't confirmed yet
+		if exporterConfirms <= latest.Uint64() {
+			fromBlock = latest.Uint64() - exporterConfirms + 1
+		}
+	} else {
+		var err error
+		fromBlock, err = strconv.ParseUint(exporterFromBlock, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid from-block: %s", exporterFromBlock)
+		}
+	}
+
+	exporter := newGaterExporter(watch, fromBlock, exporterFromBlock != "latest")
+	if exporterOnce {
+		if err := exporter.update(ctx); err != nil {
+			return err
+		}
+		return exporter.writeText(os.Stdout)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", exporterListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", exporterListen, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(exporter.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Metrics server failed")
+			stop()
+		}
+	}()
+	log.WithField("address", listener.Addr().String()).Info("Serving metrics on /metrics")
+
+	exporter.run(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// run updates the metrics at the configured interval until the context is cancelled.
+func (exporter *gaterExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(exporterInterval)
+	defer ticker.Stop()
+	for {
+		if err := exporter.update(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Warn("Failed to update metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update reads the state at the latest block and scans the logs since the previous update.
+func (exporter *gaterExporter) update(ctx context.Context) error {
+	if err := exporter.collect(ctx); err != nil {
+		exporter.updateSuccess.Set(0)
+		exporter.updateErrors.Inc()
+		return err
+	}
+	exporter.updateSuccess.Set(1)
+	exporter.lastUpdate.SetToCurrentTime()
+	return nil
+}
+
+func (exporter *gaterExporter) collect(ctx context.Context) error {
+	header, err := ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	latest := header.Number.Uint64()
+
+	// Scan the confirmed blocks chunk by chunk, so a failed scan continues at the failed chunk
+	for exporterConfirms <= latest && exporter.nextBlock <= latest-exporterConfirms {
+		confirmed := latest - exporterConfirms
+		end := confirmed
+		if exporterChunkSize > 0 && confirmed-exporter.nextBlock >= exporterChunkSize {
+			end = exporter.nextBlock + exporterChunkSize - 1
+		}
+		if err := exporter.scanLogs(ctx, exporter.nextBlock, end); err != nil {
+			return err
+		}
+		exporter.nextBlock = end + 1
+	}
+
+	// Contract state, pinned to the latest block
+	var totalSupply *big.Int
+	var customGater common.Address
+	calls := []*gater.Call{
+		gater.NewCall(&totalSupply, "totalSupply"),
+		gater.NewCall(&customGater, "getCustomGater"),
+	}
+
+	balances := make([]*big.Int, len(exporter.watch))
+	for i, account := range exporter.watch {
+		calls = append(calls, gater.NewCall(&balances[i], "balanceOf", account))
+	}
+
+	depositTypes := make([]uint16, 0, len(exporter.depositTypes))
+	for depositType := range exporter.depositTypes {
+		depositTypes = append(depositTypes, depositType)
+	}
+	configs := make([]gater.DepositGateConfig, len(depositTypes))
+	for i, depositType := range depositTypes {
+		calls = append(calls, gater.NewCall(&configs[i], "getDepositGateConfig", depositType))
+	}
+
+	candidates := make([]common.Address, 0, len(exporter.adminCandidates))
+	for account := range exporter.adminCandidates {
+		candidates = append(candidates, account)
+	}
+	isAdmin := make([]bool, len(candidates))
+	for i, account := range candidates {
+		calls = append(calls, gater.NewCall(&isAdmin[i], "hasRole", gater.DefaultAdminRole, account))
+	}
+
+	if err := gaterClient.At(header.Number).Batch(ctx, calls); err != nil {
+		return fmt.Errorf("failed to read gater state: %w", err)
+	}
+	depositCount, err := getDepositCount(ctx, header.Number)
+	if err != nil {
+		return err
+	}
+
+	exporter.blockNumber.Set(float64(latest))
+	exporter.totalSupply.Set(bigToFloat(totalSupply))
+	for i, account := range exporter.watch {
+		exporter.balance.WithLabelValues(account.Hex()).Set(bigToFloat(balances[i]))
+	}
+	for i, depositType := range depositTypes {
+		label := formatPolicyDepositType(depositType)
+		exporter.depositTypeBlocked.WithLabelValues(label).Set(boolToFloat(configs[i].Blocked))
+		exporter.depositTypeNoToken.WithLabelValues(label).Set(boolToFloat(configs[i].NoToken))
+	}
+	if exporter.adminCount != nil {
+		admins := 0
+		for _, granted := range isAdmin {
+			if granted {
+				admins++
+			}
+		}
+		exporter.adminCount.Set(float64(admins))
+	}
+	exporter.customGaterSet.Set(boolToFloat(customGater != (common.Address{})))
+	exporter.depositCount.Set(float64(depositCount))
+
+	log.WithField("block", latest).Debug("Updated metrics")
+	return nil
+}
+
+// scanLogs scans the logs of a confirmed block range (at most one chunk) for deposits, token burns,
+// admin candidates and configured deposit types. The counters are only increased once the
+// whole range was scanned, so a failed scan is retried without counting logs twice.
+func (exporter *gaterExporter) scanLogs(ctx context.Context, fromBlock, toBlock uint64) error {
+	deposits, err := fetchDepositEvents(ctx, fromBlock, toBlock, exporterChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch deposit events: %w", err)
+	}
+	burns, err := fetchTokenBurns(ctx, fromBlock, toBlock, exporterChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch token burns: %w", err)
+	}
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{gaterAddr},
+		Topics: [][]common.Hash{{
+			gater.ABI.Events["RoleGranted"].ID,
+			gater.ABI.Events["DepositGateConfigChanged"].ID,
+		}},
+	}
+	logs, err := filterLogsChunked(ctx, query, fromBlock, toBlock, exporterChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch gater logs: %w", err)
+	}
+
+	for _, deposit := range deposits {
+		exporter.deposits.WithLabelValues(formatPolicyDepositType(deposit.DepositType)).Inc()
+	}
+	for _, burn := range burns {
+		exporter.tokenBurns.Add(bigToFloat(burn.Amount))
+	}
+	for _, vLog := range logs {
+		if vLog.Removed || len(vLog.Topics) < 2 {
+			continue
+		}
+		switch vLog.Topics[0] {
+		case gater.ABI.Events["RoleGranted"].ID:
+			if exporter.adminCandidates != nil && vLog.Topics[1] == gater.DefaultAdminRole && len(vLog.Topics) >= 3 {
+				exporter.adminCandidates[common.BytesToAddress(vLog.Topics[2].Bytes())] = true
+			}
+		case gater.ABI.Events["DepositGateConfigChanged"].ID:
+			exporter.depositTypes[uint16(new(big.Int).SetBytes(vLog.Topics[1].Bytes()).Uint64())] = true
+		}
+	}
+
+	log.WithFields(map[string]interface{}{
+		"from":     fromBlock,
+		"to":       toBlock,
+		"deposits": len(deposits),
+		"burns":    len(burns),
+	}).Debug("Scanned logs")
+	return nil
+}
+
+// writeText writes the metrics in the Prometheus text format.
+func (exporter *gaterExporter) writeText(w io.Writer) error {
+	families, err := exporter.registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// bigToFloat converts a token amount to a metric value.
+func bigToFloat(value *big.Int) float64 {
+	if value == nil {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(value).Float64()
+	return f
+}
+
+// boolToFloat converts a flag to a metric value.
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExporterOnce(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "5", "--to", env.chain.User.Hex(), "--yes")
+	env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x42", "--blocked", "true", "--yes")
+
+	out := env.mustRun(nil, "exporter", "--once", "--from-block", "0", "--confirmations", "0", "--chunk-size", "2", "--watch", env.chain.User.Hex())
+	assertContains(t, out,
+		"gating_token_total_supply 5\n",
+		`gating_token_balance{address="`+env.chain.User.Hex()+`"} 5`,
+		"gating_admin_count 2\n",
+		"gating_custom_gater_set 0\n",
+		"gating_deposit_count 0\n",
+		`gating_deposit_type_blocked{deposit_type="0x00"} 0`,
+		`gating_deposit_type_blocked{deposit_type="0x42"} 1`,
+		`gating_deposit_type_no_token{deposit_type="0xffff"} 0`,
+		"gating_token_burns_total 0\n",
+		"gating_exporter_update_success 1\n",
+	)
+
+	// Without --from-block, the admins granted before the start can't be counted
+	out = env.mustRun(nil, "exporter", "--once", "--watch", env.chain.User.Hex())
+	assertContains(t, out, `gating_deposit_type_blocked{deposit_type="0x00"} 0`)
+	if strings.Contains(out, "gating_admin_count") {
+		t.Errorf("expected no admin count without --from-block:\n%s", out)
+	}
+
+	// Logs of blocks without enough confirmations aren't counted yet
+	before, err := env.chain.Client.BlockNumber(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x43", "--blocked", "true", "--yes")
+	latest, err := env.chain.Client.BlockNumber(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = env.mustRun(nil, "exporter", "--once", "--from-block", "0", "--confirmations", strconv.FormatUint(latest-before, 10))
+	assertContains(t, out, `gating_deposit_type_blocked{deposit_type="0x42"} 1`, "gating_admin_count 2\n")
+	if strings.Contains(out, `deposit_type="0x43"`) {
+		t.Errorf("expected the unconfirmed config change to be skipped:\n%s", out)
+	}
+}
+
+func TestExporterErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(nil, "exporter", "--once", "--watch", "0x1234")
+	assertError(t, err, "invalid watch address")
+
+	_, err = env.run(nil, "exporter", "--once", "--from-block", "abc")
+	assertError(t, err, "invalid from-block: abc")
+}
@@ -0,0 +1,527 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	faucetListen       string
+	faucetMaxAmount    uint64
+	faucetMaxBalance   uint64
+	faucetAddressLimit int
+	faucetIPLimit      int
+	faucetWindow       time.Duration
+	faucetQueueSize    int
+	faucetHistoryPath  string
+	faucetTrustProxy   bool
+)
+
+var faucetCmd = &cobra.Command{
+	Use:   "faucet",
+	Short: "Token faucet for devnets and testnets",
+	Long: `Runs a faucet that mints deposit tokens on request, so users of gated devnets
+and testnets don't have to ask an admin for tokens.`,
+}
+
+var faucetServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the token faucet",
+	Long: `Serves a web form and an HTTP API where users request deposit tokens for an address.
+
+Tokens are minted with the signer key, which needs the admin role. Requests are
+limited per address and per client IP within --window, and refused if the
+balance of the address would exceed --max-balance. Requests are queued and
+minted one at a time, so transactions never compete for the same nonce.
+
+The request history is appended to --history and loaded on restart, so the
+rate limits survive restarts. Mints that were sent but not confirmed are
+recorded as unconfirmed with their transaction hash and count towards the
+limits, as they may still be mined.
+
+API:
+  POST /api/request  {"address": "0x...", "amount": "1"}  mint tokens (waits for the receipt)
+  GET  /api/info                                        faucet limits and network`,
+	Args: cobra.NoArgs,
+	RunE: runFaucetServe,
+}
+
+func init() {
+	faucetServeCmd.Flags().StringVar(&faucetListen, "listen", ":8080", "Address to serve the faucet on")
+	faucetServeCmd.Flags().Uint64Var(&faucetMaxAmount, "max-amount", 1, "Maximum number of tokens per request")
+	faucetServeCmd.Flags().Uint64Var(&faucetMaxBalance, "max-balance", 10, "Refuse requests that would raise the balance of an address above this")
+	faucetServeCmd.Flags().IntVar(&faucetAddressLimit, "address-limit", 1, "Maximum number of requests per address within --window")
+	faucetServeCmd.Flags().IntVar(&faucetIPLimit, "ip-limit", 5, "Maximum number of requests per client IP within --window")
+	faucetServeCmd.Flags().DurationVar(&faucetWindow, "window", 24*time.Hour, "Time window of the rate limits")
+	faucetServeCmd.Flags().IntVar(&faucetQueueSize, "queue-size", 100, "Maximum number of queued requests")
+	faucetServeCmd.Flags().StringVar(&faucetHistoryPath, "history", "", "Request history file (default: faucet-history-<chain ID>.jsonl next to the config file)")
+	faucetServeCmd.Flags().BoolVar(&faucetTrustProxy, "trust-proxy", false, "Use the X-Forwarded-For header as client IP (only behind a reverse proxy)")
+
+	faucetCmd.AddCommand(faucetServeCmd)
+}
+
+// Faucet request states.
+const (
+	faucetStatusPending     = "pending"
+	faucetStatusMinted      = "minted"
+	faucetStatusFailed      = "failed"
+	faucetStatusUnconfirmed = "unconfirmed" // sent, but the receipt wasn't received (e.g. on shutdown)
+)
+
+// faucetRecord is a faucet request, as stored in the history file.
+type faucetRecord struct {
+	ID      uint64    `json:"id"`
+	Time    time.Time `json:"time"`
+	Address string    `json:"address"`
+	IP      string    `json:"ip"`
+	Amount  string    `json:"amount"`
+	Status  string    `json:"status"`
+	TxHash  string    `json:"txHash,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// faucetRequest is a queued faucet request.
+type faucetRequest struct {
+	record    *faucetRecord
+	recipient common.Address
+	amount    *big.Int
+	done      chan struct{}
+}
+
+// faucetError is a refused faucet request with the HTTP status to respond with.
+type faucetError struct {
+	status  int
+	message string
+}
+
+func (err *faucetError) Error() string {
+	return err.message
+}
+
+// tokenFaucet limits, queues and mints faucet requests.
+type tokenFaucet struct {
+	mu      sync.Mutex
+	records []*faucetRecord // requests within the rate limit window
+	nextID  uint64
+	history *os.File
+	queue   chan *faucetRequest
+}
+
+func runFaucetServe(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if err := checkAdminRole(ctx); err != nil {
+		return err
+	}
+	if faucetMaxAmount == 0 {
+		return fmt.Errorf("--max-amount must be at least 1")
+	}
+
+	historyPath := faucetHistoryPath
+	if historyPath == "" {
+		var err error
+		if historyPath, err = chainDataPath("faucet-history", "jsonl"); err != nil {
+			return err
+		}
+	}
+	faucet, err := newTokenFaucet(historyPath)
+	if err != nil {
+		return err
+	}
+	defer faucet.history.Close()
+
+	// The faucet mints without asking, so confirm once before serving
+	if !assumeYes {
+		fmt.Println()
+		printHeader("═══ Confirm Faucet ═══")
+		printTransactionContext()
+		fmt.Printf("%sLimits:%s            %d token(s) per request, %d request(s) per address, %d per IP within %s, max balance %d\n",
+			colorCyan, colorReset, faucetMaxAmount, faucetAddressLimit, faucetIPLimit, faucetWindow, faucetMaxBalance)
+		fmt.Println()
+	}
+	confirmed, err := requireConfirmation(fmt.Sprintf("Mint tokens for faucet requests on %s", networkLabel()))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("faucet not confirmed")
+	}
+	batchConfirmed = true
+	defer endTransactionBatch()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", faucetListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", faucetListen, err)
+	}
+	server := &http.Server{Handler: faucet.handler(ctx), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Faucet server failed")
+			stop()
+		}
+	}()
+	log.WithFields(map[string]interface{}{
+		"address": listener.Addr().String(),
+		"history": historyPath,
+	}).Info("Serving faucet")
+
+	faucet.run(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// newTokenFaucet loads the request history and opens it for appending.
+func newTokenFaucet(historyPath string) (*tokenFaucet, error) {
+	faucet := &tokenFaucet{
+		nextID: 1,
+		queue:  make(chan *faucetRequest, faucetQueueSize),
+	}
+
+	if file, err := os.Open(historyPath); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := &faucetRecord{}
+			if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("failed to parse faucet history %s: %w", historyPath, err)
+			}
+			faucet.records = append(faucet.records, record)
+			if record.ID >= faucet.nextID {
+				faucet.nextID = record.ID + 1
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read faucet history %s: %w", historyPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to open faucet history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(historyPath), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create faucet history directory: %w", err)
+	}
+	history, err := os.OpenFile(historyPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open faucet history: %w", err)
+	}
+	faucet.history = history
+	return faucet, nil
+}
+
+// enqueue checks the rate limits of a request and queues it. Queued requests count
+// towards the limits until they failed; unconfirmed requests may have been minted, so
+// they keep counting.
+func (faucet *tokenFaucet) enqueue(recipient common.Address, ip string, amount *big.Int) (*faucetRequest, error) {
+	faucet.mu.Lock()
+	defer faucet.mu.Unlock()
+
+	// Forget requests outside the window
+	cutoff := time.Now().Add(-faucetWindow)
+	records := faucet.records[:0]
+	for _, record := range faucet.records {
+		if record.Time.After(cutoff) {
+			records = append(records, record)
+		}
+	}
+	faucet.records = records
+
+	addressCount, ipCount := 0, 0
+	for _, record := range faucet.records {
+		if record.Status == faucetStatusFailed {
+			continue
+		}
+		if strings.EqualFold(record.Address, recipient.Hex()) {
+			addressCount++
+		}
+		if record.IP == ip {
+			ipCount++
+		}
+	}
+	if addressCount >= faucetAddressLimit {
+		return nil, &faucetError{http.StatusTooManyRequests, fmt.Sprintf("address %s already received tokens, try again later (limit: %d request(s) per %s)", recipient.Hex(), faucetAddressLimit, faucetWindow)}
+	}
+	if ipCount >= faucetIPLimit {
+		return nil, &faucetError{http.StatusTooManyRequests, fmt.Sprintf("too many requests from your IP, try again later (limit: %d request(s) per %s)", faucetIPLimit, faucetWindow)}
+	}
+
+	request := &faucetRequest{
+		record: &faucetRecord{
+			ID:      faucet.nextID,
+			Time:    time.Now().UTC(),
+			Address: recipient.Hex(),
+			IP:      ip,
+			Amount:  amount.String(),
+			Status:  faucetStatusPending,
+		},
+		recipient: recipient,
+		amount:    amount,
+		done:      make(chan struct{}),
+	}
+	select {
+	case faucet.queue <- request:
+	default:
+		return nil, &faucetError{http.StatusServiceUnavailable, "faucet is busy, try again later"}
+	}
+	faucet.nextID++
+	faucet.records = append(faucet.records, request.record)
+	return request, nil
+}
+
+// run mints the queued requests one at a time until the context is cancelled.
+func (faucet *tokenFaucet) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-faucet.queue:
+			faucet.process(ctx, request)
+		}
+	}
+}
+
+// process mints the tokens of a request and appends it to the history.
+func (faucet *tokenFaucet) process(ctx context.Context, request *faucetRequest) {
+	var txHash common.Hash
+	var unconfirmed *gater.UnconfirmedError
+	err := func() error {
+		balance, err := gaterClient.BalanceOf(ctx, request.recipient)
+		if err != nil {
+			return fmt.Errorf("failed to get balance: %w", err)
+		}
+		if new(big.Int).Add(balance, request.amount).Cmp(new(big.Int).SetUint64(faucetMaxBalance)) > 0 {
+			return fmt.Errorf("address %s already holds %s tokens (max balance: %d)", request.recipient.Hex(), balance.String(), faucetMaxBalance)
+		}
+		receipt, err := mintTokens(ctx, request.recipient, request.amount)
+		if err != nil {
+			return err
+		}
+		txHash = receipt.TxHash
+		return nil
+	}()
+
+	faucet.mu.Lock()
+	switch {
+	case errors.As(err, &unconfirmed):
+		log.WithError(err).WithFields(map[string]interface{}{
+			"address": request.recipient.Hex(),
+			"txHash":  unconfirmed.Tx.Hash().Hex(),
+		}).Warn("Faucet mint was sent, but not confirmed")
+		request.record.Status = faucetStatusUnconfirmed
+		request.record.TxHash = unconfirmed.Tx.Hash().Hex()
+		request.record.Error = err.Error()
+	case err != nil:
+		log.WithError(err).WithField("address", request.recipient.Hex()).Warn("Faucet request failed")
+		request.record.Status = faucetStatusFailed
+		request.record.Error = err.Error()
+	default:
+		request.record.Status = faucetStatusMinted
+		request.record.TxHash = txHash.Hex()
+	}
+	line, _ := json.Marshal(request.record)
+	faucet.mu.Unlock()
+
+	if _, err := faucet.history.Write(append(line, '\n')); err != nil {
+		log.WithError(err).Error("Failed to write faucet history")
+	}
+	close(request.done)
+}
+
+// faucetRequestBody is the body of an API request.
+type faucetRequestBody struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+// faucetResponse is the response to a faucet request.
+type faucetResponse struct {
+	ID      uint64 `json:"id,omitempty"`
+	Status  string `json:"status"`
+	Address string `json:"address,omitempty"`
+	Amount  string `json:"amount,omitempty"`
+	TxHash  string `json:"txHash,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// handler returns the HTTP handler of the web form and the API.
+func (faucet *tokenFaucet) handler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		faucet.renderPage(w, http.StatusOK, nil)
+	})
+	mux.HandleFunc("GET /api/info", func(w http.ResponseWriter, r *http.Request) {
+		writeFaucetJSON(w, http.StatusOK, map[string]interface{}{
+			"network":      networkLabel(),
+			"chainId":      chainID.Uint64(),
+			"gater":        gaterAddr.Hex(),
+			"maxAmount":    faucetMaxAmount,
+			"maxBalance":   faucetMaxBalance,
+			"addressLimit": faucetAddressLimit,
+			"ipLimit":      faucetIPLimit,
+			"window":       faucetWindow.String(),
+		})
+	})
+	mux.HandleFunc("POST /api/request", func(w http.ResponseWriter, r *http.Request) {
+		// The web form posts form values and gets the page back, API clients post JSON
+		isForm := !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+		status, response := faucet.handleRequest(ctx, r, isForm)
+		if isForm {
+			faucet.renderPage(w, status, response)
+		} else {
+			writeFaucetJSON(w, status, response)
+		}
+	})
+	return mux
+}
+
+// handleRequest validates, queues and waits for a faucet request.
+func (faucet *tokenFaucet) handleRequest(ctx context.Context, r *http.Request, isForm bool) (int, *faucetResponse) {
+	body := faucetRequestBody{}
+	if isForm {
+		body.Address = r.FormValue("address")
+		body.Amount = r.FormValue("amount")
+	} else if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 4096)).Decode(&body); err != nil {
+		return http.StatusBadRequest, &faucetResponse{Status: faucetStatusFailed, Error: "invalid request body"}
+	}
+
+	body.Address = strings.TrimSpace(body.Address)
+	if !common.IsHexAddress(body.Address) {
+		return http.StatusBadRequest, &faucetResponse{Status: faucetStatusFailed, Error: fmt.Sprintf("invalid address: %s", body.Address)}
+	}
+	recipient := common.HexToAddress(body.Address)
+
+	amount := big.NewInt(1)
+	if body.Amount = strings.TrimSpace(body.Amount); body.Amount != "" {
+		var ok bool
+		amount, ok = new(big.Int).SetString(body.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return http.StatusBadRequest, &faucetResponse{Status: faucetStatusFailed, Error: fmt.Sprintf("invalid amount: %s", body.Amount)}
+		}
+	}
+	if amount.Cmp(new(big.Int).SetUint64(faucetMaxAmount)) > 0 {
+		return http.StatusBadRequest, &faucetResponse{Status: faucetStatusFailed, Error: fmt.Sprintf("amount exceeds the maximum of %d token(s) per request", faucetMaxAmount)}
+	}
+
+	request, err := faucet.enqueue(recipient, faucetClientIP(r), amount)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var refused *faucetError
+		if errors.As(err, &refused) {
+			status = refused.status
+		}
+		return status, &faucetResponse{Status: faucetStatusFailed, Address: recipient.Hex(), Error: err.Error()}
+	}
+	log.WithFields(map[string]interface{}{
+		"id":      request.record.ID,
+		"address": recipient.Hex(),
+		"amount":  amount.String(),
+		"ip":      request.record.IP,
+	}).Info("Faucet request queued")
+
+	select {
+	case <-request.done:
+	case <-r.Context().Done():
+		// The client is gone, the request is still minted
+		return http.StatusRequestTimeout, &faucetResponse{ID: request.record.ID, Status: faucetStatusPending}
+	case <-ctx.Done():
+		return http.StatusServiceUnavailable, &faucetResponse{ID: request.record.ID, Status: faucetStatusFailed, Error: "faucet is shutting down"}
+	}
+
+	faucet.mu.Lock()
+	defer faucet.mu.Unlock()
+	response := &faucetResponse{
+		ID:      request.record.ID,
+		Status:  request.record.Status,
+		Address: request.record.Address,
+		Amount:  request.record.Amount,
+		TxHash:  request.record.TxHash,
+		Error:   request.record.Error,
+	}
+	switch response.Status {
+	case faucetStatusFailed:
+		return http.StatusUnprocessableEntity, response
+	case faucetStatusUnconfirmed:
+		return http.StatusAccepted, response
+	}
+	return http.StatusOK, response
+}
+
+// faucetClientIP returns the IP of the client, or the first X-Forwarded-For entry with --trust-proxy.
+func faucetClientIP(r *http.Request) string {
+	if faucetTrustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeFaucetJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+var faucetPage = template.Must(template.New("faucet").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Deposit Token Faucet</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; }
+input { font-family: monospace; padding: 0.3em; }
+.ok { color: #080; } .error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Deposit Token Faucet</h1>
+<p>{{.Network}}, gater <code>{{.Gater}}</code></p>
+<p>Each token allows one validator deposit. Up to {{.MaxAmount}} token(s) per request, {{.AddressLimit}} request(s) per address within {{.Window}}.</p>
+<form method="post" action="/api/request">
+<p><input name="address" size="44" placeholder="0x..." required> <input name="amount" type="number" min="1" max="{{.MaxAmount}}" value="1"> <button type="submit">Request tokens</button></p>
+</form>
+{{with .Response}}{{if .TxHash}}<p class="ok">Minted {{.Amount}} token(s) to <code>{{.Address}}</code> in transaction <code>{{.TxHash}}</code></p>{{else if .Error}}<p class="error">{{.Error}}</p>{{end}}{{end}}
+</body>
+</html>
+`))
+
+// renderPage renders the web form with the response of a form post.
+func (faucet *tokenFaucet) renderPage(w http.ResponseWriter, status int, response *faucetResponse) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	faucetPage.Execute(w, map[string]interface{}{
+		"Network":      networkLabel(),
+		"Gater":        gaterAddr.Hex(),
+		"MaxAmount":    faucetMaxAmount,
+		"AddressLimit": faucetAddressLimit,
+		"Window":       faucetWindow,
+		"Response":     response,
+	})
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+// startFaucet serves the faucet in the background and returns its URL.
+func (env *testEnv) startFaucet(args ...string) string {
+	env.t.Helper()
+	return env.startServer(env.chain.AdminKey, "/api/info", append([]string{"faucet", "serve", "--yes"}, args...)...)
+}
+
+// requestTokens posts a JSON faucet request.
+func requestTokens(t *testing.T, baseURL string, address common.Address, amount string, forwardedFor string) (int, *faucetResponse) {
+	t.Helper()
+	body, _ := json.Marshal(faucetRequestBody{Address: address.Hex(), Amount: amount})
+	req, _ := http.NewRequest(http.MethodPost, baseURL+"/api/request", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("faucet request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	response := &faucetResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		t.Fatalf("invalid faucet response: %v", err)
+	}
+	return resp.StatusCode, response
+}
+
+func TestFaucetServe(t *testing.T) {
+	env := newTestEnv(t)
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	baseURL := env.startFaucet("--history", historyPath, "--max-amount", "2", "--max-balance", "3", "--ip-limit", "3", "--trust-proxy")
+
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	status, response := requestTokens(t, baseURL, first, "2", "10.0.0.1")
+	if status != http.StatusOK || response.Status != faucetStatusMinted || response.TxHash == "" {
+		t.Fatalf("unexpected response %d: %+v", status, response)
+	}
+	if balance := env.balanceOf(first); balance.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("balance = %s, want 2", balance)
+	}
+
+	// Per-address limit
+	status, response = requestTokens(t, baseURL, first, "1", "10.0.0.2")
+	if status != http.StatusTooManyRequests {
+		t.Errorf("second request for the same address: status %d, want 429 (%+v)", status, response)
+	}
+
+	// Per-request and balance limits
+	second := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	status, _ = requestTokens(t, baseURL, second, "3", "10.0.0.1")
+	if status != http.StatusBadRequest {
+		t.Errorf("amount above --max-amount: status %d, want 400", status)
+	}
+	if _, err := env.adminGater().Mint(t.Context(), second, big.NewInt(2)); err != nil {
+		t.Fatalf("mint failed: %v", err)
+	}
+	status, response = requestTokens(t, baseURL, second, "2", "10.0.0.1")
+	if status != http.StatusUnprocessableEntity || !strings.Contains(response.Error, "max balance: 3") {
+		t.Errorf("request above --max-balance: status %d, want 422 (%+v)", status, response)
+	}
+
+	// A failed request doesn't count, the next one succeeds
+	status, response = requestTokens(t, baseURL, second, "1", "10.0.0.1")
+	if status != http.StatusOK {
+		t.Errorf("request within --max-balance: status %d, want 200 (%+v)", status, response)
+	}
+
+	// Per-IP limit (two successful requests from 10.0.0.1, one more is allowed)
+	requestTokens(t, baseURL, common.HexToAddress("0x1000000000000000000000000000000000000003"), "1", "10.0.0.1")
+	status, _ = requestTokens(t, baseURL, common.HexToAddress("0x1000000000000000000000000000000000000004"), "1", "10.0.0.1")
+	if status != http.StatusTooManyRequests {
+		t.Errorf("request above --ip-limit: status %d, want 429", status)
+	}
+
+	// The web form
+	resp, err := http.PostForm(baseURL+"/api/request", url.Values{"address": {"0x1234"}})
+	if err != nil {
+		t.Fatalf("form post failed: %v", err)
+	}
+	var page bytes.Buffer
+	page.ReadFrom(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("form post: status %d, content type %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	assertContains(t, page.String(), "<form", "invalid address: 0x1234")
+
+	// Processed requests are persisted
+	file, err := os.Open(historyPath)
+	if err != nil {
+		t.Fatalf("failed to open history: %v", err)
+	}
+	defer file.Close()
+	statuses := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &faucetRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatalf("invalid history line %q: %v", scanner.Text(), err)
+		}
+		statuses = append(statuses, record.Status)
+	}
+	if strings.Join(statuses, ",") != "minted,failed,minted,minted" {
+		t.Errorf("history statuses = %v", statuses)
+	}
+}
+
+func TestFaucetHistoryLimits(t *testing.T) {
+	env := newTestEnv(t)
+	recipient := common.HexToAddress("0x1000000000000000000000000000000000000001")
+
+	// The rate limits survive restarts
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+	record, _ := json.Marshal(faucetRecord{ID: 7, Time: time.Now().UTC(), Address: recipient.Hex(), IP: "10.0.0.1", Amount: "1", Status: faucetStatusMinted})
+	if err := os.WriteFile(historyPath, append(record, '\n'), 0o600); err != nil {
+		t.Fatalf("failed to write history: %v", err)
+	}
+
+	baseURL := env.startFaucet("--history", historyPath)
+	status, response := requestTokens(t, baseURL, recipient, "1", "")
+	if status != http.StatusTooManyRequests {
+		t.Errorf("request after restart: status %d, want 429 (%+v)", status, response)
+	}
+	status, response = requestTokens(t, baseURL, common.HexToAddress("0x1000000000000000000000000000000000000002"), "", "")
+	if status != http.StatusOK || response.ID != 8 || response.Amount != "1" {
+		t.Errorf("unexpected response %d: %+v", status, response)
+	}
+}
+
+func TestFaucetUnconfirmed(t *testing.T) {
+	env := newTestEnv(t)
+	stalled := gatertest.NewChain(t)
+	recipient := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	historyPath := filepath.Join(t.TempDir(), "history.jsonl")
+
+	// Serve on a chain without new blocks until the command times out while waiting for the receipt
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	done := make(chan error, 1)
+	go func() {
+		_, err := env.run(stalled.AdminKey, "faucet", "serve", "--yes", "--listen", address, "--history", historyPath,
+			"--rpc", stalled.IPCPath, "--deposit-contract", stalled.DepositContract.Hex(), "--timeout", "3s")
+		done <- err
+	}()
+	for i := 0; i < 100; i++ {
+		if resp, err := http.Get("http://" + address + "/api/info"); err == nil {
+			resp.Body.Close()
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	status, response := requestTokens(t, "http://"+address, recipient, "1", "")
+	if status != http.StatusAccepted && status != http.StatusServiceUnavailable {
+		t.Errorf("unexpected response %d: %+v", status, response)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("faucet serve failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := &faucetRecord{}
+	if err := json.Unmarshal(raw, record); err != nil {
+		t.Fatalf("invalid history %q: %v", raw, err)
+	}
+	if record.Status != faucetStatusUnconfirmed || record.TxHash == "" {
+		t.Errorf("unexpected history record: %+v", record)
+	}
+
+	// The unconfirmed mint counts towards the limits
+	baseURL := env.startFaucet("--history", historyPath)
+	status, response = requestTokens(t, baseURL, recipient, "1", "")
+	if status != http.StatusTooManyRequests {
+		t.Errorf("request after an unconfirmed mint: status %d, want 429 (%+v)", status, response)
+	}
+}
+
+func TestFaucetErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.UserKey, "faucet", "serve", "--yes")
+	assertError(t, err, "does not have admin role")
+
+	_, err = env.run(env.chain.AdminKey, "faucet", "serve")
+	assertError(t, err, "refusing to send transactions without confirmation")
+}
@@ -0,0 +1,511 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fleetFile      string
+	fleetReference string
+)
+
+var fleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Compare gated deposit contracts across networks",
+	Long: `Queries several gated deposit contract deployments, e.g. on devnets and
+testnets, and compares them in one view.
+
+The deployments are listed in a fleet file instead of --rpc, --deposit-contract
+and --profile. --timeout applies to the whole command, and --expect-chain-id to
+each deployment.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// The root pre-run connects to --rpc, so only the flags that don't need it are applied
+		configureOutput()
+		applyCommandTimeout(cmd)
+		return validateOutputFormat(outputFormat)
+	},
+}
+
+var fleetStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of all deployments of a fleet",
+	Long: `Queries all deployments of a fleet file concurrently and prints one table with
+the gater address, token supply, admin role of the signer and deposit type
+configs of each deployment. Gater, admin role and deposit type configs that
+differ from the reference deployment (--reference, or the first one) are
+highlighted.
+
+Example fleet file (YAML or JSON):
+
+  signer: "0x..."              # account to check for the admin role
+                               # (default: the address of --private-key)
+  reference: devnet-1          # deployment to compare with (default: the first)
+  networks:
+    - name: devnet-1
+      rpc: https://rpc.devnet-1.example
+      depositContract: "0x..." # default: the deposit contract of the network
+    - name: hoodi
+      profile: hoodi           # take rpc, depositContract and gater from a profile
+      rpc: https://rpc.hoodi.example
+
+Deployments that can't be queried are shown with their error; the other
+deployments are still compared. The command fails if the reference deployment
+can't be queried.`,
+	Args: cobra.NoArgs,
+	RunE: runFleetStatus,
+}
+
+func init() {
+	fleetStatusCmd.Flags().StringVarP(&fleetFile, "file", "f", "", "Path to the fleet file (YAML or JSON, required)")
+	fleetStatusCmd.Flags().StringVar(&fleetReference, "reference", "", "Name of the deployment to compare with (default: reference of the fleet file, or the first)")
+
+	fleetCmd.AddCommand(fleetStatusCmd)
+}
+
+// fleetConfig is the content of a fleet file.
+type fleetConfig struct {
+	Signer    string             `yaml:"signer" json:"signer"`
+	Reference string             `yaml:"reference" json:"reference"`
+	Networks  []fleetNetworkSpec `yaml:"networks" json:"networks"`
+}
+
+// fleetNetworkSpec is a deployment, as declared in the fleet file.
+type fleetNetworkSpec struct {
+	Name            string `yaml:"name" json:"name"`
+	Profile         string `yaml:"profile" json:"profile"`
+	RPC             string `yaml:"rpc" json:"rpc"`
+	DepositContract string `yaml:"depositContract" json:"depositContract"`
+	Gater           string `yaml:"gater" json:"gater"`
+
+	// chainID is the chain ID expected by the profile, if any
+	chainID uint64
+}
+
+// fleetStatus is the status of a deployment, as printed with --output json.
+type fleetStatus struct {
+	Name            string                       `json:"name"`
+	ChainID         uint64                       `json:"chainId,omitempty"`
+	Block           uint64                       `json:"block,omitempty"`
+	DepositContract string                       `json:"depositContract,omitempty"`
+	Gater           string                       `json:"gater,omitempty"`
+	TotalSupply     string                       `json:"totalSupply,omitempty"`
+	SignerIsAdmin   *bool                        `json:"signerIsAdmin,omitempty"`
+	DepositTypes    map[string]depositTypePolicy `json:"depositTypes,omitempty"`
+	Differences     []string                     `json:"differences,omitempty"`
+	Error           string                       `json:"error,omitempty"`
+}
+
+// fleetReport is the JSON output of fleet status.
+type fleetReport struct {
+	Signer    string         `json:"signer,omitempty"`
+	Reference string         `json:"reference"`
+	Networks  []*fleetStatus `json:"networks"`
+}
+
+func runFleetStatus(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if fleetFile == "" {
+		return fmt.Errorf("fleet file is required (use --file)")
+	}
+	fleet, err := loadFleetConfig(fleetFile)
+	if err != nil {
+		return err
+	}
+	if err := resolveFleetNetworks(fleet); err != nil {
+		return err
+	}
+	signer, err := resolveFleetSigner(fleet)
+	if err != nil {
+		return err
+	}
+
+	reference := fleetReference
+	if reference == "" {
+		reference = fleet.Reference
+	}
+	if reference == "" {
+		reference = fleet.Networks[0].Name
+	}
+	referenceIndex := -1
+	for i, network := range fleet.Networks {
+		if network.Name == reference {
+			referenceIndex = i
+		}
+	}
+	if referenceIndex < 0 {
+		return fmt.Errorf("reference deployment %q is not in the fleet file", reference)
+	}
+
+	// Query all deployments concurrently
+	statuses := make([]*fleetStatus, len(fleet.Networks))
+	var wg sync.WaitGroup
+	for i, network := range fleet.Networks {
+		wg.Add(1)
+		go func(i int, network fleetNetworkSpec) {
+			defer wg.Done()
+			status, err := fetchFleetStatus(ctx, network, signer)
+			if err != nil {
+				log.WithError(err).WithField("network", network.Name).Warn("Failed to query deployment")
+				status.Error = err.Error()
+			}
+			statuses[i] = status
+		}(i, network)
+	}
+	wg.Wait()
+
+	for _, status := range statuses {
+		status.Differences = compareFleetStatus(statuses[referenceIndex], status)
+	}
+
+	if outputFormat == "json" {
+		report := &fleetReport{Reference: reference, Networks: statuses}
+		if signer != (common.Address{}) {
+			report.Signer = signer.Hex()
+		}
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printFleetTable(statuses, referenceIndex, signer)
+	}
+
+	// Without the reference, nothing was compared
+	if statuses[referenceIndex].Error != "" {
+		return fmt.Errorf("failed to query reference deployment %s: %s", reference, statuses[referenceIndex].Error)
+	}
+	return nil
+}
+
+// loadFleetConfig reads a fleet file.
+func loadFleetConfig(path string) (*fleetConfig, error) {
+	fleet := &fleetConfig{}
+	if err := loadYAMLOrJSON(path, "fleet file", fleet); err != nil {
+		return nil, err
+	}
+	return fleet, nil
+}
+
+// resolveFleetNetworks validates the deployments of a fleet and fills in the values of their profiles.
+func resolveFleetNetworks(fleet *fleetConfig) error {
+	if len(fleet.Networks) == 0 {
+		return fmt.Errorf("fleet file has no networks")
+	}
+
+	var config *cliConfig
+	seen := map[string]bool{}
+	for i := range fleet.Networks {
+		network := &fleet.Networks[i]
+		if network.Name == "" {
+			network.Name = network.Profile
+		}
+		if network.Name == "" {
+			return fmt.Errorf("network %d: name is required", i+1)
+		}
+		if seen[network.Name] {
+			return fmt.Errorf("network %q is listed twice", network.Name)
+		}
+		seen[network.Name] = true
+
+		if network.Profile != "" {
+			if config == nil {
+				path, err := resolveConfigPath()
+				if err != nil {
+					return err
+				}
+				if config, err = loadCLIConfig(path); err != nil {
+					return err
+				}
+			}
+			profile := config.findProfile(network.Profile)
+			if profile == nil {
+				return fmt.Errorf("network %q: unknown profile %q", network.Name, network.Profile)
+			}
+			if network.RPC == "" {
+				network.RPC = profile.RPC
+			}
+			if network.DepositContract == "" {
+				network.DepositContract = profile.DepositContract
+			}
+			if network.Gater == "" {
+				network.Gater = profile.Gater
+			}
+			network.chainID = profile.ChainID
+		}
+
+		if len(splitRPCHosts(network.RPC)) == 0 {
+			return fmt.Errorf("network %q: rpc is required", network.Name)
+		}
+		for _, address := range []string{network.DepositContract, network.Gater} {
+			if address != "" && !common.IsHexAddress(address) {
+				return fmt.Errorf("network %q: invalid address %s", network.Name, address)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveFleetSigner returns the account checked for the admin role: the signer of the fleet
+// file, or the address of --private-key. Without either, the admin role is not checked.
+func resolveFleetSigner(fleet *fleetConfig) (common.Address, error) {
+	if fleet.Signer != "" {
+		if !common.IsHexAddress(fleet.Signer) {
+			return common.Address{}, fmt.Errorf("invalid signer address in fleet file: %s", fleet.Signer)
+		}
+		return common.HexToAddress(fleet.Signer), nil
+	}
+	key := privateKey
+	if key == "" {
+		key = os.Getenv("PRIVATE_KEY")
+	}
+	if key == "" {
+		return common.Address{}, nil
+	}
+	parsed, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	return crypto.PubkeyToAddress(parsed.PublicKey), nil
+}
+
+// fetchFleetStatus connects to a deployment and reads its status at the latest block.
+// The returned status is never nil, and holds what was read before an error.
+func fetchFleetStatus(ctx context.Context, network fleetNetworkSpec, signer common.Address) (*fleetStatus, error) {
+	status := &fleetStatus{Name: network.Name}
+
+	pool, err := dialRPCPool(ctx, splitRPCHosts(network.RPC), rpcTimeout, rpcRetries)
+	if err != nil {
+		return status, fmt.Errorf("failed to connect to RPC: %w", err)
+	}
+	defer pool.Close()
+
+	networkChainID, err := pool.ChainID(ctx)
+	if err != nil {
+		return status, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	status.ChainID = networkChainID.Uint64()
+	if expectChainID != 0 && status.ChainID != expectChainID {
+		return status, fmt.Errorf("chain ID mismatch: RPC returned %d, but --expect-chain-id is %d", status.ChainID, expectChainID)
+	}
+	if expectChainID == 0 && network.chainID != 0 && status.ChainID != network.chainID {
+		return status, fmt.Errorf("chain ID mismatch: RPC returned %d, but profile %q expects %d", status.ChainID, network.Profile, network.chainID)
+	}
+
+	// Deposit contract and gater, as in the root command
+	deposit := common.HexToAddress(mainnetDepositContractAddress)
+	if network.DepositContract != "" {
+		deposit = common.HexToAddress(network.DepositContract)
+	} else if known := findNetworkByChainID(status.ChainID); known != nil {
+		deposit = common.HexToAddress(known.DepositContract)
+	}
+	status.DepositContract = deposit.Hex()
+
+	header, err := pool.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return status, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	status.Block = header.Number.Uint64()
+
+	gaterAddress := common.HexToAddress(network.Gater)
+	if network.Gater == "" {
+		slot, err := pool.StorageAt(ctx, deposit, gaterStorageSlot, header.Number)
+		if err != nil {
+			return status, fmt.Errorf("failed to read gater storage slot: %w", err)
+		}
+		gaterAddress = common.BytesToAddress(slot)
+	}
+	status.Gater = gaterAddress.Hex()
+	if gaterAddress == (common.Address{}) {
+		return status, nil
+	}
+
+	// Read everything in a single batch
+	client := gater.NewClient(pool, gaterAddress).At(header.Number)
+	totalSupply := new(big.Int)
+	configs := make([]gater.DepositGateConfig, len(knownDepositTypes))
+	calls := []*gater.Call{gater.NewCall(&totalSupply, "totalSupply")}
+	for i, dt := range knownDepositTypes {
+		calls = append(calls, gater.NewCall(&configs[i], "getDepositGateConfig", dt.typeID))
+	}
+	var isAdmin bool
+	if signer != (common.Address{}) {
+		calls = append(calls, gater.NewCall(&isAdmin, "hasRole", gater.DefaultAdminRole, signer))
+	}
+	if err := client.Batch(ctx, calls); err != nil {
+		return status, fmt.Errorf("failed to read gater: %w", err)
+	}
+
+	status.TotalSupply = totalSupply.String()
+	status.DepositTypes = map[string]depositTypePolicy{}
+	for i, dt := range knownDepositTypes {
+		status.DepositTypes[formatPolicyDepositType(dt.typeID)] = depositTypePolicy(configs[i])
+	}
+	if signer != (common.Address{}) {
+		status.SignerIsAdmin = &isAdmin
+	}
+	return status, nil
+}
+
+// compareFleetStatus returns the fields of a deployment that differ from the reference:
+// "gater", "signerIsAdmin" and the differing deposit types. Failed deployments are not compared.
+func compareFleetStatus(reference, status *fleetStatus) []string {
+	if reference == status || reference.Error != "" || status.Error != "" {
+		return nil
+	}
+	differences := []string{}
+	if status.Gater != reference.Gater {
+		differences = append(differences, "gater")
+	}
+	if (status.SignerIsAdmin == nil) != (reference.SignerIsAdmin == nil) ||
+		(status.SignerIsAdmin != nil && *status.SignerIsAdmin != *reference.SignerIsAdmin) {
+		differences = append(differences, "signerIsAdmin")
+	}
+	for _, dt := range knownDepositTypes {
+		key := formatPolicyDepositType(dt.typeID)
+		policy, ok := status.DepositTypes[key]
+		referencePolicy, referenceOk := reference.DepositTypes[key]
+		if ok != referenceOk || policy != referencePolicy {
+			differences = append(differences, key)
+		}
+	}
+	if len(differences) == 0 {
+		return nil
+	}
+	return differences
+}
+
+// formatFleetPolicy returns the short label of a deposit type config in the fleet table.
+func formatFleetPolicy(policy depositTypePolicy) string {
+	switch {
+	case policy.Blocked && policy.NoToken:
+		return "blocked,no token"
+	case policy.Blocked:
+		return "blocked"
+	case policy.NoToken:
+		return "no token"
+	default:
+		return "token"
+	}
+}
+
+// printFleetTable prints the statuses as a table, highlighting differences from the reference.
+func printFleetTable(statuses []*fleetStatus, referenceIndex int, signer common.Address) {
+	header := []string{"Network", "Chain", "Gater", "Supply", "Admin"}
+	for _, dt := range knownDepositTypes {
+		header = append(header, depositTypeLabel(dt.typeID))
+	}
+
+	rows := make([][]string, len(statuses))
+	highlighted := make([][]bool, len(statuses))
+	for i, status := range statuses {
+		differs := map[string]bool{}
+		for _, field := range status.Differences {
+			differs[field] = true
+		}
+		name := status.Name
+		if i == referenceIndex {
+			name += " (ref)"
+		}
+		row := []string{name, fmt.Sprintf("%d", status.ChainID), "-", "-", "-"}
+		marks := make([]bool, len(header))
+		if status.Gater != "" {
+			row[2] = "None"
+			if gaterAddress := common.HexToAddress(status.Gater); gaterAddress != (common.Address{}) {
+				row[2] = shortHex(gaterAddress.Bytes())
+			}
+			marks[2] = differs["gater"]
+		}
+		if status.TotalSupply != "" {
+			row[3] = status.TotalSupply
+		}
+		if status.SignerIsAdmin != nil {
+			row[4] = "no"
+			if *status.SignerIsAdmin {
+				row[4] = "yes"
+			}
+			marks[4] = differs["signerIsAdmin"]
+		}
+		for _, dt := range knownDepositTypes {
+			key := formatPolicyDepositType(dt.typeID)
+			cell := "-"
+			if policy, ok := status.DepositTypes[key]; ok {
+				cell = formatFleetPolicy(policy)
+			}
+			marks[len(row)] = differs[key]
+			row = append(row, cell)
+		}
+		rows[i] = row
+		highlighted[i] = marks
+	}
+
+	// Column widths (the cells contain multi-byte characters, e.g. in shortened addresses)
+	widths := make([]int, len(header))
+	for column, title := range header {
+		widths[column] = utf8.RuneCountInString(title)
+		for _, row := range rows {
+			if width := utf8.RuneCountInString(row[column]); width > widths[column] {
+				widths[column] = width
+			}
+		}
+	}
+	pad := func(value string, width int) string {
+		return value + strings.Repeat(" ", width-utf8.RuneCountInString(value))
+	}
+
+	line := ""
+	for column, title := range header {
+		line += pad(title, widths[column]) + "  "
+	}
+	fmt.Printf("%s%s%s\n", colorBold, strings.TrimRight(line, " "), colorReset)
+	for i, row := range rows {
+		line := ""
+		for column, cell := range row {
+			cell = pad(cell, widths[column])
+			if highlighted[i][column] {
+				cell = colorYellow + cell + colorReset
+			}
+			line += cell + "  "
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+		if statuses[i].Error != "" {
+			fmt.Printf("  %serror: %s%s\n", colorRed, statuses[i].Error, colorReset)
+		}
+	}
+
+	fmt.Println()
+	if signer != (common.Address{}) {
+		fmt.Printf("%sAdmin:%s admin role of %s\n", colorCyan, colorReset, signer.Hex())
+	}
+	differing, failed := 0, 0
+	for _, status := range statuses {
+		if status.Error != "" {
+			failed++
+		} else if len(status.Differences) > 0 {
+			differing++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("%s%d deployment(s) failed to be queried.%s\n", colorRed, failed, colorReset)
+	}
+	switch reference := statuses[referenceIndex]; {
+	case reference.Error != "":
+		// The command fails, nothing was compared
+	case differing > 0:
+		printInfo("%d deployment(s) differ from %s (highlighted).", differing, reference.Name)
+	case failed > 0:
+		printInfo("The other deployments match %s.", reference.Name)
+	default:
+		printSuccess("All deployments match %s.", reference.Name)
+	}
+}
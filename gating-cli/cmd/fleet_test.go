@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestFleetStatus(t *testing.T) {
+	env := newTestEnv(t)
+	other := gatertest.NewChain(t)
+	if _, err := other.GaterClient(other.AdminKey).SetDepositGateConfig(t.Context(), 0x02, gater.DepositGateConfig{Blocked: true}); err != nil {
+		t.Fatalf("setConfig failed: %v", err)
+	}
+	other.AutoCommit(t, 50*time.Millisecond)
+
+	fleetPath := filepath.Join(t.TempDir(), "fleet.yaml")
+	writeFleetFile(t, fleetPath, fmt.Sprintf(`networks:
+  - name: devnet-1
+    rpc: %s
+    depositContract: "%s"
+  - name: devnet-2
+    rpc: %s
+    depositContract: "%s"
+  - name: offline
+    rpc: %s
+`, env.chain.IPCPath, env.chain.DepositContract.Hex(), other.IPCPath, other.DepositContract.Hex(), filepath.Join(t.TempDir(), "missing.ipc")))
+
+	out := env.mustRun(env.chain.AdminKey, "fleet", "status", "-f", fleetPath, "--output", "json")
+	report := &fleetReport{}
+	if err := json.Unmarshal([]byte(out), report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if report.Reference != "devnet-1" || report.Signer != env.chain.Admin.Hex() || len(report.Networks) != 3 {
+		t.Fatalf("unexpected report: %s", out)
+	}
+	reference, changed, offline := report.Networks[0], report.Networks[1], report.Networks[2]
+	if reference.Gater != env.chain.Gater.Hex() || reference.SignerIsAdmin == nil || !*reference.SignerIsAdmin || reference.TotalSupply != "0" {
+		t.Errorf("unexpected reference status: %+v", reference)
+	}
+	// The other chain has its own gater and admin
+	if !slices.Equal(changed.Differences, []string{"gater", "signerIsAdmin", "0x02"}) || !changed.DepositTypes["0x02"].Blocked {
+		t.Errorf("unexpected differences of devnet-2: %+v", changed)
+	}
+	if offline.Error == "" || offline.Differences != nil {
+		t.Errorf("unexpected status of unreachable deployment: %+v", offline)
+	}
+
+	out = env.mustRun(nil, "fleet", "status", "-f", fleetPath, "--reference", "devnet-2")
+	assertContains(t, out, "devnet-2 (ref)", "blocked", "error: failed to connect to RPC", "1 deployment(s) failed to be queried", "1 deployment(s) differ from devnet-2")
+
+	// Deployments matching the reference don't hide the failed one
+	writeFleetFile(t, fleetPath, fmt.Sprintf(`networks:
+  - name: devnet-1
+    rpc: %s
+    depositContract: "%s"
+  - name: offline
+    rpc: %s
+`, env.chain.IPCPath, env.chain.DepositContract.Hex(), filepath.Join(t.TempDir(), "missing.ipc")))
+	out = env.mustRun(nil, "fleet", "status", "-f", fleetPath)
+	assertContains(t, out, "1 deployment(s) failed to be queried", "The other deployments match devnet-1")
+	if strings.Contains(out, "All deployments match") {
+		t.Errorf("failed deployment reported as matching:\n%s", out)
+	}
+
+	// Nothing is compared without the reference
+	out, err := env.run(nil, "fleet", "status", "-f", fleetPath, "--reference", "offline", "--output", "json")
+	assertError(t, err, "failed to query reference deployment offline")
+	if err := json.Unmarshal([]byte(out), &fleetReport{}); err != nil {
+		t.Errorf("invalid JSON output: %v\n%s", err, out)
+	}
+}
+
+func TestFleetStatusRootFlags(t *testing.T) {
+	env := newTestEnv(t)
+	fleetPath := filepath.Join(t.TempDir(), "fleet.yaml")
+	writeFleetFile(t, fleetPath, fmt.Sprintf("networks:\n  - name: devnet\n    rpc: %s\n", env.chain.IPCPath))
+
+	env.mustRun(nil, "fleet", "status", "-f", fleetPath, "--expect-chain-id", "1337")
+	_, err := env.run(nil, "fleet", "status", "-f", fleetPath, "--expect-chain-id", "1")
+	assertError(t, err, "chain ID mismatch: RPC returned 1337, but --expect-chain-id is 1")
+
+	// The chain ID of a profile is checked as well
+	env.mustRun(nil, "profile", "add", "wrong", "--chain-id", "1")
+	writeFleetFile(t, fleetPath, fmt.Sprintf("networks:\n  - profile: wrong\n    rpc: %s\n", env.chain.IPCPath))
+	_, err = env.run(nil, "fleet", "status", "-f", fleetPath)
+	assertError(t, err, `profile "wrong" expects 1`)
+	env.mustRun(nil, "fleet", "status", "-f", fleetPath, "--expect-chain-id", "1337")
+
+	_, err = env.run(nil, "fleet", "status", "-f", fleetPath, "--timeout", "1ns")
+	assertError(t, err, "failed to connect to RPC")
+}
+
+// writeFleetFile writes a fleet file.
+func writeFleetFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write fleet file: %v", err)
+	}
+}
+
+func TestFleetStatusErrors(t *testing.T) {
+	env := newTestEnv(t)
+	fleetPath := filepath.Join(t.TempDir(), "fleet.yaml")
+
+	_, err := env.run(nil, "fleet", "status")
+	assertError(t, err, "fleet file is required")
+
+	writeFleetFile(t, fleetPath, "networks:\n  - name: a\n    rpc: x\n  - name: a\n    rpc: y\n")
+	_, err = env.run(nil, "fleet", "status", "-f", fleetPath)
+	assertError(t, err, `network "a" is listed twice`)
+
+	writeFleetFile(t, fleetPath, "networks:\n  - name: a\n")
+	_, err = env.run(nil, "fleet", "status", "-f", fleetPath)
+	assertError(t, err, `network "a": rpc is required`)
+
+	writeFleetFile(t, fleetPath, "networks:\n  - name: a\n    rpc: x\n")
+	_, err = env.run(nil, "fleet", "status", "-f", fleetPath, "--reference", "b")
+	assertError(t, err, `reference deployment "b" is not in the fleet file`)
+}
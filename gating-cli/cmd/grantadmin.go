@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +32,7 @@ func init() {
 }
 
 func runGrantAdmin(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Check admin role
 	if err := checkAdminRole(ctx); err != nil {
@@ -61,7 +63,7 @@ func runGrantAdmin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if already admin
-	isAdmin, err := hasRole(ctx, DefaultAdminRole, target)
+	isAdmin, err := gaterClient.HasRole(ctx, gater.DefaultAdminRole, target)
 	if err != nil {
 		return fmt.Errorf("failed to check existing role: %w", err)
 	}
@@ -70,18 +72,10 @@ func runGrantAdmin(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	log.WithField("target", target.Hex()).Info("Granting admin role")
-
-	// Pack transaction data
-	data, err := parsedABI.Pack("grantRole", DefaultAdminRole, target)
-	if err != nil {
-		return fmt.Errorf("failed to pack grantRole call: %w", err)
-	}
-
 	// Send transaction
-	receipt, err := sendTransaction(ctx, gaterAddr, data)
+	receipt, err := grantAdminRole(ctx, target)
 	if err != nil {
-		return fmt.Errorf("grantAdmin failed: %w", err)
+		return err
 	}
 
 	printSuccess("Successfully granted admin role to %s", target.Hex())
@@ -90,3 +84,14 @@ func runGrantAdmin(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// grantAdminRole grants the admin role to an account with the signer key and waits for the receipt.
+func grantAdminRole(ctx context.Context, target common.Address) (*types.Receipt, error) {
+	log.WithField("target", target.Hex()).Info("Granting admin role")
+
+	receipt, err := gaterClient.GrantRole(ctx, gater.DefaultAdminRole, target)
+	if err != nil {
+		return nil, fmt.Errorf("grantAdmin failed: %w", err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import "testing"
+
+func TestGrantAdmin(t *testing.T) {
+	env := newTestEnv(t)
+
+	out := env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+	assertContains(t, out, "Successfully granted admin role to "+env.chain.User.Hex())
+	if !env.hasAdminRole(env.chain.User) {
+		t.Fatal("user is not admin after grantAdmin")
+	}
+
+	// The new admin can use admin commands
+	env.mustRun(env.chain.UserKey, "mint", "1", "--yes")
+
+	// Granting again is a no-op
+	out = env.mustRun(env.chain.AdminKey, "grantAdmin", "--address", env.chain.User.Hex(), "--yes")
+	assertContains(t, out, "already has admin role")
+}
+
+func TestGrantAdminErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.AdminKey, "grantAdmin", "not-an-address", "--yes")
+	assertError(t, err, "invalid address")
+
+	_, err = env.run(env.chain.AdminKey, "grantAdmin", "--yes")
+	assertError(t, err, "address is required")
+
+	_, err = env.run(env.chain.UserKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+	assertError(t, err, "does not have admin role")
+	if env.hasAdminRole(env.chain.User) {
+		t.Error("non-admin granted itself the admin role")
+	}
+}
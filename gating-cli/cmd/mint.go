@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -32,7 +33,7 @@ func init() {
 }
 
 func runMint(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Check admin role
 	if err := checkAdminRole(ctx); err != nil {
@@ -88,21 +89,10 @@ func runMint(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("amount is required (use --amount or provide as argument)")
 	}
 
-	log.WithFields(map[string]interface{}{
-		"recipient": recipient.Hex(),
-		"amount":    amount.String(),
-	}).Info("Minting tokens")
-
-	// Pack transaction data
-	data, err := parsedABI.Pack("mint", recipient, amount)
-	if err != nil {
-		return fmt.Errorf("failed to pack mint call: %w", err)
-	}
-
 	// Send transaction
-	receipt, err := sendTransaction(ctx, gaterAddr, data)
+	receipt, err := mintTokens(ctx, recipient, amount)
 	if err != nil {
-		return fmt.Errorf("mint failed: %w", err)
+		return err
 	}
 
 	printSuccess("Successfully minted %s tokens to %s", amount.String(), recipient.Hex())
@@ -110,10 +100,24 @@ func runMint(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sGas used:%s    %d\n", colorCyan, colorReset, receipt.GasUsed)
 
 	// Show new balance
-	newBalance, err := getBalanceOf(ctx, recipient)
+	newBalance, err := gaterClient.At(receipt.BlockNumber).BalanceOf(ctx, recipient)
 	if err == nil {
 		fmt.Printf("%sNew balance:%s %s tokens\n", colorCyan, colorReset, newBalance.String())
 	}
 
 	return nil
 }
+
+// mintTokens mints tokens to a recipient with the signer key and waits for the receipt.
+func mintTokens(ctx context.Context, recipient common.Address, amount *big.Int) (*types.Receipt, error) {
+	log.WithFields(map[string]interface{}{
+		"recipient": recipient.Hex(),
+		"amount":    amount.String(),
+	}).Info("Minting tokens")
+
+	receipt, err := gaterClient.Mint(ctx, recipient, amount)
+	if err != nil {
+		return nil, fmt.Errorf("mint failed: %w", err)
+	}
+	return receipt, nil
+}
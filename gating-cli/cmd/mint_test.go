@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMint(t *testing.T) {
+	env := newTestEnv(t)
+
+	out := env.mustRun(env.chain.AdminKey, "mint", "3", "--to", env.chain.User.Hex(), "--yes")
+	assertContains(t, out, "Successfully minted 3 tokens to "+env.chain.User.Hex(), "New balance: 3 tokens")
+	if balance := env.balanceOf(env.chain.User); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("balance = %s, want 3", balance)
+	}
+
+	// Mints to the signer by default
+	env.mustRun(env.chain.AdminKey, "mint", "--amount", "2", "--yes")
+	if balance := env.balanceOf(env.chain.Admin); balance.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("signer balance = %s, want 2", balance)
+	}
+}
+
+func TestMintErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.UserKey, "mint", "1", "--yes")
+	assertError(t, err, "does not have admin role")
+
+	_, err = env.run(env.chain.AdminKey, "mint", "abc", "--yes")
+	assertError(t, err, "invalid amount: abc")
+
+	_, err = env.run(env.chain.AdminKey, "mint", "1", "--to", "0x1234", "--yes")
+	assertError(t, err, "invalid recipient address")
+
+	// Transactions are not sent without confirmation if stdin is not a terminal
+	_, err = env.run(env.chain.AdminKey, "mint", "1")
+	assertError(t, err, "refusing to send transactions without confirmation")
+
+	if supply, _ := env.gater().TotalSupply(t.Context()); supply.Sign() != 0 {
+		t.Errorf("total supply = %s after failed mints, want 0", supply)
+	}
+}
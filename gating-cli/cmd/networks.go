@@ -0,0 +1,32 @@
+package cmd
+
+// networkInfo describes a well-known Ethereum network.
+type networkInfo struct {
+	Name               string
+	ChainID            uint64
+	GenesisForkVersion [4]byte
+	GenesisTime        uint64 // beacon chain genesis time
+	DepositContract    string
+}
+
+// mainnetDepositContractAddress is the deposit contract address used when the chain isn't a known network.
+const mainnetDepositContractAddress = "0x00000000219ab540356cBB839Cbe05303d7705Fa"
+
+// knownNetworks lists the public networks the CLI knows about.
+// They are also available as built-in profiles (--profile hoodi).
+var knownNetworks = []networkInfo{
+	{Name: "mainnet", ChainID: 1, GenesisForkVersion: [4]byte{0x00, 0x00, 0x00, 0x00}, GenesisTime: 1606824023, DepositContract: mainnetDepositContractAddress},
+	{Name: "sepolia", ChainID: 11155111, GenesisForkVersion: [4]byte{0x90, 0x00, 0x00, 0x69}, GenesisTime: 1655733600, DepositContract: "0x7f02C3E3c98b133055B8B348B2Ac625669Ed295D"},
+	{Name: "holesky", ChainID: 17000, GenesisForkVersion: [4]byte{0x01, 0x01, 0x70, 0x00}, GenesisTime: 1695902400, DepositContract: "0x4242424242424242424242424242424242424242"},
+	{Name: "hoodi", ChainID: 560048, GenesisForkVersion: [4]byte{0x10, 0x00, 0x09, 0x10}, GenesisTime: 1742213400, DepositContract: mainnetDepositContractAddress},
+}
+
+// findNetworkByChainID returns the well-known network with the given chain ID, if any.
+func findNetworkByChainID(chainID uint64) *networkInfo {
+	for i := range knownNetworks {
+		if knownNetworks[i].ChainID == chainID {
+			return &knownNetworks[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyFile      string
+	policyFromBlock uint64
+	policyChunkSize uint64
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to apply a gate policy file",
+	Long: `Compares a declarative gate policy file (YAML or JSON) with the chain state
+and shows the transactions needed to apply it.
+
+Example policy:
+  version: 1
+  depositTypes:
+    0x00: {blocked: true, noToken: false}
+    0x02: {blocked: false, noToken: false}
+    0xffff: {blocked: false, noToken: true}
+  admins:                 # exact admin set (omit to leave admins untouched)
+    - 0x...
+  depositContracts:       # exact set of deposit contract role holders (optional)
+    - 0x...
+  customGater: 0x0000000000000000000000000000000000000000
+  balances:               # target token balances (only minted up)
+    0x...: 10
+
+Current admins are found by scanning RoleGranted logs of the gater, so
+--from-block should be at or before the gater deployment.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a gate policy file",
+	Long: `Applies a declarative gate policy file by sending only the transactions needed.
+
+Changes are applied in a safe order: admins are granted first, then deposit type
+configs, the custom gater and token mints are applied, and admins are revoked last
+(the signer itself at the very end). The plan is shown and must be confirmed
+before anything is sent (use --yes to skip the confirmation).
+
+See 'gating-cli plan --help' for the policy file format.`,
+	RunE: runApply,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{planCmd, applyCmd} {
+		cmd.Flags().StringVarP(&policyFile, "file", "f", "", "Path to the policy file (YAML or JSON)")
+		cmd.Flags().Uint64Var(&policyFromBlock, "from-block", 0, "First block to scan for RoleGranted logs")
+		cmd.Flags().Uint64Var(&policyChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+	}
+}
+
+// loadPolicyAndPlan loads the policy file given by --file and plans it against the chain state.
+func loadPolicyAndPlan(ctx context.Context) (*parsedPolicy, *policyPlan, error) {
+	if gaterAddr == (common.Address{}) {
+		return nil, nil, fmt.Errorf("no gating contract configured on deposit contract")
+	}
+	if policyFile == "" {
+		return nil, nil, fmt.Errorf("policy file is required (use --file)")
+	}
+
+	policy, err := loadPolicyFile(policyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := planPolicy(ctx, policy, roleScanRange{fromBlock: policyFromBlock, chunkSize: policyChunkSize})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to plan policy: %w", err)
+	}
+	return policy, plan, nil
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	_, plan, err := loadPolicyAndPlan(ctx)
+	if err != nil {
+		return err
+	}
+
+	printHeader("═══ Policy Plan ═══")
+	fmt.Println()
+	fmt.Printf("%sPolicy File:%s       %s\n", colorCyan, colorReset, policyFile)
+	fmt.Printf("%sGating Contract:%s   %s\n", colorCyan, colorReset, gaterAddr.Hex())
+	fmt.Println()
+	printPolicyPlan(plan)
+
+	return nil
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// Check admin role
+	if err := checkAdminRole(ctx); err != nil {
+		return err
+	}
+
+	policy, plan, err := loadPolicyAndPlan(ctx)
+	if err != nil {
+		return err
+	}
+
+	printHeader("═══ Policy Apply ═══")
+	fmt.Println()
+	fmt.Printf("%sPolicy File:%s       %s\n", colorCyan, colorReset, policyFile)
+	fmt.Printf("%sGating Contract:%s   %s\n", colorCyan, colorReset, gaterAddr.Hex())
+	fmt.Println()
+	printPolicyPlan(plan)
+
+	if len(plan.Actions) == 0 {
+		return nil
+	}
+
+	confirmed, err := confirmTransactionBatch(len(plan.Actions), fmt.Sprintf("apply %s", policyFile))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		printInfo("Aborted.")
+		return nil
+	}
+	defer endTransactionBatch()
+
+	for i, action := range plan.Actions {
+		log.WithFields(map[string]interface{}{
+			"step":    fmt.Sprintf("%d/%d", i+1, len(plan.Actions)),
+			"kind":    action.Kind,
+			"subject": action.Subject,
+		}).Info("Applying change")
+
+		// Send transaction
+		receipt, err := action.send(ctx)
+		if err != nil {
+			return fmt.Errorf("apply failed at step %d/%d (%s): %w", i+1, len(plan.Actions), action.Describe(), err)
+		}
+
+		printSuccess("%s", action.Describe())
+		fmt.Printf("%sTransaction:%s %s\n", colorCyan, colorReset, receipt.TxHash.Hex())
+	}
+
+	// Verify the result by planning again
+	fmt.Println()
+	verifyPlan, err := planPolicy(ctx, policy, roleScanRange{fromBlock: policyFromBlock, chunkSize: policyChunkSize})
+	if err != nil {
+		log.WithError(err).Warn("Failed to verify applied policy")
+		return nil
+	}
+	if len(verifyPlan.Actions) > 0 {
+		printPolicyPlan(verifyPlan)
+		return fmt.Errorf("chain state does not match the policy after applying")
+	}
+	printSuccess("Policy applied successfully.")
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePolicy writes a policy file to a temporary directory and returns its path.
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPlanApply(t *testing.T) {
+	env := newTestEnv(t)
+	path := writePolicy(t, `version: 1
+depositTypes:
+  "0x00": {blocked: true, noToken: false}
+  "0x01": {blocked: false, noToken: true}
+admins:
+  - `+env.chain.Admin.Hex()+`
+  - `+env.chain.User.Hex()+`
+depositContracts:
+  - `+env.chain.DepositContract.Hex()+`
+balances:
+  `+env.chain.User.Hex()+`: 2
+`)
+
+	// Plan only lists the changes
+	out := env.mustRun(nil, "plan", "--file", path)
+	assertContains(t, out,
+		"+ grant admin role to "+env.chain.User.Hex(),
+		"+ mint to "+env.chain.User.Hex()+": balance 0 → 2",
+		"~ set config for ",
+	)
+	if env.hasAdminRole(env.chain.User) {
+		t.Fatal("plan must not change the chain state")
+	}
+
+	out = env.mustRun(env.chain.AdminKey, "apply", "--file", path, "--yes")
+	assertContains(t, out, "Policy applied successfully.")
+	if !env.hasAdminRole(env.chain.User) {
+		t.Error("expected the user to be granted the admin role")
+	}
+	if balance := env.balanceOf(env.chain.User); balance.Int64() != 2 {
+		t.Errorf("expected a balance of 2, got %s", balance)
+	}
+	for depositType, expected := range map[uint16][2]bool{0x00: {true, false}, 0x01: {false, true}} {
+		config, err := env.gater().DepositGateConfig(context.Background(), depositType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Blocked != expected[0] || config.NoToken != expected[1] {
+			t.Errorf("config of 0x%02x = %+v, want blocked %v, noToken %v", depositType, config, expected[0], expected[1])
+		}
+	}
+
+	// Applying the policy again doesn't change anything
+	out = env.mustRun(env.chain.AdminKey, "apply", "--file", path, "--yes")
+	assertContains(t, out, "No changes. The chain state matches the policy.")
+
+	// Removing an admin from the policy revokes its role
+	path = writePolicy(t, "version: 1\nadmins:\n  - "+env.chain.Admin.Hex()+"\n")
+	out = env.mustRun(env.chain.AdminKey, "apply", "--file", path, "--yes")
+	assertContains(t, out, "- revoke admin role from "+env.chain.User.Hex())
+	if env.hasAdminRole(env.chain.User) {
+		t.Error("expected the admin role of the user to be revoked")
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(nil, "plan")
+	assertError(t, err, "policy file is required")
+
+	_, err = env.run(nil, "plan", "--file", writePolicy(t, "version: 2\n"))
+	assertError(t, err, "unsupported policy version 2")
+
+	_, err = env.run(nil, "plan", "--file", writePolicy(t, "version: 1\nadmins: [0x1234]\n"))
+	assertError(t, err, "invalid admin address")
+
+	_, err = env.run(env.chain.UserKey, "apply", "--file", writePolicy(t, "version: 1\n"), "--yes")
+	assertError(t, err, "does not have admin role")
+}
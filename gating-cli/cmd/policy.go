@@ -0,0 +1,472 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"gopkg.in/yaml.v3"
+)
+
+// policyVersion is the current version of the gate policy file format.
+const policyVersion = 1
+
+// gatePolicy is the declarative gater configuration stored in a policy file (YAML or JSON).
+//
+// Only the sections present in the file are managed: a missing admins list leaves the
+// admin set untouched, while an admins list declares the exact set of admins. The lists
+// are always written, as an empty list manages the section while a missing one doesn't,
+// so an exported policy must use empty (non-nil) slices.
+type gatePolicy struct {
+	Version          int                          `yaml:"version" json:"version"`
+	Source           *policySource                `yaml:"source,omitempty" json:"source,omitempty"`
+	DepositTypes     map[string]depositTypePolicy `yaml:"depositTypes,omitempty" json:"depositTypes,omitempty"`
+	Admins           []string                     `yaml:"admins" json:"admins"`
+	StickyAdmins     []string                     `yaml:"stickyAdmins" json:"stickyAdmins"`
+	DepositContracts []string                     `yaml:"depositContracts" json:"depositContracts"`
+	CustomGater      *string                      `yaml:"customGater,omitempty" json:"customGater,omitempty"`
+	Balances         map[string]uint64            `yaml:"balances,omitempty" json:"balances,omitempty"`
+}
+
+// policySource describes where an exported policy was read from. It is informational only.
+type policySource struct {
+	ChainID         uint64 `yaml:"chainId" json:"chainId"`
+	Gater           string `yaml:"gater" json:"gater"`
+	DepositContract string `yaml:"depositContract" json:"depositContract"`
+	Block           uint64 `yaml:"block" json:"block"`
+	BlockHash       string `yaml:"blockHash" json:"blockHash"`
+	ExportedAt      string `yaml:"exportedAt" json:"exportedAt"`
+}
+
+// depositTypePolicy is the declared gate configuration of a deposit type.
+type depositTypePolicy struct {
+	Blocked bool `yaml:"blocked" json:"blocked"`
+	NoToken bool `yaml:"noToken" json:"noToken"`
+}
+
+// parsedPolicy is a validated gate policy.
+type parsedPolicy struct {
+	depositTypes           map[uint16]gater.DepositGateConfig
+	manageAdmins           bool
+	admins                 []common.Address
+	stickyAdmins           map[common.Address]bool
+	manageDepositContracts bool
+	depositContracts       []common.Address
+	customGater            *common.Address
+	balances               map[common.Address]*big.Int
+}
+
+// loadPolicyFile reads and validates a policy file.
+func loadPolicyFile(path string) (*parsedPolicy, error) {
+	var policy gatePolicy
+	if err := loadYAMLOrJSON(path, "policy file", &policy); err != nil {
+		return nil, err
+	}
+	if policy.Version != policyVersion {
+		return nil, fmt.Errorf("unsupported policy version %d (expected %d)", policy.Version, policyVersion)
+	}
+
+	return parsePolicy(&policy)
+}
+
+// loadYAMLOrJSON decodes a YAML or JSON file (named by kind in errors) into out. Unknown
+// fields are rejected, so typos don't go unnoticed.
+func loadYAMLOrJSON(path, kind string, out interface{}) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", kind, err)
+	}
+
+	// YAML is a superset of JSON, so this handles both formats
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", kind, err)
+	}
+	return nil
+}
+
+// parsePolicy validates a gate policy.
+func parsePolicy(policy *gatePolicy) (*parsedPolicy, error) {
+	parsed := &parsedPolicy{
+		depositTypes:           map[uint16]gater.DepositGateConfig{},
+		manageAdmins:           policy.Admins != nil || policy.StickyAdmins != nil,
+		stickyAdmins:           map[common.Address]bool{},
+		manageDepositContracts: policy.DepositContracts != nil,
+		balances:               map[common.Address]*big.Int{},
+	}
+
+	for key, config := range policy.DepositTypes {
+		depositType, err := parseDepositType(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deposit type in policy: %w", err)
+		}
+		if _, exists := parsed.depositTypes[depositType]; exists {
+			return nil, fmt.Errorf("duplicate deposit type in policy: %s", key)
+		}
+		parsed.depositTypes[depositType] = gater.DepositGateConfig{Blocked: config.Blocked, NoToken: config.NoToken}
+	}
+
+	// Sticky admins are admins as well, the flag can't be restored by a transaction
+	stickyAdmins, err := parseAddressList("sticky admin", policy.StickyAdmins)
+	if err != nil {
+		return nil, err
+	}
+	for _, admin := range stickyAdmins {
+		parsed.stickyAdmins[admin] = true
+	}
+	if parsed.admins, err = parseAddressList("admin", append(append([]string{}, policy.Admins...), policy.StickyAdmins...)); err != nil {
+		return nil, err
+	}
+
+	if parsed.depositContracts, err = parseAddressList("deposit contract", policy.DepositContracts); err != nil {
+		return nil, err
+	}
+
+	if policy.CustomGater != nil {
+		if !common.IsHexAddress(*policy.CustomGater) {
+			return nil, fmt.Errorf("invalid custom gater address in policy: %s", *policy.CustomGater)
+		}
+		addr := common.HexToAddress(*policy.CustomGater)
+		parsed.customGater = &addr
+	}
+
+	for holder, balance := range policy.Balances {
+		if !common.IsHexAddress(holder) {
+			return nil, fmt.Errorf("invalid balance holder address in policy: %s", holder)
+		}
+		parsed.balances[common.HexToAddress(holder)] = new(big.Int).SetUint64(balance)
+	}
+
+	return parsed, nil
+}
+
+// parseAddressList parses a list of addresses from a policy, dropping duplicates.
+func parseAddressList(name string, list []string) ([]common.Address, error) {
+	seen := map[common.Address]bool{}
+	addresses := []common.Address{}
+	for _, entry := range list {
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("invalid %s address in policy: %s", name, entry)
+		}
+		addr := common.HexToAddress(entry)
+		if !seen[addr] {
+			seen[addr] = true
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses, nil
+}
+
+// Policy action kinds in the order they are applied.
+const (
+	actionGrantAdmin            = "grantAdmin"
+	actionGrantDepositContract  = "grantDepositContract"
+	actionSetConfig             = "setConfig"
+	actionSetCustomGater        = "setCustomGater"
+	actionMint                  = "mint"
+	actionRevokeDepositContract = "revokeDepositContract"
+	actionRevokeAdmin           = "revokeAdmin"
+)
+
+// policyAction is a single change needed to bring the chain state in line with a policy.
+type policyAction struct {
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+
+	// send sends the transaction of the action and waits for its receipt
+	send func(ctx context.Context) (*types.Receipt, error)
+}
+
+// Describe returns a human readable description of the action.
+func (action *policyAction) Describe() string {
+	switch action.Kind {
+	case actionGrantAdmin:
+		return fmt.Sprintf("+ grant admin role to %s", action.Subject)
+	case actionRevokeAdmin:
+		return fmt.Sprintf("- revoke admin role from %s", action.Subject)
+	case actionGrantDepositContract:
+		return fmt.Sprintf("+ grant deposit contract role to %s", action.Subject)
+	case actionRevokeDepositContract:
+		return fmt.Sprintf("- revoke deposit contract role from %s", action.Subject)
+	case actionSetConfig:
+		return fmt.Sprintf("~ set config for %s: %s → %s", action.Subject, action.Current, action.Desired)
+	case actionSetCustomGater:
+		return fmt.Sprintf("~ set custom gater: %s → %s", action.Current, action.Desired)
+	case actionMint:
+		return fmt.Sprintf("+ mint to %s: balance %s → %s", action.Subject, action.Current, action.Desired)
+	default:
+		return fmt.Sprintf("? %s %s", action.Kind, action.Subject)
+	}
+}
+
+// policyPlan is the ordered list of actions needed to apply a policy.
+type policyPlan struct {
+	Actions  []*policyAction `json:"actions"`
+	Warnings []string        `json:"warnings,omitempty"`
+}
+
+// roleScanRange is the block range scanned for RoleGranted logs to find role holders.
+type roleScanRange struct {
+	fromBlock uint64
+	chunkSize uint64
+}
+
+// formatGateConfig formats a gate config for plan output.
+func formatGateConfig(config gater.DepositGateConfig) string {
+	return fmt.Sprintf("blocked=%v noToken=%v", config.Blocked, config.NoToken)
+}
+
+// planRoleMembers compares the holders of a role with the desired set and returns the
+// grants and revokes needed. Sticky holders that are not desired are reported as warnings.
+func planRoleMembers(ctx context.Context, role common.Hash, roleName string, grantKind, revokeKind string, desired []common.Address, latest uint64, scan roleScanRange) ([]*policyAction, []*policyAction, []string, error) {
+	candidates := append([]common.Address{}, desired...)
+	if signerAddress != (common.Address{}) {
+		candidates = append(candidates, signerAddress)
+	}
+	current, err := fetchRoleMembers(ctx, role, candidates, scan.fromBlock, latest, scan.chunkSize)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	isCurrent := map[common.Address]bool{}
+	for _, account := range current {
+		isCurrent[account] = true
+	}
+	isDesired := map[common.Address]bool{}
+	var grants, revokes []*policyAction
+	var warnings []string
+	for _, account := range desired {
+		isDesired[account] = true
+		if !isCurrent[account] {
+			grants = append(grants, &policyAction{
+				Kind:    grantKind,
+				Subject: account.Hex(),
+				Current: "false",
+				Desired: "true",
+				send: func(ctx context.Context) (*types.Receipt, error) {
+					return gaterClient.GrantRole(ctx, role, account)
+				},
+			})
+		}
+	}
+
+	client := gaterClient.At(new(big.Int).SetUint64(latest))
+	for _, account := range current {
+		if isDesired[account] {
+			continue
+		}
+		isSticky, err := client.IsStickyRole(ctx, role, account)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to check sticky status of %s: %w", account.Hex(), err)
+		}
+		if isSticky {
+			warnings = append(warnings, fmt.Sprintf("%s %s is not in the policy but has a sticky role that cannot be revoked", roleName, account.Hex()))
+			continue
+		}
+		revokes = append(revokes, &policyAction{
+			Kind:    revokeKind,
+			Subject: account.Hex(),
+			Current: "true",
+			Desired: "false",
+			send: func(ctx context.Context) (*types.Receipt, error) {
+				return gaterClient.RevokeRole(ctx, role, account)
+			},
+		})
+	}
+	return grants, revokes, warnings, nil
+}
+
+// planPolicy compares a policy with the chain state and returns the actions needed to apply it.
+//
+// Actions are ordered so that the gater stays administrable at every step: new admins and deposit
+// contracts are granted first, then configs, custom gater and mints are applied, and roles are
+// revoked last (the signer's admin role at the very end).
+func planPolicy(ctx context.Context, policy *parsedPolicy, scan roleScanRange) (*policyPlan, error) {
+	plan := &policyPlan{Actions: []*policyAction{}}
+
+	// Pin the latest block to avoid cached responses
+	latestBlock, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	latest := latestBlock.Uint64()
+
+	// Admins
+	var revokes, depositContractRevokes []*policyAction
+	if policy.manageAdmins {
+		grants, adminRevokes, warnings, err := planRoleMembers(ctx, gater.DefaultAdminRole, "admin", actionGrantAdmin, actionRevokeAdmin, policy.admins, latest, scan)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, grants...)
+		plan.Warnings = append(plan.Warnings, warnings...)
+
+		var revokeSelf *policyAction
+		for _, action := range adminRevokes {
+			if action.Subject == signerAddress.Hex() {
+				revokeSelf = action
+				continue
+			}
+			revokes = append(revokes, action)
+		}
+		if revokeSelf != nil {
+			revokes = append(revokes, revokeSelf)
+		}
+
+		for _, admin := range policy.admins {
+			if !policy.stickyAdmins[admin] {
+				continue
+			}
+			isSticky, err := gaterClient.At(latestBlock).IsStickyRole(ctx, gater.DefaultAdminRole, admin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check sticky status of %s: %w", admin.Hex(), err)
+			}
+			if !isSticky {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("admin %s is sticky in the policy, but sticky roles cannot be granted by transactions (granted as regular admin)", admin.Hex()))
+			}
+		}
+	}
+
+	// Deposit contract role holders
+	if policy.manageDepositContracts {
+		grants, depositRevokes, warnings, err := planRoleMembers(ctx, gater.DepositContractRole, "deposit contract", actionGrantDepositContract, actionRevokeDepositContract, policy.depositContracts, latest, scan)
+		if err != nil {
+			return nil, err
+		}
+		plan.Actions = append(plan.Actions, grants...)
+		plan.Warnings = append(plan.Warnings, warnings...)
+
+		// Never lock out the deposit contract the CLI is connected to
+		for _, action := range depositRevokes {
+			if action.Subject == depositAddr.Hex() {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("deposit contract %s is not in the policy, but its role is kept as it is the connected deposit contract", action.Subject))
+				continue
+			}
+			depositContractRevokes = append(depositContractRevokes, action)
+		}
+	}
+
+	// Deposit type configs
+	depositTypes := make([]uint16, 0, len(policy.depositTypes))
+	for depositType := range policy.depositTypes {
+		depositTypes = append(depositTypes, depositType)
+	}
+	sort.Slice(depositTypes, func(i, j int) bool { return depositTypes[i] < depositTypes[j] })
+	configs := make([]gater.DepositGateConfig, len(depositTypes))
+	configCalls := make([]*gater.Call, len(depositTypes))
+	for i, depositType := range depositTypes {
+		configCalls[i] = gater.NewCall(&configs[i], "getDepositGateConfig", depositType)
+	}
+	if err := gaterClient.At(latestBlock).Batch(ctx, configCalls); err != nil {
+		return nil, fmt.Errorf("failed to get deposit type configs: %w", err)
+	}
+	for i, depositType := range depositTypes {
+		desired := policy.depositTypes[depositType]
+		current := configs[i]
+		if current == desired {
+			continue
+		}
+		plan.Actions = append(plan.Actions, &policyAction{
+			Kind:    actionSetConfig,
+			Subject: fmt.Sprintf("0x%04x", depositType),
+			Current: formatGateConfig(current),
+			Desired: formatGateConfig(desired),
+			send: func(ctx context.Context) (*types.Receipt, error) {
+				return sendDepositGateConfig(ctx, depositType, current, desired)
+			},
+		})
+	}
+
+	// Custom gater
+	if policy.customGater != nil {
+		current, err := gaterClient.At(latestBlock).CustomGater(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom gater: %w", err)
+		}
+		if current != *policy.customGater {
+			plan.Actions = append(plan.Actions, &policyAction{
+				Kind:    actionSetCustomGater,
+				Subject: "customGater",
+				Current: formatOptionalAddress(current),
+				Desired: formatOptionalAddress(*policy.customGater),
+				send: func(ctx context.Context) (*types.Receipt, error) {
+					return gaterClient.SetCustomGater(ctx, *policy.customGater)
+				},
+			})
+		}
+	}
+
+	// Token balances
+	holders := make([]common.Address, 0, len(policy.balances))
+	for holder := range policy.balances {
+		holders = append(holders, holder)
+	}
+	sortAddresses(holders)
+	balances := make([]*big.Int, len(holders))
+	balanceCalls := make([]*gater.Call, len(holders))
+	for i, holder := range holders {
+		balanceCalls[i] = gater.NewCall(&balances[i], "balanceOf", holder)
+	}
+	if err := gaterClient.At(latestBlock).Batch(ctx, balanceCalls); err != nil {
+		return nil, fmt.Errorf("failed to get token balances: %w", err)
+	}
+	for i, holder := range holders {
+		desired := policy.balances[holder]
+		current := balances[i]
+		switch current.Cmp(desired) {
+		case -1:
+			plan.Actions = append(plan.Actions, &policyAction{
+				Kind:    actionMint,
+				Subject: holder.Hex(),
+				Current: current.String(),
+				Desired: desired.String(),
+				send: func(ctx context.Context) (*types.Receipt, error) {
+					return gaterClient.Mint(ctx, holder, new(big.Int).Sub(desired, current))
+				},
+			})
+		case 1:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("balance of %s is %s, above the target of %s (tokens cannot be burned by admins)", holder.Hex(), current.String(), desired.String()))
+		}
+	}
+
+	plan.Actions = append(plan.Actions, depositContractRevokes...)
+	plan.Actions = append(plan.Actions, revokes...)
+	return plan, nil
+}
+
+// printPolicyPlan prints the actions and warnings of a plan.
+func printPolicyPlan(plan *policyPlan) {
+	for _, warning := range plan.Warnings {
+		fmt.Printf("%sWarning: %s%s\n", colorYellow, warning, colorReset)
+	}
+	if len(plan.Warnings) > 0 {
+		fmt.Println()
+	}
+
+	if len(plan.Actions) == 0 {
+		printSuccess("No changes. The chain state matches the policy.")
+		return
+	}
+
+	for _, action := range plan.Actions {
+		color := colorYellow
+		switch action.Kind {
+		case actionGrantAdmin, actionGrantDepositContract, actionMint:
+			color = colorGreen
+		case actionRevokeAdmin, actionRevokeDepositContract:
+			color = colorRed
+		}
+		fmt.Printf("  %s%s%s\n", color, action.Describe(), colorReset)
+	}
+	fmt.Println()
+	printInfo("Plan: %d change(s)", len(plan.Actions))
+}
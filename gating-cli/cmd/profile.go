@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileAddChainID uint64
+	profileAddSigner  string
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named network profiles",
+	Long: `Manages the named profiles in the config file (~/.config/gating-cli/config.yaml).
+
+A profile stores the RPC URL, deposit contract, gater override, signer backend,
+expected chain ID and output format of a network, so they don't have to be
+given on every invocation. Select a profile with --profile (or GATING_CLI_PROFILE),
+or make it the default with 'gating-cli profile use'.
+
+The well-known networks (mainnet, sepolia, holesky, hoodi) are available as
+built-in profiles with their deposit contract and chain ID.
+
+Flags and environment variables always take precedence over profile values. If
+the chain ID of the RPC doesn't match the profile, the CLI refuses to run.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		configureOutput()
+		return validateOutputFormat(outputFormat)
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or update a profile",
+	Long: `Adds a profile to the config file, or updates an existing one.
+
+The profile values are taken from the global flags (repeat --rpc to store
+several endpoints for failover):
+  gating-cli -r https://rpc.devnet.example -d 0x... --gater 0x... --output json \
+    profile add devnet --signer env:DEVNET_KEY
+
+If --chain-id is not given, the chain ID is queried from the RPC.
+
+Signer backends:
+  env:<VAR>    read the private key from an environment variable
+  file:<path>  read the private key from a file`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileAdd,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+func init() {
+	profileAddCmd.Flags().Uint64Var(&profileAddChainID, "chain-id", 0, "Expected chain ID (default: queried from the RPC)")
+	profileAddCmd.Flags().StringVar(&profileAddSigner, "signer", "", "Signer backend (env:<VAR> or file:<path>)")
+
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+}
+
+func runProfileAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	path, err := resolveConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := loadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+
+	profile, exists := config.Profiles[name]
+	if !exists {
+		profile = &networkProfile{}
+		if preset := presetProfile(name); preset != nil {
+			*profile = *preset
+		}
+	}
+
+	if len(rpcHosts) > 0 {
+		profile.RPC = strings.Join(rpcHosts, ",")
+	}
+	if depositContract != "" {
+		if !common.IsHexAddress(depositContract) {
+			return fmt.Errorf("invalid deposit contract address: %s", depositContract)
+		}
+		profile.DepositContract = common.HexToAddress(depositContract).Hex()
+	}
+	if gaterOverride != "" {
+		if !common.IsHexAddress(gaterOverride) {
+			return fmt.Errorf("invalid gater address: %s", gaterOverride)
+		}
+		profile.Gater = common.HexToAddress(gaterOverride).Hex()
+	}
+	if profileAddSigner != "" {
+		profile.Signer = profileAddSigner
+	}
+	if cmd.Flags().Changed("output") {
+		profile.Output = outputFormat
+	}
+	if err := profile.validate(); err != nil {
+		return err
+	}
+
+	// Determine the expected chain ID
+	switch {
+	case profileAddChainID != 0:
+		profile.ChainID = profileAddChainID
+	case len(rpcHosts) > 0:
+		ctx := cmd.Context()
+		pool, err := dialRPCPool(ctx, rpcHosts, rpcTimeout, rpcRetries)
+		if err != nil {
+			return fmt.Errorf("failed to connect to RPC: %w", err)
+		}
+		defer pool.Close()
+		remoteChainID, err := pool.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		profile.ChainID = remoteChainID.Uint64()
+		log.WithField("chainID", profile.ChainID).Info("Using chain ID of the RPC")
+	}
+
+	config.Profiles[name] = profile
+	if err := saveCLIConfig(path, config); err != nil {
+		return err
+	}
+
+	if exists {
+		printSuccess("Updated profile %q in %s", name, path)
+	} else {
+		printSuccess("Added profile %q to %s", name, path)
+	}
+	printProfile(profile)
+	return nil
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	path, err := resolveConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := loadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		type profileEntry struct {
+			Name    string `json:"name"`
+			Current bool   `json:"current"`
+			Preset  bool   `json:"preset"`
+			*networkProfile
+		}
+		entries := []profileEntry{}
+		for _, name := range config.profileNames() {
+			_, userDefined := config.Profiles[name]
+			entries = append(entries, profileEntry{
+				Name:           name,
+				Current:        name == config.CurrentProfile,
+				Preset:         !userDefined,
+				networkProfile: config.findProfile(name),
+			})
+		}
+		return printJSON(entries)
+	}
+
+	printHeader("═══ Profiles ═══")
+	fmt.Println()
+	fmt.Printf("%sConfig File:%s %s\n", colorCyan, colorReset, path)
+	fmt.Println()
+
+	for _, name := range config.profileNames() {
+		profile := config.findProfile(name)
+		marker := " "
+		if name == config.CurrentProfile {
+			marker = colorGreen + "*" + colorReset
+		}
+		label := name
+		if _, userDefined := config.Profiles[name]; !userDefined {
+			label += " (built-in)"
+		}
+		fmt.Printf("%s %s%s%s\n", marker, colorBold, label, colorReset)
+		printProfile(profile)
+		fmt.Println()
+	}
+	return nil
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	path, err := resolveConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := loadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if config.findProfile(name) == nil {
+		return fmt.Errorf("unknown profile %q (see 'gating-cli profile list')", name)
+	}
+	config.CurrentProfile = name
+	if err := saveCLIConfig(path, config); err != nil {
+		return err
+	}
+
+	printSuccess("Using profile %q by default", name)
+	return nil
+}
+
+// printProfile prints the values of a profile.
+func printProfile(profile *networkProfile) {
+	printValue := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s%-18s%s %s\n", colorCyan, label+":", colorReset, value)
+		}
+	}
+	printValue("RPC", profile.RPC)
+	printValue("Deposit Contract", profile.DepositContract)
+	printValue("Gater", profile.Gater)
+	printValue("Signer", profile.Signer)
+	if profile.ChainID != 0 {
+		printValue("Chain ID", fmt.Sprintf("%d", profile.ChainID))
+	}
+	printValue("Output", profile.Output)
+}
+
+// printJSON prints a value as indented JSON.
+func printJSON(value interface{}) error {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
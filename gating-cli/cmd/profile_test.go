@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestProfiles(t *testing.T) {
+	env := newTestEnv(t)
+	t.Setenv("DEV_KEY", hex.EncodeToString(crypto.FromECDSA(env.chain.AdminKey)))
+
+	out := env.mustRun(nil, "--output", "json", "profile", "add", "dev", "--signer", "env:DEV_KEY")
+	assertContains(t, out, `Added profile "dev"`)
+	env.mustRun(nil, "profile", "add", "wrong", "--chain-id", "1")
+	out = env.mustRun(nil, "profile", "list")
+	assertContains(t, out, "dev", "wrong")
+
+	// The profile provides the signer and the output format
+	out = env.mustRun(nil, "status", "--profile", "dev")
+	var report statusReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("expected JSON output of the profile: %v\n%s", err, out)
+	}
+	if report.ChainID != 1337 || !report.SignerIsAdmin {
+		t.Errorf("unexpected status with profile: %+v", report)
+	}
+
+	// Flags and environment variables take precedence over the profile
+	out = env.mustRun(nil, "status", "--profile", "dev", "--output", "text")
+	assertContains(t, out, "Signer is Admin:   Yes")
+	out = env.mustRun(env.chain.UserKey, "status", "--profile", "dev", "--output", "text")
+	assertContains(t, out, "Signer is Admin:   No")
+	t.Setenv("PRIVATE_KEY", hex.EncodeToString(crypto.FromECDSA(env.chain.UserKey)))
+	out = env.mustRun(nil, "status", "--profile", "dev", "--output", "text")
+	assertContains(t, out, "Signer is Admin:   No")
+	t.Setenv("PRIVATE_KEY", "")
+
+	// The profile is selected by --profile, then GATING_CLI_PROFILE, then the current profile
+	env.mustRun(nil, "profile", "use", "wrong")
+	_, err := env.run(env.chain.AdminKey, "status")
+	assertError(t, err, `profile "wrong" expects 1`)
+	t.Setenv("GATING_CLI_PROFILE", "dev")
+	env.mustRun(nil, "status")
+	_, err = env.run(env.chain.AdminKey, "status", "--profile", "wrong")
+	assertError(t, err, `profile "wrong" expects 1`)
+
+	// --expect-chain-id replaces the chain ID of the profile
+	env.mustRun(env.chain.AdminKey, "status", "--profile", "wrong", "--expect-chain-id", "1337")
+
+	_, err = env.run(env.chain.AdminKey, "status", "--profile", "missing")
+	assertError(t, err, `unknown profile "missing"`)
+}
@@ -0,0 +1,598 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	requestsStorePath     string
+	requestsJustification string
+	requestsSubmitter     string
+	requestsStatus        string
+	requestsReason        string
+	requestsLimit         int
+	requestsListen        string
+	requestsAuthToken     string
+)
+
+var requestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "Token requests with admin approval",
+	Long: `Manages requests for deposit tokens that need an admin's approval before minting.
+
+Operators submit requests (address, amount, justification) with 'requests submit'
+or via the HTTP API of 'requests serve'. Admins review them with 'requests list',
+'requests approve' and 'requests reject', and mint all approved requests in one
+confirmed batch with 'requests mint'. The transaction hash of each mint is
+recorded with the request.
+
+Requests are kept in a local store file (default: requests-<chain ID>.json next to
+the config file), so all commands must use the same --store.`,
+}
+
+var requestsSubmitCmd = &cobra.Command{
+	Use:         "submit <address> <amount>",
+	Short:       "Submit a token request",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runRequestsSubmit,
+}
+
+var requestsListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List token requests",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runRequestsList,
+}
+
+var requestsApproveCmd = &cobra.Command{
+	Use:   "approve <id>...",
+	Short: "Approve pending token requests (admin only)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runRequestsApprove,
+}
+
+var requestsRejectCmd = &cobra.Command{
+	Use:   "reject <id>...",
+	Short: "Reject pending or approved token requests (admin only)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runRequestsReject,
+}
+
+var requestsMintCmd = &cobra.Command{
+	Use:   "mint",
+	Short: "Mint all approved token requests (admin only)",
+	Long: `Mints the tokens of all approved requests, oldest first, after a single confirmation.
+
+Each request is marked as minting before its transaction is sent and as minted
+with the transaction hash once it is included. If a mint fails before its
+transaction was sent, the request returns to approved with the error and the
+batch stops. If the transaction was sent but not confirmed (e.g. a timeout), the
+request stays in the minting state with the transaction hash, and the next run
+checks its receipt: it is marked as minted once the transaction is included, or
+returns to approved if it reverted. A request left in the minting state without
+a transaction hash (e.g. after a crash) is skipped; check its recipient's
+balance before handling it manually.`,
+	Args: cobra.NoArgs,
+	RunE: runRequestsMint,
+}
+
+var requestsServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an HTTP API for submitting token requests",
+	Long: `Serves an HTTP API where operators submit token requests and check their status:
+
+  POST /api/requests       {"address": "0x...", "amount": "1", "justification": "...", "submitter": "..."}
+  GET  /api/requests/<id>  status of a request
+
+If --auth-token is set, requests must carry an "Authorization: Bearer <token>" header.
+Reviewing and minting is only possible with the CLI.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runRequestsServe,
+}
+
+func init() {
+	requestsCmd.PersistentFlags().StringVar(&requestsStorePath, "store", "", "Request store file (default: requests-<chain ID>.json next to the config file)")
+
+	requestsSubmitCmd.Flags().StringVar(&requestsJustification, "justification", "", "Why the tokens are needed (required)")
+	requestsSubmitCmd.Flags().StringVar(&requestsSubmitter, "submitter", "", "Name or contact of the submitter")
+	requestsListCmd.Flags().StringVar(&requestsStatus, "status", "", "Only list requests with this status (pending, approved, rejected, minting, minted)")
+	requestsRejectCmd.Flags().StringVar(&requestsReason, "reason", "", "Reason for the rejection")
+	requestsMintCmd.Flags().IntVar(&requestsLimit, "limit", 0, "Maximum number of requests to mint (default: all)")
+	requestsServeCmd.Flags().StringVar(&requestsListen, "listen", ":8081", "Address to serve the API on")
+	requestsServeCmd.Flags().StringVar(&requestsAuthToken, "auth-token", "", "Bearer token required to use the API (default: none)")
+
+	requestsCmd.AddCommand(requestsSubmitCmd)
+	requestsCmd.AddCommand(requestsListCmd)
+	requestsCmd.AddCommand(requestsApproveCmd)
+	requestsCmd.AddCommand(requestsRejectCmd)
+	requestsCmd.AddCommand(requestsMintCmd)
+	requestsCmd.AddCommand(requestsServeCmd)
+}
+
+// resolveRequestStorePath returns the store given by --store or the default store of the network.
+func resolveRequestStorePath() (string, error) {
+	if requestsStorePath != "" {
+		return requestsStorePath, nil
+	}
+	return chainDataPath("requests", "json")
+}
+
+// newTokenRequest validates the values of a submitted request.
+func newTokenRequest(address, amount, justification, submitter string) (*tokenRequest, error) {
+	address = strings.TrimSpace(address)
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address: %s", address)
+	}
+	parsedAmount, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+	if !ok || parsedAmount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount: %s", amount)
+	}
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return nil, fmt.Errorf("justification is required")
+	}
+	return &tokenRequest{
+		Address:       common.HexToAddress(address).Hex(),
+		Amount:        parsedAmount.String(),
+		Justification: justification,
+		Submitter:     strings.TrimSpace(submitter),
+	}, nil
+}
+
+// parseRequestIDs parses request IDs given as "3" or "#3".
+func parseRequestIDs(args []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseUint(strings.TrimPrefix(arg, "#"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func runRequestsSubmit(cmd *cobra.Command, args []string) error {
+	storePath, err := resolveRequestStorePath()
+	if err != nil {
+		return err
+	}
+	request, err := newTokenRequest(args[0], args[1], requestsJustification, requestsSubmitter)
+	if err != nil {
+		return err
+	}
+	if err := requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+		store.add(request)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(request)
+	}
+	printSuccess("Submitted request #%d for %s tokens to %s", request.ID, request.Amount, request.Address)
+	return nil
+}
+
+func runRequestsList(cmd *cobra.Command, args []string) error {
+	storePath, err := resolveRequestStorePath()
+	if err != nil {
+		return err
+	}
+	store, err := requestStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+
+	requests := []*tokenRequest{}
+	for _, request := range store.Requests {
+		if requestsStatus == "" || request.Status == requestsStatus {
+			requests = append(requests, request)
+		}
+	}
+
+	if outputFormat == "json" {
+		return printJSON(requests)
+	}
+
+	printHeader("═══ Token Requests ═══")
+	fmt.Println()
+	fmt.Printf("%sStore:%s %s\n", colorCyan, colorReset, storePath)
+	fmt.Println()
+	if len(requests) == 0 {
+		printInfo("No requests.")
+		return nil
+	}
+	for _, request := range requests {
+		printTokenRequest(request)
+		fmt.Println()
+	}
+	return nil
+}
+
+// printTokenRequest prints a request with its review and mint details.
+func printTokenRequest(request *tokenRequest) {
+	fmt.Printf("%s#%d%s %s\n", colorBold, request.ID, colorReset, formatTokenRequestStatus(request.Status))
+	printValue := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s%-15s%s %s\n", colorCyan, label+":", colorReset, value)
+		}
+	}
+	printValue("Address", request.Address)
+	printValue("Amount", request.Amount+" tokens")
+	printValue("Justification", request.Justification)
+	printValue("Submitter", request.Submitter)
+	printValue("Submitted", request.SubmittedAt.Format(time.RFC3339))
+	if request.ReviewedAt != nil {
+		printValue("Reviewed", fmt.Sprintf("%s by %s", request.ReviewedAt.Format(time.RFC3339), request.ReviewedBy))
+	}
+	printValue("Reason", request.Reason)
+	printValue("Transaction", request.TxHash)
+	printValue("Error", request.Error)
+}
+
+// formatTokenRequestStatus returns a colored request status.
+func formatTokenRequestStatus(status string) string {
+	switch status {
+	case tokenRequestApproved, tokenRequestMinted:
+		return colorGreen + status + colorReset
+	case tokenRequestRejected:
+		return colorRed + status + colorReset
+	default:
+		return colorYellow + status + colorReset
+	}
+}
+
+func runRequestsApprove(cmd *cobra.Command, args []string) error {
+	return reviewTokenRequests(cmd.Context(), args, tokenRequestApproved)
+}
+
+func runRequestsReject(cmd *cobra.Command, args []string) error {
+	return reviewTokenRequests(cmd.Context(), args, tokenRequestRejected)
+}
+
+// reviewTokenRequests approves or rejects requests as the signer. All requests are
+// checked before any is changed.
+func reviewTokenRequests(ctx context.Context, args []string, status string) error {
+	if err := checkAdminRole(ctx); err != nil {
+		return err
+	}
+	ids, err := parseRequestIDs(args)
+	if err != nil {
+		return err
+	}
+	storePath, err := resolveRequestStorePath()
+	if err != nil {
+		return err
+	}
+
+	err = requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+		requests := make([]*tokenRequest, 0, len(ids))
+		for _, id := range ids {
+			request, err := store.find(id)
+			if err != nil {
+				return err
+			}
+			reviewable := request.Status == tokenRequestPending || (status == tokenRequestRejected && request.Status == tokenRequestApproved)
+			if !reviewable {
+				return fmt.Errorf("request #%d is %s and can't be %s", id, request.Status, status)
+			}
+			requests = append(requests, request)
+		}
+
+		now := time.Now().UTC()
+		for _, request := range requests {
+			request.Status = status
+			request.ReviewedBy = signerAddress.Hex()
+			request.ReviewedAt = &now
+			request.Reason = requestsReason
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		printSuccess("Request #%d %s", id, status)
+	}
+	return nil
+}
+
+func runRequestsMint(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if err := checkAdminRole(ctx); err != nil {
+		return err
+	}
+	storePath, err := resolveRequestStorePath()
+	if err != nil {
+		return err
+	}
+	if err := reconcileMintingRequests(ctx, storePath); err != nil {
+		return err
+	}
+	store, err := requestStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+
+	approved := []*tokenRequest{}
+	for _, request := range store.Requests {
+		switch request.Status {
+		case tokenRequestApproved:
+			approved = append(approved, request)
+		case tokenRequestMinting:
+			log.WithFields(map[string]interface{}{
+				"id": request.ID,
+				"tx": request.TxHash,
+			}).Warn("Skipping request in minting state, check if it was minted")
+		}
+	}
+	sort.Slice(approved, func(i, j int) bool { return approved[i].ID < approved[j].ID })
+	if requestsLimit > 0 && len(approved) > requestsLimit {
+		approved = approved[:requestsLimit]
+	}
+	if len(approved) == 0 {
+		printInfo("No approved requests to mint.")
+		return nil
+	}
+
+	printHeader("═══ Approved Requests ═══")
+	fmt.Println()
+	for _, request := range approved {
+		fmt.Printf("  #%-5d %s tokens to %s\n", request.ID, request.Amount, request.Address)
+	}
+
+	confirmed, err := confirmTransactionBatch(len(approved), "mint approved token requests")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		printInfo("Aborted.")
+		return nil
+	}
+	defer endTransactionBatch()
+
+	for i, pending := range approved {
+		// Reserve the request, so a concurrent mint doesn't send it again
+		var request *tokenRequest
+		err := requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+			var err error
+			if request, err = store.find(pending.ID); err != nil {
+				return err
+			}
+			if request.Status != tokenRequestApproved {
+				return fmt.Errorf("request #%d is %s now", request.ID, request.Status)
+			}
+			request.Status = tokenRequestMinting
+			request.Error = ""
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		log.WithFields(map[string]interface{}{
+			"step": fmt.Sprintf("%d/%d", i+1, len(approved)),
+			"id":   request.ID,
+		}).Info("Minting request")
+
+		amount, _ := new(big.Int).SetString(request.Amount, 10)
+		receipt, mintErr := mintTokens(ctx, common.HexToAddress(request.Address), amount)
+
+		err = requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+			stored, err := store.find(request.ID)
+			if err != nil {
+				return err
+			}
+			var unconfirmed *gater.UnconfirmedError
+			if errors.As(mintErr, &unconfirmed) {
+				// The mint may still be included, the next run checks its receipt
+				stored.TxHash = unconfirmed.Tx.Hash().Hex()
+				stored.Error = mintErr.Error()
+				return nil
+			}
+			if mintErr != nil {
+				stored.Status = tokenRequestApproved
+				stored.Error = mintErr.Error()
+				return nil
+			}
+			now := time.Now().UTC()
+			stored.Status = tokenRequestMinted
+			stored.TxHash = receipt.TxHash.Hex()
+			stored.MintedAt = &now
+			return nil
+		})
+		if mintErr != nil {
+			return fmt.Errorf("request #%d: %w", request.ID, mintErr)
+		}
+		if err != nil {
+			return fmt.Errorf("request #%d was minted in %s, but recording it failed: %w", request.ID, receipt.TxHash.Hex(), err)
+		}
+
+		printSuccess("Request #%d: minted %s tokens to %s", request.ID, request.Amount, request.Address)
+		fmt.Printf("%sTransaction:%s %s\n", colorCyan, colorReset, receipt.TxHash.Hex())
+	}
+
+	fmt.Println()
+	printSuccess("Minted %d request(s).", len(approved))
+	return nil
+}
+
+// reconcileMintingRequests checks the receipts of requests in the minting state whose mint was
+// sent, but not confirmed. Included mints are marked as minted, reverted ones return to approved.
+func reconcileMintingRequests(ctx context.Context, storePath string) error {
+	store, err := requestStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(store.Requests, func(request *tokenRequest) bool {
+		return request.Status == tokenRequestMinting && request.TxHash != ""
+	}) {
+		return nil
+	}
+
+	return requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+		for _, request := range store.Requests {
+			if request.Status != tokenRequestMinting || request.TxHash == "" {
+				continue
+			}
+			receipt, err := ethClient.TransactionReceipt(ctx, common.HexToHash(request.TxHash))
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get receipt of request #%d: %w", request.ID, err)
+			}
+
+			if receipt.Status == types.ReceiptStatusFailed {
+				request.Status = tokenRequestApproved
+				request.Error = fmt.Sprintf("mint transaction %s reverted", request.TxHash)
+				log.WithField("id", request.ID).Warn("Mint of request reverted, it is minted again")
+				continue
+			}
+			now := time.Now().UTC()
+			request.Status = tokenRequestMinted
+			request.MintedAt = &now
+			request.Error = ""
+			log.WithFields(map[string]interface{}{
+				"id": request.ID,
+				"tx": request.TxHash,
+			}).Info("Mint of request was included")
+		}
+		return nil
+	})
+}
+
+func runRequestsServe(cmd *cobra.Command, args []string) error {
+	storePath, err := resolveRequestStorePath()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", requestsListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", requestsListen, err)
+	}
+	server := &http.Server{Handler: tokenRequestHandler(storePath), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Request server failed")
+			stop()
+		}
+	}()
+	log.WithFields(map[string]interface{}{
+		"address": listener.Addr().String(),
+		"store":   storePath,
+	}).Info("Serving token request API")
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// tokenRequestHandler returns the HTTP handler of the request API.
+func tokenRequestHandler(storePath string) http.Handler {
+	writeJSON := func(w http.ResponseWriter, status int, value interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(value)
+	}
+	writeError := func(w http.ResponseWriter, status int, err error) {
+		writeJSON(w, status, map[string]string{"error": err.Error()})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/requests", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Address       string `json:"address"`
+			Amount        string `json:"amount"`
+			Justification string `json:"justification"`
+			Submitter     string `json:"submitter"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16384)).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body"))
+			return
+		}
+		request, err := newTokenRequest(body.Address, body.Amount, body.Justification, body.Submitter)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+			store.add(request)
+			return nil
+		}); err != nil {
+			log.WithError(err).Error("Failed to store token request")
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to store request"))
+			return
+		}
+		log.WithFields(map[string]interface{}{
+			"id":      request.ID,
+			"address": request.Address,
+			"amount":  request.Amount,
+		}).Info("Token request submitted")
+		writeJSON(w, http.StatusCreated, request)
+	})
+	mux.HandleFunc("GET /api/requests/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ids, err := parseRequestIDs([]string{r.PathValue("id")})
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		store, err := requestStoreFile.load(storePath)
+		if err != nil {
+			log.WithError(err).Error("Failed to load token requests")
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to load requests"))
+			return
+		}
+		request, err := store.find(ids[0])
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, request)
+	})
+
+	if requestsAuthToken == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+requestsAuthToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid authorization token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
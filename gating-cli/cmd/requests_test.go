@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// requestStatuses returns the status of each request in the store.
+func requestStatuses(t *testing.T, storePath string) map[uint64]*tokenRequest {
+	t.Helper()
+	store, err := requestStoreFile.load(storePath)
+	if err != nil {
+		t.Fatalf("failed to load request store: %v", err)
+	}
+	requests := map[uint64]*tokenRequest{}
+	for _, request := range store.Requests {
+		requests[request.ID] = request
+	}
+	return requests
+}
+
+func TestRequestsWorkflow(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "requests.json")
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	second := common.HexToAddress("0x1000000000000000000000000000000000000002")
+
+	// Operators submit without a key
+	out := env.mustRun(nil, "requests", "submit", first.Hex(), "3", "--justification", "3 validators for client testing", "--submitter", "alice", "--store", storePath)
+	assertContains(t, out, "Submitted request #1 for 3 tokens to "+first.Hex())
+	env.mustRun(nil, "requests", "submit", second.Hex(), "100", "--justification", "lots", "--store", storePath)
+	env.mustRun(nil, "requests", "submit", second.Hex(), "1", "--justification", "one more", "--store", storePath)
+
+	out = env.mustRun(nil, "requests", "list", "--status", "pending", "--store", storePath, "--output", "json")
+	var pending []*tokenRequest
+	if err := json.Unmarshal([]byte(out), &pending); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if len(pending) != 3 || pending[0].Submitter != "alice" {
+		t.Fatalf("unexpected pending requests: %s", out)
+	}
+
+	// Only admins review
+	_, err := env.run(env.chain.UserKey, "requests", "approve", "1", "--store", storePath)
+	assertError(t, err, "does not have admin role")
+
+	env.mustRun(env.chain.AdminKey, "requests", "approve", "1", "#3", "--store", storePath)
+	env.mustRun(env.chain.AdminKey, "requests", "reject", "2", "--reason", "too many", "--store", storePath)
+
+	_, err = env.run(env.chain.AdminKey, "requests", "approve", "2", "--store", storePath)
+	assertError(t, err, "request #2 is rejected and can't be approved")
+
+	// Approved requests are minted in one batch
+	out = env.mustRun(env.chain.AdminKey, "requests", "mint", "--store", storePath, "--yes")
+	assertContains(t, out, "Request #1: minted 3 tokens", "Request #3: minted 1 tokens", "Minted 2 request(s).")
+	if balance := env.balanceOf(first); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("balance of %s = %s, want 3", first.Hex(), balance)
+	}
+	if balance := env.balanceOf(second); balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("balance of %s = %s, want 1", second.Hex(), balance)
+	}
+
+	requests := requestStatuses(t, storePath)
+	if requests[1].Status != tokenRequestMinted || requests[1].TxHash == "" || requests[1].ReviewedBy != env.chain.Admin.Hex() {
+		t.Errorf("unexpected request #1: %+v", requests[1])
+	}
+	if requests[2].Status != tokenRequestRejected || requests[2].Reason != "too many" {
+		t.Errorf("unexpected request #2: %+v", requests[2])
+	}
+
+	out = env.mustRun(env.chain.AdminKey, "requests", "mint", "--store", storePath, "--yes")
+	assertContains(t, out, "No approved requests to mint.")
+}
+
+func TestRequestsServe(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "requests.json")
+	baseURL := env.startServer(nil, "/api/requests/1", "requests", "serve", "--store", storePath, "--auth-token", "secret")
+
+	post := func(token string, body map[string]string) (int, map[string]interface{}) {
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, baseURL+"/api/requests", bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		response := map[string]interface{}{}
+		json.NewDecoder(resp.Body).Decode(&response)
+		return resp.StatusCode, response
+	}
+
+	status, _ := post("wrong", map[string]string{"address": env.chain.User.Hex(), "amount": "2", "justification": "test"})
+	if status != http.StatusUnauthorized {
+		t.Errorf("wrong token: status %d, want 401", status)
+	}
+	status, response := post("secret", map[string]string{"address": env.chain.User.Hex(), "amount": "2"})
+	if status != http.StatusBadRequest || response["error"] != "justification is required" {
+		t.Errorf("missing justification: status %d (%v)", status, response)
+	}
+	status, response = post("secret", map[string]string{"address": env.chain.User.Hex(), "amount": "2", "justification": "test", "submitter": "bob"})
+	if status != http.StatusCreated || response["id"] != float64(1) || response["status"] != tokenRequestPending {
+		t.Fatalf("submit: status %d (%v)", status, response)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, baseURL+"/api/requests/1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("status request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	request := &tokenRequest{}
+	json.NewDecoder(resp.Body).Decode(request)
+	if resp.StatusCode != http.StatusOK || request.Address != env.chain.User.Hex() || request.Submitter != "bob" {
+		t.Errorf("status: %d %+v", resp.StatusCode, request)
+	}
+
+	if requests := requestStatuses(t, storePath); len(requests) != 1 || requests[1].Amount != "2" {
+		t.Errorf("unexpected store: %+v", requests)
+	}
+}
+
+func TestRequestsMintUnconfirmed(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "requests.json")
+	for i := 0; i < 3; i++ {
+		env.mustRun(nil, "requests", "submit", env.chain.User.Hex(), "2", "--justification", "testing", "--store", storePath)
+	}
+	env.mustRun(env.chain.AdminKey, "requests", "approve", "1", "2", "3", "--store", storePath)
+
+	// Mints that were sent, but not confirmed: #1 was included later, #2 is still unknown
+	receipt, err := env.adminGater().Mint(t.Context(), env.chain.User, big.NewInt(2))
+	if err != nil {
+		t.Fatalf("mint failed: %v", err)
+	}
+	err = requestStoreFile.update(storePath, func(store *tokenRequestStore) error {
+		store.Requests[0].Status = tokenRequestMinting
+		store.Requests[0].TxHash = receipt.TxHash.Hex()
+		store.Requests[1].Status = tokenRequestMinting
+		store.Requests[1].TxHash = common.HexToHash("0x01").Hex()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to update request store: %v", err)
+	}
+
+	out := env.mustRun(env.chain.AdminKey, "requests", "mint", "--store", storePath, "--yes")
+	assertContains(t, out, "Request #3: minted 2 tokens", "Minted 1 request(s).")
+	if balance := env.balanceOf(env.chain.User); balance.Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("balance = %s, want 4", balance)
+	}
+	requests := requestStatuses(t, storePath)
+	if requests[1].Status != tokenRequestMinted || requests[1].TxHash != receipt.TxHash.Hex() || requests[1].MintedAt == nil {
+		t.Errorf("unexpected request #1: %+v", requests[1])
+	}
+	if requests[2].Status != tokenRequestMinting {
+		t.Errorf("unexpected request #2: %+v", requests[2])
+	}
+}
+
+func TestRequestsErrors(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "requests.json")
+
+	_, err := env.run(nil, "requests", "submit", "0x1234", "1", "--justification", "x", "--store", storePath)
+	assertError(t, err, "invalid address: 0x1234")
+
+	_, err = env.run(nil, "requests", "submit", env.chain.User.Hex(), "0", "--justification", "x", "--store", storePath)
+	assertError(t, err, "invalid amount: 0")
+
+	_, err = env.run(nil, "requests", "submit", env.chain.User.Hex(), "1", "--store", storePath)
+	assertError(t, err, "justification is required")
+
+	_, err = env.run(env.chain.AdminKey, "requests", "approve", "7", "--store", storePath)
+	assertError(t, err, "request #7 not found")
+
+	_, err = env.run(env.chain.AdminKey, "requests", "reject", "abc", "--store", storePath)
+	assertError(t, err, "invalid request ID: abc")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// Token request states. Requests go from pending to approved or rejected, and approved
+// requests from minting to minted (or back to approved with an error if the mint failed).
+const (
+	tokenRequestPending  = "pending"
+	tokenRequestApproved = "approved"
+	tokenRequestRejected = "rejected"
+	tokenRequestMinting  = "minting"
+	tokenRequestMinted   = "minted"
+)
+
+// tokenRequest is a request for deposit tokens awaiting admin approval.
+type tokenRequest struct {
+	ID            uint64     `json:"id"`
+	Address       string     `json:"address"`
+	Amount        string     `json:"amount"`
+	Justification string     `json:"justification"`
+	Submitter     string     `json:"submitter,omitempty"`
+	SubmittedAt   time.Time  `json:"submittedAt"`
+	Status        string     `json:"status"`
+	ReviewedBy    string     `json:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewedAt,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+	TxHash        string     `json:"txHash,omitempty"`
+	MintedAt      *time.Time `json:"mintedAt,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// tokenRequestStore is the content of the request store file.
+type tokenRequestStore struct {
+	NextID   uint64          `json:"nextId"`
+	Requests []*tokenRequest `json:"requests"`
+}
+
+// requestStoreFile is the request store file. Its updates are also serialized within the
+// process, e.g. of the HTTP server.
+var requestStoreFile = &jsonStore[tokenRequestStore]{
+	name:  "request store",
+	empty: func() *tokenRequestStore { return &tokenRequestStore{NextID: 1} },
+}
+
+// find returns the request with the given ID.
+func (store *tokenRequestStore) find(id uint64) (*tokenRequest, error) {
+	for _, request := range store.Requests {
+		if request.ID == id {
+			return request, nil
+		}
+	}
+	return nil, fmt.Errorf("request #%d not found", id)
+}
+
+// add appends a new pending request and assigns its ID.
+func (store *tokenRequestStore) add(request *tokenRequest) {
+	request.ID = store.NextID
+	request.Status = tokenRequestPending
+	request.SubmittedAt = time.Now().UTC()
+	store.NextID++
+	store.Requests = append(store.Requests, request)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
 	"github.com/spf13/cobra"
 )
 
@@ -28,7 +30,7 @@ func init() {
 }
 
 func runRevokeAdmin(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Check admin role
 	if err := checkAdminRole(ctx); err != nil {
@@ -59,7 +61,7 @@ func runRevokeAdmin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if has admin role
-	isAdmin, err := hasRole(ctx, DefaultAdminRole, target)
+	isAdmin, err := gaterClient.HasRole(ctx, gater.DefaultAdminRole, target)
 	if err != nil {
 		return fmt.Errorf("failed to check existing role: %w", err)
 	}
@@ -69,7 +71,7 @@ func runRevokeAdmin(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if sticky
-	isSticky, err := isStickyRole(ctx, DefaultAdminRole, target)
+	isSticky, err := gaterClient.IsStickyRole(ctx, gater.DefaultAdminRole, target)
 	if err != nil {
 		return fmt.Errorf("failed to check sticky status: %w", err)
 	}
@@ -77,18 +79,10 @@ func runRevokeAdmin(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot revoke admin role from %s: role is sticky", target.Hex())
 	}
 
-	log.WithField("target", target.Hex()).Info("Revoking admin role")
-
-	// Pack transaction data
-	data, err := parsedABI.Pack("revokeRole", DefaultAdminRole, target)
-	if err != nil {
-		return fmt.Errorf("failed to pack revokeRole call: %w", err)
-	}
-
 	// Send transaction
-	receipt, err := sendTransaction(ctx, gaterAddr, data)
+	receipt, err := revokeAdminRole(ctx, target)
 	if err != nil {
-		return fmt.Errorf("revokeAdmin failed: %w", err)
+		return err
 	}
 
 	printSuccess("Successfully revoked admin role from %s", target.Hex())
@@ -97,3 +91,14 @@ func runRevokeAdmin(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// revokeAdminRole revokes the admin role from an account with the signer key and waits for the receipt.
+func revokeAdminRole(ctx context.Context, target common.Address) (*types.Receipt, error) {
+	log.WithField("target", target.Hex()).Info("Revoking admin role")
+
+	receipt, err := gaterClient.RevokeRole(ctx, gater.DefaultAdminRole, target)
+	if err != nil {
+		return nil, fmt.Errorf("revokeAdmin failed: %w", err)
+	}
+	return receipt, nil
+}
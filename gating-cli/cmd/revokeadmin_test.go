@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestRevokeAdmin(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+
+	out := env.mustRun(env.chain.AdminKey, "revokeAdmin", env.chain.User.Hex(), "--yes")
+	assertContains(t, out, "Successfully revoked admin role from "+env.chain.User.Hex())
+	if env.hasAdminRole(env.chain.User) {
+		t.Fatal("user is still admin after revokeAdmin")
+	}
+
+	// Revoking again is a no-op
+	out = env.mustRun(env.chain.AdminKey, "revokeAdmin", env.chain.User.Hex(), "--yes")
+	assertContains(t, out, "does not have admin role")
+}
+
+func TestRevokeAdminSticky(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.AdminKey, "revokeAdmin", env.chain.Admin.Hex(), "--gater", gatertest.StickyGaterAddress.Hex(), "--yes")
+	assertError(t, err, "role is sticky")
+}
+
+func TestRevokeAdminErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.UserKey, "revokeAdmin", env.chain.Admin.Hex(), "--yes")
+	assertError(t, err, "does not have admin role")
+	if !env.hasAdminRole(env.chain.Admin) {
+		t.Error("non-admin revoked the admin role")
+	}
+
+	_, err = env.run(env.chain.AdminKey, "revokeAdmin", "0xzz", "--yes")
+	assertError(t, err, "invalid address")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+// fetchRoleMembers returns all accounts that currently hold a role.
+// Candidates are collected from RoleGranted logs and then checked with hasRole, as
+// roles can also be revoked, renounced or set directly in storage (e.g. sticky genesis admins).
+// Membership is checked at toBlock.
+func fetchRoleMembers(ctx context.Context, role common.Hash, extraCandidates []common.Address, fromBlock, toBlock, chunkSize uint64) ([]common.Address, error) {
+	candidates, err := newRoleCandidateScanner(role, fromBlock, chunkSize).scan(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	return checkRoleMembers(ctx, role, append(candidates, extraCandidates...), toBlock)
+}
+
+// roleCandidateScanner collects the accounts that were granted a role from RoleGranted logs.
+// Each scan only fetches the logs of the blocks that weren't scanned before, so long-running
+// commands can look up the role holders repeatedly without scanning the whole chain each time.
+type roleCandidateScanner struct {
+	role      common.Hash
+	chunkSize uint64
+
+	mutex      sync.Mutex
+	nextBlock  uint64
+	seen       map[common.Address]bool
+	candidates []common.Address
+}
+
+// newRoleCandidateScanner creates a scanner for a role, starting at fromBlock.
+func newRoleCandidateScanner(role common.Hash, fromBlock, chunkSize uint64) *roleCandidateScanner {
+	return &roleCandidateScanner{
+		role:      role,
+		chunkSize: chunkSize,
+		nextBlock: fromBlock,
+		seen:      map[common.Address]bool{},
+	}
+}
+
+// scan fetches the RoleGranted logs up to toBlock and returns all candidates found so far.
+// Candidates are never dropped (e.g. on reorgs), membership is checked with checkRoleMembers.
+func (scanner *roleCandidateScanner) scan(ctx context.Context, toBlock uint64) ([]common.Address, error) {
+	scanner.mutex.Lock()
+	defer scanner.mutex.Unlock()
+
+	if toBlock >= scanner.nextBlock {
+		query := ethereum.FilterQuery{
+			Addresses: []common.Address{gaterAddr},
+			Topics: [][]common.Hash{
+				{gater.ABI.Events["RoleGranted"].ID},
+				{scanner.role},
+			},
+		}
+		logs, err := filterLogsChunked(ctx, query, scanner.nextBlock, toBlock, scanner.chunkSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch RoleGranted logs: %w", err)
+		}
+		for _, vLog := range logs {
+			if vLog.Removed || len(vLog.Topics) < 3 {
+				continue
+			}
+			if account := common.BytesToAddress(vLog.Topics[2].Bytes()); !scanner.seen[account] {
+				scanner.seen[account] = true
+				scanner.candidates = append(scanner.candidates, account)
+			}
+		}
+		scanner.nextBlock = toBlock + 1
+	}
+	return append([]common.Address{}, scanner.candidates...), nil
+}
+
+// checkRoleMembers returns the candidates that hold a role at a block, without duplicates.
+func checkRoleMembers(ctx context.Context, role common.Hash, candidates []common.Address, block uint64) ([]common.Address, error) {
+	seen := map[common.Address]bool{}
+	unique := []common.Address{}
+	for _, account := range candidates {
+		if !seen[account] {
+			seen[account] = true
+			unique = append(unique, account)
+		}
+	}
+
+	isMember := make([]bool, len(unique))
+	calls := make([]*gater.Call, len(unique))
+	for i, account := range unique {
+		calls[i] = gater.NewCall(&isMember[i], "hasRole", role, account)
+	}
+	if err := gaterClient.At(new(big.Int).SetUint64(block)).Batch(ctx, calls); err != nil {
+		return nil, fmt.Errorf("failed to check role members: %w", err)
+	}
+
+	members := []common.Address{}
+	for i, account := range unique {
+		if isMember[i] {
+			members = append(members, account)
+		}
+	}
+	return members, nil
+}
@@ -3,14 +3,16 @@ package cmd
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -20,19 +22,34 @@ var (
 
 	// Global flags
 	privateKey      string
-	rpcHost         string
+	rpcHosts        []string
+	rpcTimeout      time.Duration
+	rpcRetries      int
+	commandTimeout  time.Duration
 	depositContract string
 	interactive     bool
 	verbose         bool
 	noColor         bool
+	profileName     string
+	configPath      string
+	gaterOverride   string
+	outputFormat    string
+	expectChainID   uint64
+	assumeYes       bool
+	auditLogPath    string
+	auditOperator   string
 
 	// Parsed values (set during PreRun)
-	ethClient     *ethclient.Client
+	ethClient     *rpcPool
+	gaterClient   *gater.Client
+	transactor    *gater.Transactor
 	signerKey     *ecdsa.PrivateKey
 	signerAddress common.Address
 	depositAddr   common.Address
 	gaterAddr     common.Address
 	chainID       *big.Int
+	activeProfile *networkProfile
+	cancelCommand context.CancelFunc
 )
 
 // Storage slot 0x41 is where depositGater address is stored in the deposit contract.
@@ -43,10 +60,8 @@ var (
 // - address depositGater: slot 65 (0x41)
 var gaterStorageSlot = common.HexToHash("0x41")
 
-// Role constants from TokenDepositGater.sol
-var (
-	DefaultAdminRole = common.HexToHash("0xacce55000000000000000000ffffffffffffffffffffffffffffffffffffffff")
-)
+// annotationSignerOptional marks commands (and their subcommands) that can run without a private key.
+const annotationSignerOptional = "signerOptional"
 
 var rootCmd = &cobra.Command{
 	Use:   "gating-cli [command]",
@@ -67,8 +82,19 @@ In interactive mode (-i), you can repeatedly select actions until you choose to
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&privateKey, "private-key", "k", "", "Private key for signing transactions (hex format)")
-	rootCmd.PersistentFlags().StringVarP(&rpcHost, "rpc", "r", "", "Ethereum RPC endpoint URL")
-	rootCmd.PersistentFlags().StringVarP(&depositContract, "deposit-contract", "d", "", "Deposit contract address (optional, uses mainnet default)")
+	rootCmd.PersistentFlags().StringSliceVarP(&rpcHosts, "rpc", "r", nil, "Ethereum RPC endpoint URL (HTTP, WebSocket or IPC); repeat or comma-separate for failover")
+	rootCmd.PersistentFlags().DurationVar(&rpcTimeout, "rpc-timeout", 30*time.Second, "Timeout of a single RPC call")
+	rootCmd.PersistentFlags().IntVar(&rpcRetries, "rpc-retries", 3, "Number of retries of failed RPC reads")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "Timeout of the whole command (e.g. 5m, default: none)")
+	rootCmd.PersistentFlags().StringVarP(&depositContract, "deposit-contract", "d", "", "Deposit contract address (optional, uses the default of the connected network)")
+	rootCmd.PersistentFlags().StringVar(&gaterOverride, "gater", "", "Gating contract address (optional, read from the deposit contract by default)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Named profile from the config file or a well-known network (mainnet, sepolia, holesky, hoodi)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to the config file (default: ~/.config/gating-cli/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "text", "Output format: text or json")
+	rootCmd.PersistentFlags().Uint64Var(&expectChainID, "expect-chain-id", 0, "Refuse to run if the RPC returns a different chain ID")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Send transactions without asking for confirmation")
+	rootCmd.PersistentFlags().StringVar(&auditLogPath, "audit-log", "", "Audit log of sent transactions (default: audit-<chain ID>.jsonl next to the config file)")
+	rootCmd.PersistentFlags().StringVar(&auditOperator, "operator", "", "Operator name recorded in the audit log (default: the OS user)")
 	rootCmd.PersistentFlags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for missing required values")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
@@ -78,29 +104,85 @@ func init() {
 	rootCmd.AddCommand(grantAdminCmd)
 	rootCmd.AddCommand(revokeAdminCmd)
 	rootCmd.AddCommand(setConfigCmd)
+	rootCmd.AddCommand(depositsCmd)
+	rootCmd.AddCommand(depositCmd)
+	rootCmd.AddCommand(checkDepositCmd)
+	rootCmd.AddCommand(devnetCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(driftCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(exporterCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(faucetCmd)
+	rootCmd.AddCommand(requestsCmd)
+	rootCmd.AddCommand(autorefillCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(fleetCmd)
 }
 
 // Execute runs the root command.
 func Execute() error {
-	return rootCmd.Execute()
+	return ExecuteContext(context.Background())
 }
 
-func persistentPreRun(cmd *cobra.Command, args []string) error {
-	// Disable colors if requested
-	if noColor {
-		disableColors()
+// ExecuteContext runs the root command with a context. Cancelling the context stops the command.
+func ExecuteContext(ctx context.Context) error {
+	defer func() {
+		if cancelCommand != nil {
+			cancelCommand()
+		}
+	}()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// exitCodeError is an error that makes the CLI exit with a specific exit code.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
 	}
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
 
-	// Configure logging
-	if verbose {
-		log.SetLevel(logrus.DebugLevel)
-	} else {
-		log.SetLevel(logrus.InfoLevel)
+// applyCommandTimeout applies --timeout to the context of the command and returns the context.
+func applyCommandTimeout(cmd *cobra.Command) context.Context {
+	ctx := cmd.Context()
+	if commandTimeout > 0 {
+		ctx, cancelCommand = context.WithTimeout(ctx, commandTimeout)
+		cmd.SetContext(ctx)
+	}
+	return ctx
+}
+
+func persistentPreRun(cmd *cobra.Command, args []string) error {
+	configureOutput()
+	auditCommand = cmd.CommandPath()
+
+	// Load the active profile
+	if err := loadActiveProfile(cmd); err != nil {
+		return err
 	}
-	log.SetFormatter(&logrus.TextFormatter{
-		DisableTimestamp: true,
-		DisableColors:    noColor,
-	})
 
 	// Gather required values (prompting if interactive mode is enabled)
 	var err error
@@ -109,31 +191,42 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	if privateKey == "" {
 		privateKey = os.Getenv("PRIVATE_KEY")
 	}
-	if privateKey == "" && interactive {
+	if privateKey == "" && activeProfile.Signer != "" {
+		if privateKey, err = loadProfileSigner(activeProfile.Signer); err != nil {
+			return err
+		}
+	}
+	needsSigner := signerRequired(cmd)
+	if privateKey == "" && interactive && needsSigner {
 		privateKey, err = promptPrivateKey("Private key (hex)")
 		if err != nil {
 			return fmt.Errorf("failed to read private key: %w", err)
 		}
 	}
-	if privateKey == "" {
+	if privateKey == "" && needsSigner {
 		return fmt.Errorf("private key is required (use --private-key, -k, or PRIVATE_KEY env var)")
 	}
 
 	// Parse private key
-	privateKey = strings.TrimPrefix(privateKey, "0x")
-	signerKey, err = crypto.HexToECDSA(privateKey)
-	if err != nil {
-		return fmt.Errorf("invalid private key: %w", err)
+	if privateKey != "" {
+		privateKey = strings.TrimPrefix(privateKey, "0x")
+		signerKey, err = crypto.HexToECDSA(privateKey)
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		signerAddress = crypto.PubkeyToAddress(signerKey.PublicKey)
+		log.WithField("address", signerAddress.Hex()).Debug("Loaded signer key")
 	}
-	signerAddress = crypto.PubkeyToAddress(signerKey.PublicKey)
-	log.WithField("address", signerAddress.Hex()).Debug("Loaded signer key")
 
-	// RPC host
-	if rpcHost == "" {
-		rpcHost = os.Getenv("ETH_RPC_URL")
+	// RPC hosts
+	if len(rpcHosts) == 0 {
+		rpcHosts = splitRPCHosts(os.Getenv("ETH_RPC_URL"))
+	}
+	if len(rpcHosts) == 0 {
+		rpcHosts = splitRPCHosts(activeProfile.RPC)
 	}
-	if rpcHost == "" && interactive {
-		rpcHost, err = promptText("RPC endpoint URL", "", func(s string) error {
+	if len(rpcHosts) == 0 && interactive {
+		input, err := promptText("RPC endpoint URL", "", func(s string) error {
 			if strings.TrimSpace(s) == "" {
 				return fmt.Errorf("RPC URL cannot be empty")
 			}
@@ -142,14 +235,16 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to read RPC URL: %w", err)
 		}
+		rpcHosts = splitRPCHosts(input)
 	}
-	if rpcHost == "" {
+	if len(rpcHosts) == 0 {
 		return fmt.Errorf("RPC endpoint is required (use --rpc, -r, or ETH_RPC_URL env var)")
 	}
 
+	ctx := applyCommandTimeout(cmd)
+
 	// Connect to Ethereum
-	ctx := context.Background()
-	ethClient, err = ethclient.DialContext(ctx, rpcHost)
+	ethClient, err = dialRPCPool(ctx, rpcHosts, rpcTimeout, rpcRetries)
 	if err != nil {
 		return fmt.Errorf("failed to connect to RPC: %w", err)
 	}
@@ -161,21 +256,37 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	}
 	log.WithField("chainID", chainID.String()).Debug("Connected to network")
 
+	// Refuse to run against the wrong chain
+	if expectChainID != 0 && chainID.Uint64() != expectChainID {
+		return fmt.Errorf("chain ID mismatch: RPC returned %s, but --expect-chain-id is %d", chainID.String(), expectChainID)
+	}
+	if expectChainID == 0 && activeProfile.ChainID != 0 && chainID.Uint64() != activeProfile.ChainID {
+		return fmt.Errorf("chain ID mismatch: RPC returned %s, but profile %q expects %d", chainID.String(), profileName, activeProfile.ChainID)
+	}
+
 	// Deposit contract address
 	if depositContract == "" {
 		depositContract = os.Getenv("DEPOSIT_CONTRACT")
 	}
+	if depositContract == "" {
+		depositContract = activeProfile.DepositContract
+	}
 	if depositContract == "" && interactive {
-		depositContract, err = promptText("Deposit contract address (empty for mainnet default)", "", nil)
+		depositContract, err = promptText("Deposit contract address (empty for network default)", "", nil)
 		if err != nil {
 			return fmt.Errorf("failed to read deposit contract: %w", err)
 		}
 	}
 
-	// Use default mainnet deposit contract if not specified
+	// Use the deposit contract of the connected network if not specified
 	if depositContract == "" {
-		depositContract = "0x00000000219ab540356cBB839Cbe05303d7705Fa"
-		log.Debug("Using default mainnet deposit contract")
+		depositContract = mainnetDepositContractAddress
+		if network := findNetworkByChainID(chainID.Uint64()); network != nil {
+			depositContract = network.DepositContract
+			log.WithField("network", network.Name).Debug("Using default deposit contract of network")
+		} else {
+			log.Debug("Using default mainnet deposit contract")
+		}
 	}
 
 	if !common.IsHexAddress(depositContract) {
@@ -191,15 +302,118 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	}
 	gaterAddr = common.BytesToAddress(gaterAddrBytes)
 
+	// Gater override
+	if gaterOverride == "" {
+		gaterOverride = activeProfile.Gater
+	}
+	if gaterOverride != "" {
+		if !common.IsHexAddress(gaterOverride) {
+			return fmt.Errorf("invalid gater address: %s", gaterOverride)
+		}
+		override := common.HexToAddress(gaterOverride)
+		if gaterAddr != (common.Address{}) && gaterAddr != override {
+			log.WithFields(logrus.Fields{
+				"configured": gaterAddr.Hex(),
+				"override":   override.Hex(),
+			}).Warn("Gater override differs from the gater configured on the deposit contract")
+		}
+		gaterAddr = override
+	}
+
 	if gaterAddr == (common.Address{}) {
 		log.Warn("No gating contract configured on this deposit contract")
 	} else {
 		log.WithField("address", gaterAddr.Hex()).Debug("Found gating contract")
 	}
 
+	// Gater client, sending transactions with the signer key
+	gaterClient = gater.NewClient(ethClient, gaterAddr)
+	if signerKey != nil {
+		transactor = newTransactor()
+		gaterClient = gaterClient.WithTransactor(transactor)
+	}
+
 	return nil
 }
 
+// configureOutput applies the color and logging flags.
+func configureOutput() {
+	// Disable colors if requested
+	if noColor {
+		disableColors()
+	}
+
+	// Configure logging
+	if verbose {
+		log.SetLevel(logrus.DebugLevel)
+	} else {
+		log.SetLevel(logrus.InfoLevel)
+	}
+	log.SetFormatter(&logrus.TextFormatter{
+		DisableTimestamp: true,
+		DisableColors:    noColor,
+	})
+}
+
+// loadActiveProfile loads the profile selected with --profile (or the current profile of the
+// config file) and applies its output format. Without a profile, an empty profile is used.
+func loadActiveProfile(cmd *cobra.Command) error {
+	activeProfile = &networkProfile{}
+
+	path, err := resolveConfigPath()
+	if err != nil {
+		return err
+	}
+	config, err := loadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if profileName == "" {
+		profileName = os.Getenv("GATING_CLI_PROFILE")
+	}
+	if profileName == "" {
+		profileName = config.CurrentProfile
+	}
+	if profileName != "" {
+		profile := config.findProfile(profileName)
+		if profile == nil {
+			return fmt.Errorf("unknown profile %q (see 'gating-cli profile list')", profileName)
+		}
+		if err := profile.validate(); err != nil {
+			return fmt.Errorf("invalid profile %q: %w", profileName, err)
+		}
+		activeProfile = profile
+		log.WithField("profile", profileName).Debug("Using profile")
+	}
+
+	if !cmd.Flags().Changed("output") && activeProfile.Output != "" {
+		outputFormat = activeProfile.Output
+	}
+	return validateOutputFormat(outputFormat)
+}
+
+// splitRPCHosts splits a comma-separated list of RPC URLs.
+func splitRPCHosts(value string) []string {
+	hosts := []string{}
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// signerRequired checks if a command needs a private key to run.
+func signerRequired(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[annotationSignerOptional] == "true" {
+			return false
+		}
+	}
+	return true
+}
+
 // runRoot handles the root command - shows status and optionally enters interactive loop.
 func runRoot(cmd *cobra.Command, args []string) error {
 	// Always show status first
@@ -258,6 +472,15 @@ func runInteractiveLoop(cmd *cobra.Command) error {
 				return runSetConfig(cmd, nil)
 			},
 		},
+		{
+			Name:        "Undo",
+			Description: "Revert the last configuration change",
+			Run: func() error {
+				resetCommandFlags()
+				configRevert = true
+				return runSetConfig(cmd, nil)
+			},
+		},
 		{
 			Name:        "Exit",
 			Description: "Exit the CLI",
@@ -291,4 +514,5 @@ func resetCommandFlags() {
 	configPrefix = ""
 	configBlocked = ""
 	configNoToken = ""
+	configRevert = false
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGlobalFlagErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(nil, "status")
+	assertError(t, err, "private key is required")
+
+	_, err = env.run(env.chain.AdminKey, "status", "--private-key", "0xnothex")
+	assertError(t, err, "invalid private key")
+
+	_, err = env.run(env.chain.AdminKey, "status", "--expect-chain-id", "1")
+	assertError(t, err, "chain ID mismatch")
+
+	_, err = env.run(env.chain.AdminKey, "status", "--deposit-contract", "0x1234")
+	assertError(t, err, "invalid deposit contract address")
+}
+
+func TestRPCFailover(t *testing.T) {
+	env := newTestEnv(t)
+
+	// The first endpoint is unreachable, reads fail over to the simulated chain
+	out := env.mustRun(env.chain.AdminKey, "status", "--rpc", "http://127.0.0.1:1,"+env.chain.IPCPath)
+	assertContains(t, out, "Signer is Admin:   Yes")
+}
+
+func TestNoGater(t *testing.T) {
+	env := newTestEnv(t)
+
+	// An address without gater in storage slot 0x41
+	noGater := common.HexToAddress("0x000000000000000000000000000000000000dead").Hex()
+	_, err := env.run(env.chain.AdminKey, "mint", "1", "--deposit-contract", noGater, "--yes")
+	assertError(t, err, "no gating contract configured")
+}
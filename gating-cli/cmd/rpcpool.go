@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// rpcInitialBackoff is the delay before the first retry of a failed read.
+	rpcInitialBackoff = 250 * time.Millisecond
+	// rpcMaxBackoff caps the exponential backoff between retries.
+	rpcMaxBackoff = 5 * time.Second
+	// rpcUnhealthyCooldown is how long a failed endpoint is skipped before it is tried again.
+	rpcUnhealthyCooldown = 30 * time.Second
+)
+
+// rpcEndpoint is a single RPC endpoint of a pool.
+type rpcEndpoint struct {
+	url            string
+	client         *ethclient.Client
+	unhealthyUntil time.Time
+}
+
+// rpcPool is a set of RPC endpoints for the same chain with failover.
+//
+// Calls go to the first healthy endpoint in the configured order. Endpoints that fail
+// with a transport error (connection errors, timeouts, HTTP 429/5xx) are skipped for a
+// cooldown period. Reads are retried with exponential backoff on the next endpoint;
+// errors returned by the node itself (e.g. execution reverted) are never retried.
+// Pending nonces are read from all healthy endpoints (see PendingNonceAt).
+type rpcPool struct {
+	mu          sync.Mutex
+	endpoints   []*rpcEndpoint
+	chainID     *big.Int
+	callTimeout time.Duration
+	retries     int
+}
+
+// dialRPCPool connects to all endpoints (HTTP, WebSocket or IPC), checks their health and
+// verifies that all healthy endpoints serve the same chain.
+func dialRPCPool(ctx context.Context, urls []string, callTimeout time.Duration, retries int) (*rpcPool, error) {
+	pool := &rpcPool{
+		callTimeout: callTimeout,
+		retries:     retries,
+	}
+
+	var failures []string
+	for _, url := range urls {
+		dialCtx, cancel := pool.callContext(ctx)
+		client, err := ethclient.DialContext(dialCtx, url)
+		if err != nil {
+			cancel()
+			log.WithError(err).WithField("rpc", url).Warn("Failed to connect to RPC endpoint")
+			failures = append(failures, fmt.Sprintf("%s: %v", url, err))
+			continue
+		}
+
+		// Health check
+		endpointChainID, err := client.ChainID(dialCtx)
+		cancel()
+		if err != nil {
+			client.Close()
+			log.WithError(err).WithField("rpc", url).Warn("RPC endpoint is not healthy")
+			failures = append(failures, fmt.Sprintf("%s: %v", url, err))
+			continue
+		}
+
+		if pool.chainID == nil {
+			pool.chainID = endpointChainID
+		} else if pool.chainID.Cmp(endpointChainID) != 0 {
+			pool.Close()
+			client.Close()
+			return nil, fmt.Errorf("RPC endpoints disagree on chain ID: %s returned %s, %s returned %s", pool.endpoints[0].url, pool.chainID.String(), url, endpointChainID.String())
+		}
+		pool.endpoints = append(pool.endpoints, &rpcEndpoint{url: url, client: client})
+		log.WithFields(map[string]interface{}{
+			"rpc":     url,
+			"chainID": endpointChainID.String(),
+		}).Debug("Connected to RPC endpoint")
+	}
+
+	if len(pool.endpoints) == 0 {
+		return nil, fmt.Errorf("no healthy RPC endpoint: %s", strings.Join(failures, "; "))
+	}
+	return pool, nil
+}
+
+// Close closes all endpoint connections.
+func (pool *rpcPool) Close() {
+	for _, endpoint := range pool.endpoints {
+		endpoint.client.Close()
+	}
+}
+
+// callContext derives the context for a single call, applying the per-call timeout.
+func (pool *rpcPool) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if pool.callTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, pool.callTimeout)
+}
+
+// pick returns the first healthy endpoint, or the one that becomes healthy again first.
+// The second return value reports if the returned endpoint is healthy.
+func (pool *rpcPool) pick() (*rpcEndpoint, bool) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	now := time.Now()
+	best := pool.endpoints[0]
+	for _, endpoint := range pool.endpoints {
+		if endpoint.unhealthyUntil.Before(now) {
+			return endpoint, true
+		}
+		if endpoint.unhealthyUntil.Before(best.unhealthyUntil) {
+			best = endpoint
+		}
+	}
+	return best, false
+}
+
+// ordered returns the healthy endpoints in the configured order, followed by the unhealthy
+// endpoints in the order they become healthy again.
+func (pool *rpcPool) ordered() (healthy []*rpcEndpoint, unhealthy []*rpcEndpoint) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	now := time.Now()
+	for _, endpoint := range pool.endpoints {
+		if endpoint.unhealthyUntil.Before(now) {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool { return unhealthy[i].unhealthyUntil.Before(unhealthy[j].unhealthyUntil) })
+	return healthy, unhealthy
+}
+
+// markUnhealthy skips an endpoint for the cooldown period.
+func (pool *rpcPool) markUnhealthy(endpoint *rpcEndpoint) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	endpoint.unhealthyUntil = time.Now().Add(rpcUnhealthyCooldown)
+}
+
+// markHealthy clears the cooldown of an endpoint after a successful call.
+func (pool *rpcPool) markHealthy(endpoint *rpcEndpoint) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	endpoint.unhealthyUntil = time.Time{}
+}
+
+// do runs a call with failover and exponential backoff retries on transport errors.
+// Only idempotent calls may be run through do.
+func (pool *rpcPool) do(ctx context.Context, method string, call func(ctx context.Context, client *ethclient.Client) error) error {
+	backoff := rpcInitialBackoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		endpoint, _ := pool.pick()
+		callCtx, cancel := pool.callContext(ctx)
+		err = call(callCtx, endpoint.client)
+		cancel()
+		if err == nil {
+			pool.markHealthy(endpoint)
+			return nil
+		}
+		if ctx.Err() != nil || !isRetryableRPCError(err) {
+			return err
+		}
+
+		pool.markUnhealthy(endpoint)
+		if attempt >= pool.retries {
+			return fmt.Errorf("%s failed after %d attempt(s): %w", method, attempt+1, err)
+		}
+
+		// Fail over to the next healthy endpoint right away, back off if there is none
+		if next, healthy := pool.pick(); healthy {
+			log.WithError(err).WithFields(map[string]interface{}{
+				"rpc":    endpoint.url,
+				"method": method,
+				"next":   next.url,
+			}).Warn("RPC call failed, failing over")
+			continue
+		}
+		log.WithError(err).WithFields(map[string]interface{}{
+			"rpc":     endpoint.url,
+			"method":  method,
+			"attempt": attempt + 1,
+			"backoff": backoff.String(),
+		}).Warn("RPC call failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > rpcMaxBackoff {
+			backoff = rpcMaxBackoff
+		}
+	}
+}
+
+// isRetryableRPCError checks if an error is a transport error worth retrying on another endpoint.
+// Errors returned by the node itself (reverts, invalid params, ...) are not retryable.
+func isRetryableRPCError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, rpc.ErrClientQuit) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// -32005 is the common "limit exceeded" / rate limit error code
+		return rpcErr.ErrorCode() == -32005
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// ChainID returns the chain ID all endpoints agreed on.
+func (pool *rpcPool) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(pool.chainID), nil
+}
+
+// HeaderByNumber returns a block header (nil for the latest block).
+func (pool *rpcPool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := pool.do(ctx, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// CallContract executes an eth_call.
+func (pool *rpcPool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := pool.do(ctx, "eth_call", func(ctx context.Context, client *ethclient.Client) (err error) {
+		result, err = client.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// CallContractWithOverrides executes an eth_call with state overrides.
+func (pool *rpcPool) CallContractWithOverrides(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, overrides *map[common.Address]gethclient.OverrideAccount) ([]byte, error) {
+	var result []byte
+	err := pool.do(ctx, "eth_call", func(ctx context.Context, client *ethclient.Client) (err error) {
+		result, err = gethclient.New(client.Client()).CallContract(ctx, msg, blockNumber, overrides)
+		return err
+	})
+	return result, err
+}
+
+// BatchCallContext sends several requests in a single JSON-RPC batch.
+// Errors of individual requests are reported in the Error field of the elements.
+func (pool *rpcPool) BatchCallContext(ctx context.Context, elems []rpc.BatchElem) error {
+	return pool.do(ctx, "batch", func(ctx context.Context, client *ethclient.Client) error {
+		for i := range elems {
+			elems[i].Error = nil
+		}
+		return client.Client().BatchCallContext(ctx, elems)
+	})
+}
+
+// FilterLogs executes an eth_getLogs query.
+func (pool *rpcPool) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := pool.do(ctx, "eth_getLogs", func(ctx context.Context, client *ethclient.Client) (err error) {
+		logs, err = client.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// StorageAt returns a storage slot of an account.
+func (pool *rpcPool) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var value []byte
+	err := pool.do(ctx, "eth_getStorageAt", func(ctx context.Context, client *ethclient.Client) (err error) {
+		value, err = client.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return value, err
+}
+
+// BalanceAt returns the ETH balance of an account.
+func (pool *rpcPool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := pool.do(ctx, "eth_getBalance", func(ctx context.Context, client *ethclient.Client) (err error) {
+		balance, err = client.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+// CodeAt returns the code of an account.
+func (pool *rpcPool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := pool.do(ctx, "eth_getCode", func(ctx context.Context, client *ethclient.Client) (err error) {
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// TransactionReceipt returns the receipt of a mined transaction.
+func (pool *rpcPool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := pool.do(ctx, "eth_getTransactionReceipt", func(ctx context.Context, client *ethclient.Client) (err error) {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// PendingNonceAt returns the pending nonce of an account: the highest pending nonce of all
+// healthy endpoints, so an endpoint that lags behind (or hasn't seen a transaction sent to
+// another endpoint yet) doesn't cause a nonce to be reused.
+func (pool *rpcPool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	answered := false
+	healthy, _ := pool.ordered()
+	for _, endpoint := range healthy {
+		callCtx, cancel := pool.callContext(ctx)
+		endpointNonce, err := endpoint.client.PendingNonceAt(callCtx, account)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			if !isRetryableRPCError(err) {
+				return 0, err
+			}
+			log.WithError(err).WithField("rpc", endpoint.url).Warn("Failed to get pending nonce")
+			pool.markUnhealthy(endpoint)
+			continue
+		}
+		pool.markHealthy(endpoint)
+		answered = true
+		nonce = max(nonce, endpointNonce)
+	}
+	if answered {
+		return nonce, nil
+	}
+
+	// No healthy endpoint answered, retry with backoff
+	err := pool.do(ctx, "eth_getTransactionCount", func(ctx context.Context, client *ethclient.Client) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// SuggestGasPrice returns the suggested gas price.
+func (pool *rpcPool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var gasPrice *big.Int
+	err := pool.do(ctx, "eth_gasPrice", func(ctx context.Context, client *ethclient.Client) (err error) {
+		gasPrice, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return gasPrice, err
+}
+
+// EstimateGas estimates the gas needed for a transaction.
+func (pool *rpcPool) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := pool.do(ctx, "eth_estimateGas", func(ctx context.Context, client *ethclient.Client) (err error) {
+		gas, err = client.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction submits a signed transaction.
+// Resubmitting the same signed transaction to another endpoint is safe, so it is retried like
+// a read. If an endpoint rejects the transaction (e.g. as already known, or because its nonce
+// was used by the transaction itself after an earlier attempt timed out), the endpoint is asked
+// for the transaction, and the send counts as successful if the endpoint knows it.
+func (pool *rpcPool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return pool.do(ctx, "eth_sendRawTransaction", func(ctx context.Context, client *ethclient.Client) error {
+		err := client.SendTransaction(ctx, tx)
+		if err == nil || isRetryableRPCError(err) {
+			return err
+		}
+		if _, _, lookupErr := client.TransactionByHash(ctx, tx.Hash()); lookupErr == nil {
+			log.WithError(err).WithField("txHash", tx.Hash().Hex()).Debug("Transaction is already known")
+			return nil
+		}
+		return err
+	})
+}
+
+// PendingCodeAt returns the code of an account in the pending state.
+func (pool *rpcPool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := pool.do(ctx, "eth_getCode", func(ctx context.Context, client *ethclient.Client) (err error) {
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// SuggestGasTipCap returns the suggested priority fee.
+func (pool *rpcPool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tipCap *big.Int
+	err := pool.do(ctx, "eth_maxPriorityFeePerGas", func(ctx context.Context, client *ethclient.Client) (err error) {
+		tipCap, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tipCap, err
+}
+
+// SubscribeFilterLogs subscribes to logs on the first endpoint that supports subscriptions
+// (WebSocket or IPC). A failed subscription is not moved to another endpoint, the caller has
+// to subscribe again once the subscription's Err channel reports the failure.
+func (pool *rpcPool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return pool.subscribe(ctx, "eth_subscribe", func(ctx context.Context, client *ethclient.Client) (ethereum.Subscription, error) {
+		return client.SubscribeFilterLogs(ctx, query, ch)
+	})
+}
+
+// SubscribeNewHead subscribes to new block headers on the first endpoint that supports
+// subscriptions (WebSocket or IPC), see SubscribeFilterLogs.
+func (pool *rpcPool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return pool.subscribe(ctx, "eth_subscribe", func(ctx context.Context, client *ethclient.Client) (ethereum.Subscription, error) {
+		return client.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// subscribe tries a subscription on each endpoint, healthy endpoints first, until one succeeds.
+// Endpoints failing with a transport error are marked unhealthy; endpoints without subscription
+// support (HTTP) are skipped. The error of the last endpoint is returned if none succeeds.
+func (pool *rpcPool) subscribe(ctx context.Context, method string, call func(ctx context.Context, client *ethclient.Client) (ethereum.Subscription, error)) (ethereum.Subscription, error) {
+	healthy, unhealthy := pool.ordered()
+	var err error
+	for _, endpoint := range append(healthy, unhealthy...) {
+		var sub ethereum.Subscription
+		sub, err = call(ctx, endpoint.client)
+		if err == nil {
+			return sub, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if isRetryableRPCError(err) {
+			log.WithError(err).WithFields(map[string]interface{}{
+				"rpc":    endpoint.url,
+				"method": method,
+			}).Warn("RPC subscription failed, failing over")
+			pool.markUnhealthy(endpoint)
+		}
+	}
+	return nil, err
+}
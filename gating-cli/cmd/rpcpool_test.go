@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestRPCPoolPendingNonce(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "1", "--to", env.chain.User.Hex(), "--yes")
+	lagging := gatertest.NewChain(t)
+
+	expected, err := env.chain.Client.PendingNonceAt(t.Context(), env.chain.Admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first endpoint doesn't know the transactions of the admin, the nonce of the second is used
+	pool, err := dialRPCPool(t.Context(), []string{lagging.IPCPath, env.chain.IPCPath}, 5*time.Second, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	nonce, err := pool.PendingNonceAt(t.Context(), env.chain.Admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != expected || nonce == 0 {
+		t.Errorf("expected nonce %d, got %d", expected, nonce)
+	}
+}
+
+func TestRPCPoolSendTransaction(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	pool, err := dialRPCPool(t.Context(), []string{chain.IPCPath}, 5*time.Second, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+
+	nonce, err := pool.PendingNonceAt(t.Context(), chain.Admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(value int64) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, chain.User, big.NewInt(value), 21000, big.NewInt(1e10), nil), types.NewEIP155Signer(gatertest.ChainID), chain.AdminKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	// Resubmitting a known transaction succeeds, also after it was mined
+	tx := sign(1)
+	for range 2 {
+		if err := pool.SendTransaction(t.Context(), tx); err != nil {
+			t.Fatalf("failed to send transaction: %v", err)
+		}
+	}
+	chain.Backend.Commit()
+	if err := pool.SendTransaction(t.Context(), tx); err != nil {
+		t.Fatalf("failed to resubmit mined transaction: %v", err)
+	}
+
+	// Another transaction with the same nonce is rejected
+	if err := pool.SendTransaction(t.Context(), sign(2)); err == nil {
+		t.Fatal("expected a transaction reusing a nonce to be rejected")
+	}
+}
@@ -0,0 +1,674 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleStorePath      string
+	scheduleAt             string
+	schedulePrefix         string
+	scheduleBlocked        string
+	scheduleNoToken        string
+	scheduleGenesisTime    uint64
+	scheduleBeaconAPI      string
+	scheduleSecondsPerSlot uint64
+	scheduleStatus         string
+	scheduleInterval       time.Duration
+	scheduleOnce           bool
+)
+
+// slotsPerEpoch is the number of slots per beacon chain epoch.
+const slotsPerEpoch = 32
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Scheduled configuration changes",
+	Long: `Schedules deposit type configuration changes for a planned time, block or epoch
+(e.g. unblocking 0x02 compounding deposits at a fork).
+
+Jobs are added with 'schedule add' and executed by the 'schedule run' daemon once
+they are due. Each job is sent as a normal setDepositGateConfig transaction, and
+the config is read back at the block of the transaction to verify the result.
+The outcome (transaction hash, verified config or error) is recorded with the job.
+
+Jobs are kept in a local store file (default: schedule-<chain ID>.json next to
+the config file), so all commands must use the same --store.`,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add setConfig",
+	Short: "Schedule a configuration change",
+	Long: `Schedules a deposit type configuration change:
+
+  gating-cli schedule add --at epoch:364032 setConfig --prefix 0x02 --blocked false
+
+Triggers (--at):
+  2026-11-05T14:00:00Z   a time (RFC 3339)
+  block:<number>         a block number; the job runs once the block is reached
+  epoch:<number>         the start of a beacon chain epoch
+
+Epoch start times are computed from the beacon chain genesis time, taken from
+--genesis-time, the beacon node given by --beacon-api or the well-known network
+matching the chain ID, in that order.
+
+Values that are not given keep their value at the time the job runs.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runScheduleAdd,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List scheduled jobs",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runScheduleList,
+}
+
+var scheduleCancelCmd = &cobra.Command{
+	Use:         "cancel <id>...",
+	Short:       "Cancel pending jobs",
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runScheduleCancel,
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Execute due jobs (admin only)",
+	Long: `Checks the job store every --interval and executes the jobs that are due, oldest
+first. Time and epoch triggers are compared with the local clock, block triggers
+with the latest block.
+
+A job is marked as running before its transaction is sent, and as done or
+failed once the result is verified. If the transaction was sent but not
+confirmed (e.g. a timeout), the job is marked as unconfirmed with the
+transaction hash and its receipt is checked on the next run, so the change is
+never sent twice. A job left running (e.g. after a crash) is skipped; check the
+config of its deposit type and cancel or re-add it.
+
+The daemon asks for confirmation once on startup (or use --yes).`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleRun,
+}
+
+func init() {
+	scheduleCmd.PersistentFlags().StringVar(&scheduleStorePath, "store", "", "Job store file (default: schedule-<chain ID>.json next to the config file)")
+
+	scheduleAddCmd.Flags().StringVar(&scheduleAt, "at", "", "When to run the job: RFC 3339 time, block:<number> or epoch:<number> (required)")
+	scheduleAddCmd.Flags().StringVarP(&schedulePrefix, "prefix", "p", "", "Deposit type prefix (e.g., 0x00, 0x01, 0x02, 0xffff)")
+	scheduleAddCmd.Flags().StringVarP(&scheduleBlocked, "blocked", "b", "", "Block deposits of this type (true/false)")
+	scheduleAddCmd.Flags().StringVarP(&scheduleNoToken, "no-token", "n", "", "Allow deposits without token (true/false)")
+	scheduleAddCmd.Flags().Uint64Var(&scheduleGenesisTime, "genesis-time", 0, "Beacon chain genesis time (unix seconds) for epoch triggers")
+	scheduleAddCmd.Flags().StringVar(&scheduleBeaconAPI, "beacon-api", "", "Beacon node API URL to fetch the genesis time from")
+	scheduleAddCmd.Flags().Uint64Var(&scheduleSecondsPerSlot, "seconds-per-slot", 12, "Slot duration for epoch triggers")
+	scheduleListCmd.Flags().StringVar(&scheduleStatus, "status", "", "Only list jobs with this status (pending, running, unconfirmed, done, failed, cancelled)")
+	scheduleRunCmd.Flags().DurationVar(&scheduleInterval, "interval", 12*time.Second, "Interval between checks for due jobs")
+	scheduleRunCmd.Flags().BoolVar(&scheduleOnce, "once", false, "Execute the due jobs once and exit")
+
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleCancelCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+}
+
+// resolveScheduleStorePath returns the store given by --store or the default store of the network.
+func resolveScheduleStorePath() (string, error) {
+	if scheduleStorePath != "" {
+		return scheduleStorePath, nil
+	}
+	return chainDataPath("schedule", "json")
+}
+
+func runScheduleAdd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if args[0] != scheduleOperationSetConfig {
+		return fmt.Errorf("unsupported operation %q (only %s can be scheduled)", args[0], scheduleOperationSetConfig)
+	}
+	if schedulePrefix == "" {
+		return fmt.Errorf("prefix is required (use --prefix)")
+	}
+	depositType, err := parseDepositType(schedulePrefix)
+	if err != nil {
+		return err
+	}
+
+	job := &scheduledJob{
+		Operation:   scheduleOperationSetConfig,
+		DepositType: depositType,
+	}
+	if scheduleBlocked != "" {
+		blocked, err := parseBool(scheduleBlocked)
+		if err != nil {
+			return fmt.Errorf("invalid blocked value: %w", err)
+		}
+		job.Blocked = &blocked
+	}
+	if scheduleNoToken != "" {
+		noToken, err := parseBool(scheduleNoToken)
+		if err != nil {
+			return fmt.Errorf("invalid no-token value: %w", err)
+		}
+		job.NoToken = &noToken
+	}
+	if job.Blocked == nil && job.NoToken == nil {
+		return fmt.Errorf("nothing to change (use --blocked and/or --no-token)")
+	}
+	if signerKey != nil {
+		job.CreatedBy = signerAddress.Hex()
+	}
+
+	if err := parseScheduleTrigger(ctx, scheduleAt, job); err != nil {
+		return err
+	}
+
+	storePath, err := resolveScheduleStorePath()
+	if err != nil {
+		return err
+	}
+	if err := scheduleStoreFile.update(storePath, func(store *scheduleStore) error {
+		store.add(job)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printJSON(job)
+	}
+	printSuccess("Scheduled job #%d: %s at %s", job.ID, describeScheduledChange(job), describeScheduleTrigger(job))
+	return nil
+}
+
+// parseScheduleTrigger parses the --at value into the trigger of a job. Triggers in the
+// past are refused.
+func parseScheduleTrigger(ctx context.Context, at string, job *scheduledJob) error {
+	at = strings.TrimSpace(at)
+	if at == "" {
+		return fmt.Errorf("trigger is required (use --at)")
+	}
+	job.At = at
+
+	kind, value, found := strings.Cut(at, ":")
+	switch {
+	case found && kind == "block":
+		block, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid block trigger: %s", at)
+		}
+		latest, err := getLatestBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %w", err)
+		}
+		if block <= latest.Uint64() {
+			return fmt.Errorf("block %d has already been reached (latest block: %s)", block, latest.String())
+		}
+		job.Block = block
+
+	case found && kind == "epoch":
+		epoch, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid epoch trigger: %s", at)
+		}
+		genesisTime, err := resolveGenesisTime(ctx)
+		if err != nil {
+			return err
+		}
+		start := time.Unix(int64(genesisTime+epoch*slotsPerEpoch*scheduleSecondsPerSlot), 0).UTC()
+		if !start.After(time.Now()) {
+			return fmt.Errorf("epoch %d has already started (%s)", epoch, start.Format(time.RFC3339))
+		}
+		job.NotBefore = &start
+
+	default:
+		notBefore, err := time.Parse(time.RFC3339, strings.TrimPrefix(at, "time:"))
+		if err != nil {
+			return fmt.Errorf("invalid trigger %q (use an RFC 3339 time, block:<number> or epoch:<number>)", at)
+		}
+		notBefore = notBefore.UTC()
+		if !notBefore.After(time.Now()) {
+			return fmt.Errorf("time %s is in the past", notBefore.Format(time.RFC3339))
+		}
+		job.NotBefore = &notBefore
+	}
+	return nil
+}
+
+// resolveGenesisTime determines the beacon chain genesis time of the connected chain.
+func resolveGenesisTime(ctx context.Context) (uint64, error) {
+	if scheduleGenesisTime != 0 {
+		return scheduleGenesisTime, nil
+	}
+	if scheduleBeaconAPI != "" {
+		genesis, err := fetchBeaconGenesis(ctx, scheduleBeaconAPI)
+		if err != nil {
+			return 0, err
+		}
+		return genesis.GenesisTime, nil
+	}
+	if network := findNetworkByChainID(chainID.Uint64()); network != nil && network.GenesisTime != 0 {
+		return network.GenesisTime, nil
+	}
+	return 0, fmt.Errorf("unknown genesis time for chain ID %s (use --genesis-time or --beacon-api)", chainID.String())
+}
+
+// describeScheduledChange returns the change of a job, e.g. "setConfig 0x0002 blocked=false".
+func describeScheduledChange(job *scheduledJob) string {
+	parts := []string{fmt.Sprintf("%s 0x%04x", job.Operation, job.DepositType)}
+	if job.Blocked != nil {
+		parts = append(parts, fmt.Sprintf("blocked=%v", *job.Blocked))
+	}
+	if job.NoToken != nil {
+		parts = append(parts, fmt.Sprintf("noToken=%v", *job.NoToken))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeScheduleTrigger returns the trigger of a job, with the start time of epoch triggers.
+func describeScheduleTrigger(job *scheduledJob) string {
+	if strings.HasPrefix(job.At, "epoch:") && job.NotBefore != nil {
+		return fmt.Sprintf("%s (%s)", job.At, job.NotBefore.Format(time.RFC3339))
+	}
+	return job.At
+}
+
+// isDue checks if a job is due at the given time and latest block.
+func (job *scheduledJob) isDue(now time.Time, latestBlock uint64) bool {
+	if job.NotBefore != nil {
+		return !now.Before(*job.NotBefore)
+	}
+	return latestBlock >= job.Block
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	storePath, err := resolveScheduleStorePath()
+	if err != nil {
+		return err
+	}
+	store, err := scheduleStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+
+	jobs := []*scheduledJob{}
+	for _, job := range store.Jobs {
+		if scheduleStatus == "" || job.Status == scheduleStatus {
+			jobs = append(jobs, job)
+		}
+	}
+
+	if outputFormat == "json" {
+		return printJSON(jobs)
+	}
+
+	printHeader("═══ Scheduled Jobs ═══")
+	fmt.Println()
+	fmt.Printf("%sStore:%s %s\n", colorCyan, colorReset, storePath)
+	fmt.Println()
+	if len(jobs) == 0 {
+		printInfo("No jobs.")
+		return nil
+	}
+	for _, job := range jobs {
+		printScheduledJob(job)
+		fmt.Println()
+	}
+	return nil
+}
+
+// printScheduledJob prints a job with its outcome.
+func printScheduledJob(job *scheduledJob) {
+	fmt.Printf("%s#%d%s %s\n", colorBold, job.ID, colorReset, formatScheduleJobStatus(job.Status))
+	printValue := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s%-10s%s %s\n", colorCyan, label+":", colorReset, value)
+		}
+	}
+	printValue("Change", describeScheduledChange(job))
+	printValue("At", describeScheduleTrigger(job))
+	printValue("Created", fmt.Sprintf("%s %s", job.CreatedAt.Format(time.RFC3339), job.CreatedBy))
+	if job.ExecutedAt != nil {
+		printValue("Executed", job.ExecutedAt.Format(time.RFC3339))
+	}
+	printValue("Tx", job.TxHash)
+	if job.Result != nil {
+		printValue("Result", fmt.Sprintf("blocked=%v noToken=%v", job.Result.Blocked, job.Result.NoToken))
+	}
+	printValue("Error", job.Error)
+}
+
+// formatScheduleJobStatus returns a colored job status.
+func formatScheduleJobStatus(status string) string {
+	switch status {
+	case scheduleJobDone:
+		return colorGreen + status + colorReset
+	case scheduleJobFailed, scheduleJobCancelled:
+		return colorRed + status + colorReset
+	default:
+		return colorYellow + status + colorReset
+	}
+}
+
+func runScheduleCancel(cmd *cobra.Command, args []string) error {
+	ids, err := parseRequestIDs(args)
+	if err != nil {
+		return err
+	}
+	storePath, err := resolveScheduleStorePath()
+	if err != nil {
+		return err
+	}
+
+	err = scheduleStoreFile.update(storePath, func(store *scheduleStore) error {
+		jobs := make([]*scheduledJob, 0, len(ids))
+		for _, id := range ids {
+			job, err := store.find(id)
+			if err != nil {
+				return err
+			}
+			if job.Status != scheduleJobPending {
+				return fmt.Errorf("job #%d is %s and can't be cancelled", id, job.Status)
+			}
+			jobs = append(jobs, job)
+		}
+		for _, job := range jobs {
+			job.Status = scheduleJobCancelled
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		printSuccess("Job #%d cancelled", id)
+	}
+	return nil
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if err := checkAdminRole(ctx); err != nil {
+		return err
+	}
+	storePath, err := resolveScheduleStorePath()
+	if err != nil {
+		return err
+	}
+
+	// The daemon sends the jobs without asking, so confirm once before starting
+	if !assumeYes {
+		fmt.Println()
+		printHeader("═══ Confirm Scheduled Jobs ═══")
+		printTransactionContext()
+		fmt.Printf("%sJob Store:%s         %s\n", colorCyan, colorReset, storePath)
+		fmt.Println()
+	}
+	confirmed, err := requireConfirmation(fmt.Sprintf("Execute scheduled jobs on %s", networkLabel()))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("scheduled jobs not confirmed")
+	}
+	batchConfirmed = true
+	defer endTransactionBatch()
+
+	if scheduleOnce {
+		return runDueJobs(ctx, storePath)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.WithFields(map[string]interface{}{
+		"store":    storePath,
+		"interval": scheduleInterval.String(),
+	}).Info("Waiting for scheduled jobs")
+
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	for {
+		if err := runDueJobs(ctx, storePath); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.WithError(err).Warn("Failed to run scheduled jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueJobs checks the receipts of unconfirmed jobs and executes all pending jobs that are
+// due, oldest first.
+func runDueJobs(ctx context.Context, storePath string) error {
+	if err := reconcileUnconfirmedJobs(ctx, storePath); err != nil {
+		return err
+	}
+
+	latest, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	store, err := scheduleStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	due := []*scheduledJob{}
+	for _, job := range store.Jobs {
+		if job.Status == scheduleJobPending && job.isDue(now, latest.Uint64()) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+
+	for _, pending := range due {
+		if err := runScheduledJob(ctx, storePath, pending.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runScheduledJob executes a job and records its outcome. It only fails if the job store
+// can't be updated; a failed job is recorded as failed.
+func runScheduledJob(ctx context.Context, storePath string, id uint64) error {
+	// Reserve the job, so a concurrent daemon doesn't send it again
+	var job *scheduledJob
+	err := scheduleStoreFile.update(storePath, func(store *scheduleStore) error {
+		var err error
+		if job, err = store.find(id); err != nil {
+			return err
+		}
+		if job.Status != scheduleJobPending {
+			return fmt.Errorf("job #%d is %s now", job.ID, job.Status)
+		}
+		job.Status = scheduleJobRunning
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"id":     job.ID,
+		"change": describeScheduledChange(job),
+		"at":     job.At,
+	}).Info("Running scheduled job")
+
+	txHash, result, jobErr := executeScheduledJob(ctx, job)
+
+	err = scheduleStoreFile.update(storePath, func(store *scheduleStore) error {
+		stored, err := store.find(job.ID)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		stored.ExecutedAt = &now
+		stored.TxHash = txHash
+		stored.Result = result
+		var unconfirmed *gater.UnconfirmedError
+		if errors.As(jobErr, &unconfirmed) {
+			// The transaction may still be included, its receipt is checked on the next run
+			stored.Status = scheduleJobUnconfirmed
+			stored.TxHash = unconfirmed.Tx.Hash().Hex()
+			stored.Error = jobErr.Error()
+		} else if jobErr != nil {
+			stored.Status = scheduleJobFailed
+			stored.Error = jobErr.Error()
+		} else {
+			stored.Status = scheduleJobDone
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("job #%d was executed, but recording it failed: %w", job.ID, err)
+	}
+
+	var unconfirmed *gater.UnconfirmedError
+	if errors.As(jobErr, &unconfirmed) {
+		printError("Job #%d was sent, but not confirmed: %v", job.ID, jobErr)
+	} else if jobErr != nil {
+		printError("Job #%d failed: %v", job.ID, jobErr)
+	} else {
+		printSuccess("Job #%d done: %s", job.ID, describeScheduledChange(job))
+		fmt.Printf("%sTransaction:%s %s\n", colorCyan, colorReset, txHash)
+	}
+	return nil
+}
+
+// reconcileUnconfirmedJobs checks the receipts of unconfirmed jobs. Jobs whose transaction was
+// included are verified and marked as done, reverted ones as failed; jobs whose transaction is
+// still unknown stay unconfirmed.
+func reconcileUnconfirmedJobs(ctx context.Context, storePath string) error {
+	store, err := scheduleStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(store.Jobs, func(job *scheduledJob) bool {
+		return job.Status == scheduleJobUnconfirmed
+	}) {
+		return nil
+	}
+
+	return scheduleStoreFile.update(storePath, func(store *scheduleStore) error {
+		for _, job := range store.Jobs {
+			if job.Status != scheduleJobUnconfirmed {
+				continue
+			}
+			receipt, err := ethClient.TransactionReceipt(ctx, common.HexToHash(job.TxHash))
+			if errors.Is(err, ethereum.NotFound) {
+				log.WithFields(map[string]interface{}{
+					"id": job.ID,
+					"tx": job.TxHash,
+				}).Warn("Transaction of scheduled job is still unconfirmed")
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get receipt of job #%d: %w", job.ID, err)
+			}
+
+			if receipt.Status == types.ReceiptStatusFailed {
+				job.Status = scheduleJobFailed
+				job.Error = fmt.Sprintf("transaction %s reverted", job.TxHash)
+				log.WithField("id", job.ID).Warn("Transaction of scheduled job reverted")
+				continue
+			}
+			verified, err := gaterClient.At(receipt.BlockNumber).DepositGateConfig(ctx, job.DepositType)
+			if err != nil {
+				return fmt.Errorf("failed to verify job #%d: %w", job.ID, err)
+			}
+			job.Result = &depositTypePolicy{Blocked: verified.Blocked, NoToken: verified.NoToken}
+			if (job.Blocked != nil && *job.Blocked != verified.Blocked) || (job.NoToken != nil && *job.NoToken != verified.NoToken) {
+				job.Status = scheduleJobFailed
+				job.Error = fmt.Sprintf("verification failed: config of 0x%04x is blocked=%v noToken=%v", job.DepositType, verified.Blocked, verified.NoToken)
+				continue
+			}
+			job.Status = scheduleJobDone
+			job.Error = ""
+			log.WithFields(map[string]interface{}{
+				"id": job.ID,
+				"tx": job.TxHash,
+			}).Info("Transaction of scheduled job was included")
+		}
+		return nil
+	})
+}
+
+// executeScheduledJob sends the config change of a job and verifies it by reading the config
+// back at the block of the transaction. It returns the transaction hash and the verified config.
+func executeScheduledJob(ctx context.Context, job *scheduledJob) (string, *depositTypePolicy, error) {
+	latest, err := gaterClient.AtLatest(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	current, err := latest.DepositGateConfig(ctx, job.DepositType)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get current config: %w", err)
+	}
+
+	config := current
+	if job.Blocked != nil {
+		config.Blocked = *job.Blocked
+	}
+	if job.NoToken != nil {
+		config.NoToken = *job.NoToken
+	}
+	if config == current {
+		log.WithField("id", job.ID).Info("Config already applied, nothing to send")
+		return "", &depositTypePolicy{Blocked: current.Blocked, NoToken: current.NoToken}, nil
+	}
+
+	receipt, err := sendDepositGateConfig(ctx, job.DepositType, current, config)
+	if err != nil {
+		return "", nil, err
+	}
+	txHash := receipt.TxHash.Hex()
+
+	verified, err := verifyDepositGateConfig(ctx, receipt.BlockNumber, job.DepositType, config)
+	if verified == nil {
+		return txHash, nil, err
+	}
+	return txHash, &depositTypePolicy{Blocked: verified.Blocked, NoToken: verified.NoToken}, err
+}
+
+// verifyDepositGateConfig reads the config of a deposit type at a block and checks it matches
+// the expected config. The read config is returned along with a mismatch error.
+func verifyDepositGateConfig(ctx context.Context, block *big.Int, depositType uint16, expected gater.DepositGateConfig) (*gater.DepositGateConfig, error) {
+	verified, err := gaterClient.At(block).DepositGateConfig(ctx, depositType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify new config: %w", err)
+	}
+	if verified != expected {
+		return &verified, fmt.Errorf("verification failed: config of 0x%04x is blocked=%v noToken=%v, expected blocked=%v noToken=%v",
+			depositType, verified.Blocked, verified.NoToken, expected.Blocked, expected.NoToken)
+	}
+	return &verified, nil
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+// scheduledJobs returns the jobs of the job store.
+func scheduledJobs(t *testing.T, storePath string) []*scheduledJob {
+	t.Helper()
+	store, err := scheduleStoreFile.load(storePath)
+	if err != nil {
+		t.Fatalf("failed to load job store: %v", err)
+	}
+	return store.Jobs
+}
+
+func TestScheduleBlockTrigger(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "schedule.json")
+
+	latest, err := env.chain.Client.BlockNumber(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get latest block: %v", err)
+	}
+	at := latest + 40
+	out := env.mustRun(nil, "schedule", "add", "--at", fmt.Sprintf("block:%d", at), "setConfig", "--prefix", "0x02", "--blocked", "true", "--store", storePath)
+	assertContains(t, out, "Scheduled job #1: setConfig 0x0002 blocked=true at block:"+strconv.FormatUint(at, 10))
+	env.mustRun(nil, "schedule", "add", "--at", fmt.Sprintf("block:%d", latest+1000000), "setConfig", "--prefix", "0x02", "--blocked", "false", "--store", storePath)
+
+	for i := 0; i < 200; i++ {
+		if number, _ := env.chain.Client.BlockNumber(t.Context()); number >= at {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Only the due job runs
+	out = env.mustRun(env.chain.AdminKey, "schedule", "run", "--once", "--yes", "--store", storePath)
+	assertContains(t, out, "Job #1 done: setConfig 0x0002 blocked=true")
+	if jobs := scheduledJobs(t, storePath); jobs[1].Status != scheduleJobPending {
+		t.Errorf("job ran before its block: %+v", jobs[1])
+	}
+
+	job := scheduledJobs(t, storePath)[0]
+	if job.Status != scheduleJobDone || job.TxHash == "" || job.Result == nil || !job.Result.Blocked || job.ExecutedAt == nil {
+		t.Errorf("unexpected job outcome: %+v", job)
+	}
+	config, err := env.gater().DepositGateConfig(t.Context(), 0x02)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if config != (gater.DepositGateConfig{Blocked: true}) {
+		t.Errorf("config of 0x0002 = %+v, want blocked", config)
+	}
+
+	// Scheduled changes are recorded for setConfig --revert
+	out = env.mustRun(env.chain.AdminKey, "setConfig", "--revert", "--yes")
+	assertContains(t, out, "Revert config for 0x0002", "Blocked:  "+formatBool(true)+" → "+formatBool(false))
+	if strings.Contains(out, "changed elsewhere") {
+		t.Errorf("scheduled change was not recorded:\n%s", out)
+	}
+	if config, _ := env.gater().DepositGateConfig(t.Context(), 0x02); config.Blocked {
+		t.Error("revert did not restore the config before the scheduled change")
+	}
+}
+
+func TestScheduleTimeTriggers(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "schedule.json")
+
+	_, err := env.run(nil, "schedule", "add", "--at", "2020-01-01T00:00:00Z", "setConfig", "--prefix", "0x02", "--blocked", "false", "--store", storePath)
+	assertError(t, err, "is in the past")
+
+	// Epoch 2 starts 2 * 32 * 12s after genesis
+	genesis := time.Now().Unix()
+	out := env.mustRun(nil, "schedule", "add", "--at", "epoch:2", "setConfig", "--prefix", "0x02", "--no-token", "true",
+		"--genesis-time", strconv.FormatInt(genesis, 10), "--store", storePath, "--output", "json")
+	job := &scheduledJob{}
+	if err := json.Unmarshal([]byte(out), job); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if job.NotBefore == nil || job.NotBefore.Unix() != genesis+2*32*12 || job.Blocked != nil || job.NoToken == nil || !*job.NoToken {
+		t.Errorf("unexpected epoch job: %+v", job)
+	}
+
+	// RFC 3339 times have no fraction, so the job is due 1-2s from now
+	soon := time.Now().Truncate(time.Second).Add(2 * time.Second)
+	env.mustRun(nil, "schedule", "add", "--at", soon.UTC().Format(time.RFC3339), "setConfig", "--prefix", "0x02", "--blocked", "false", "--store", storePath)
+
+	// Only the due job runs
+	var jobs []*scheduledJob
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(200 * time.Millisecond) {
+		env.mustRun(env.chain.AdminKey, "schedule", "run", "--once", "--yes", "--store", storePath)
+		if jobs = scheduledJobs(t, storePath); jobs[1].Status != scheduleJobPending {
+			break
+		}
+	}
+	if jobs[0].Status != scheduleJobPending || jobs[1].Status != scheduleJobDone {
+		t.Errorf("job statuses = %s, %s", jobs[0].Status, jobs[1].Status)
+	} else if jobs[1].ExecutedAt.Before(soon) {
+		t.Errorf("job ran at %s, before it was due at %s", jobs[1].ExecutedAt, soon)
+	}
+
+	env.mustRun(nil, "schedule", "cancel", "1", "--store", storePath)
+	_, err = env.run(nil, "schedule", "cancel", "1", "--store", storePath)
+	assertError(t, err, "job #1 is cancelled and can't be cancelled")
+}
+
+func TestScheduleUnconfirmed(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "schedule.json")
+
+	// The transaction is sent to a chain that doesn't mine it before the command times out
+	stalled := gatertest.NewChain(t)
+	stalledArgs := []string{"--rpc", stalled.IPCPath, "--deposit-contract", stalled.DepositContract.Hex(), "--store", storePath}
+	latest, err := stalled.Client.BlockNumber(t.Context())
+	if err != nil {
+		t.Fatalf("failed to get latest block: %v", err)
+	}
+	env.mustRun(nil, append([]string{"schedule", "add", "--at", fmt.Sprintf("block:%d", latest+1), "setConfig", "--prefix", "0x02", "--blocked", "true"}, stalledArgs...)...)
+	stalled.Backend.Commit()
+	out := env.mustRun(stalled.AdminKey, append([]string{"schedule", "run", "--once", "--yes", "--timeout", "2s"}, stalledArgs...)...)
+	assertContains(t, out, "Job #1 was sent, but not confirmed")
+	job := scheduledJobs(t, storePath)[0]
+	if job.Status != scheduleJobUnconfirmed || job.TxHash == "" {
+		t.Fatalf("unexpected job after timeout: %+v", job)
+	}
+
+	// The job isn't sent again while its transaction is unknown
+	nonce, err := stalled.Client.PendingNonceAt(t.Context(), stalled.Admin)
+	if err != nil {
+		t.Fatalf("failed to get nonce: %v", err)
+	}
+	env.mustRun(stalled.AdminKey, append([]string{"schedule", "run", "--once", "--yes"}, stalledArgs...)...)
+	if after, _ := stalled.Client.PendingNonceAt(t.Context(), stalled.Admin); after != nonce {
+		t.Errorf("unconfirmed job was sent again (nonce %d → %d)", nonce, after)
+	}
+
+	// Once the transaction is included, the job is done
+	stalled.Backend.Commit()
+	env.mustRun(stalled.AdminKey, append([]string{"schedule", "run", "--once", "--yes"}, stalledArgs...)...)
+	if done := scheduledJobs(t, storePath)[0]; done.Status != scheduleJobDone || done.TxHash != job.TxHash || done.Result == nil || !done.Result.Blocked {
+		t.Errorf("unexpected job after inclusion: %+v", done)
+	}
+}
+
+func TestScheduleErrors(t *testing.T) {
+	env := newTestEnv(t)
+	storePath := filepath.Join(t.TempDir(), "schedule.json")
+
+	_, err := env.run(nil, "schedule", "add", "--at", "block:1000", "mint", "--store", storePath)
+	assertError(t, err, `unsupported operation "mint"`)
+
+	_, err = env.run(nil, "schedule", "add", "--at", "block:1000", "setConfig", "--prefix", "0x02", "--store", storePath)
+	assertError(t, err, "nothing to change")
+
+	_, err = env.run(nil, "schedule", "add", "--at", "tomorrow", "setConfig", "--prefix", "0x02", "--blocked", "true", "--store", storePath)
+	assertError(t, err, "invalid trigger")
+
+	_, err = env.run(nil, "schedule", "add", "--at", "epoch:10", "setConfig", "--prefix", "0x02", "--blocked", "true", "--store", storePath)
+	assertError(t, err, "unknown genesis time")
+
+	_, err = env.run(env.chain.UserKey, "schedule", "run", "--once", "--yes", "--store", storePath)
+	assertError(t, err, "does not have admin role")
+}
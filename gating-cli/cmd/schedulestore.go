@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scheduled job states. Jobs go from pending to running when they are due, and from running
+// to done or failed, or to unconfirmed if the transaction was sent but not confirmed. Unconfirmed
+// jobs become done or failed once their receipt is found. Pending jobs can be cancelled.
+const (
+	scheduleJobPending     = "pending"
+	scheduleJobRunning     = "running"
+	scheduleJobUnconfirmed = "unconfirmed"
+	scheduleJobDone        = "done"
+	scheduleJobFailed      = "failed"
+	scheduleJobCancelled   = "cancelled"
+)
+
+// scheduleOperationSetConfig is the operation of a job setting a deposit type config.
+const scheduleOperationSetConfig = "setConfig"
+
+// scheduledJob is a configuration change to execute at a planned time, block or epoch.
+type scheduledJob struct {
+	ID          uint64 `json:"id"`
+	Operation   string `json:"operation"`
+	DepositType uint16 `json:"depositType"`
+	Blocked     *bool  `json:"blocked,omitempty"`
+	NoToken     *bool  `json:"noToken,omitempty"`
+
+	// Trigger: At is the trigger as given, NotBefore is set for time and epoch triggers,
+	// Block for block triggers.
+	At        string     `json:"at"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	Block     uint64     `json:"block,omitempty"`
+
+	CreatedAt  time.Time          `json:"createdAt"`
+	CreatedBy  string             `json:"createdBy,omitempty"`
+	Status     string             `json:"status"`
+	ExecutedAt *time.Time         `json:"executedAt,omitempty"`
+	TxHash     string             `json:"txHash,omitempty"`
+	Result     *depositTypePolicy `json:"result,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// scheduleStore is the content of the job store file.
+type scheduleStore struct {
+	NextID uint64          `json:"nextId"`
+	Jobs   []*scheduledJob `json:"jobs"`
+}
+
+// scheduleStoreFile is the job store file.
+var scheduleStoreFile = &jsonStore[scheduleStore]{
+	name:  "job store",
+	empty: func() *scheduleStore { return &scheduleStore{NextID: 1} },
+}
+
+// find returns the job with the given ID.
+func (store *scheduleStore) find(id uint64) (*scheduledJob, error) {
+	for _, job := range store.Jobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, fmt.Errorf("job #%d not found", id)
+}
+
+// add appends a new pending job and assigns its ID.
+func (store *scheduleStore) add(job *scheduledJob) {
+	job.ID = store.NextID
+	job.Status = scheduleJobPending
+	job.CreatedAt = time.Now().UTC()
+	store.NextID++
+	store.Jobs = append(store.Jobs, job)
+}
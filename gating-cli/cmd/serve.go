@@ -0,0 +1,521 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveListen    string
+	serveAuthToken string
+	serveAllow     []string
+	serveFromBlock uint64
+	serveChunkSize uint64
+)
+
+// Operations that can be enabled in the API with --allow.
+const (
+	serveOperationMint        = "mint"
+	serveOperationSetConfig   = "setConfig"
+	serveOperationGrantAdmin  = "grantAdmin"
+	serveOperationRevokeAdmin = "revokeAdmin"
+)
+
+var serveOperations = []string{serveOperationMint, serveOperationSetConfig, serveOperationGrantAdmin, serveOperationRevokeAdmin}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a REST API for the gating contract",
+	Long: `Serves an HTTP/JSON API to read the gating contract and, if enabled, send
+admin transactions, so other tools don't have to shell out to the CLI.
+
+  GET  /api/status                  contract status (as status --output json)
+  GET  /api/deposit-types           configs of the known deposit types
+  GET  /api/deposit-types/<type>    config of a deposit type
+  GET  /api/balances/<address>      token balance of an address
+  GET  /api/roles                   admins and their sticky status
+  GET  /api/roles/<address>         admin and sticky status of an address
+  POST /api/mint         {"to": "0x...", "amount": "1"}
+  POST /api/setConfig    {"depositType": "0x02", "blocked": true, "noToken": false}
+  POST /api/grantAdmin   {"address": "0x..."}
+  POST /api/revokeAdmin  {"address": "0x..."}
+
+The API is read-only by default. Operations are enabled with --allow (mint,
+setConfig, grantAdmin, revokeAdmin) and need --auth-token and the signer key with
+the admin role. If --auth-token is set, all requests must carry an
+"Authorization: Bearer <token>" header. Without --auth-token, the API can only be
+served on a loopback address (e.g. --listen 127.0.0.1:8082).
+
+Admins are found by scanning RoleGranted logs from --from-block once; later
+requests only scan the new blocks. Transactions are confirmed once at
+startup and sent one at a time; responses wait for the receipt.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveListen, "listen", ":8082", "Address to serve the API on")
+	serveCmd.Flags().StringVar(&serveAuthToken, "auth-token", "", "Bearer token required to use the API (default: none)")
+	serveCmd.Flags().StringSliceVar(&serveAllow, "allow", nil, "Operations to enable: "+strings.Join(serveOperations, ", ")+" (default: read-only)")
+	serveCmd.Flags().Uint64Var(&serveFromBlock, "from-block", 0, "First block to scan for RoleGranted logs")
+	serveCmd.Flags().Uint64Var(&serveChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+}
+
+// serveError is a failed API request with the HTTP status to respond with.
+type serveError struct {
+	status  int
+	message string
+}
+
+func (err *serveError) Error() string {
+	return err.message
+}
+
+// serveTxResponse is the response of an API operation.
+type serveTxResponse struct {
+	TxHash string `json:"txHash"`
+	Block  uint64 `json:"block"`
+}
+
+// serveDepositType is the config of a deposit type in API responses.
+type serveDepositType struct {
+	DepositType string `json:"depositType"`
+	Blocked     bool   `json:"blocked"`
+	NoToken     bool   `json:"noToken"`
+}
+
+// serveRole is the admin status of an account in API responses.
+type serveRole struct {
+	Address string `json:"address"`
+	Admin   bool   `json:"admin"`
+	Sticky  bool   `json:"sticky"`
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	allowed, err := parseServeOperations(serveAllow)
+	if err != nil {
+		return err
+	}
+	if len(allowed) > 0 {
+		if serveAuthToken == "" {
+			return fmt.Errorf("--allow requires --auth-token")
+		}
+		if signerAddress == (common.Address{}) {
+			return fmt.Errorf("--allow requires a private key")
+		}
+		if err := checkAdminRole(ctx); err != nil {
+			return err
+		}
+
+		// The API sends transactions without asking, so confirm once before serving
+		if !assumeYes {
+			fmt.Println()
+			printHeader("═══ Confirm API ═══")
+			printTransactionContext()
+			fmt.Printf("%sOperations:%s        %s\n", colorCyan, colorReset, strings.Join(serveAllow, ", "))
+			fmt.Println()
+		}
+		confirmed, err := requireConfirmation(fmt.Sprintf("Send API transactions on %s", networkLabel()))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("API operations not confirmed")
+		}
+		batchConfirmed = true
+		defer endTransactionBatch()
+	}
+
+	if serveAuthToken == "" && !isLoopbackListenAddress(serveListen) {
+		return fmt.Errorf("--auth-token is required to serve on %s (only loopback addresses can be served without)", serveListen)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", serveListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveListen, err)
+	}
+	server := &http.Server{Handler: serveHandler(ctx, allowed), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("API server failed")
+			stop()
+		}
+	}()
+	log.WithFields(map[string]interface{}{
+		"address":    listener.Addr().String(),
+		"operations": strings.Join(serveAllow, ","),
+	}).Info("Serving API")
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+// isLoopbackListenAddress checks if a listen address only accepts local connections.
+func isLoopbackListenAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// parseServeOperations checks the operations given with --allow.
+func parseServeOperations(operations []string) (map[string]bool, error) {
+	allowed := map[string]bool{}
+	for _, operation := range operations {
+		known := false
+		for _, candidate := range serveOperations {
+			if operation == candidate {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown operation %q (use %s)", operation, strings.Join(serveOperations, ", "))
+		}
+		allowed[operation] = true
+	}
+	return allowed, nil
+}
+
+// serveHandler returns the HTTP handler of the API. Operations not in allowed are refused.
+func serveHandler(ctx context.Context, allowed map[string]bool) http.Handler {
+	writeJSON := func(w http.ResponseWriter, status int, value interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(value)
+	}
+	writeError := func(w http.ResponseWriter, err error) {
+		status := http.StatusInternalServerError
+		var apiErr *serveError
+		if errors.As(err, &apiErr) {
+			status = apiErr.status
+		}
+		writeJSON(w, status, map[string]string{"error": err.Error()})
+	}
+
+	// read returns the handler of a GET endpoint, which needs the gater
+	read := func(handle func(r *http.Request) (interface{}, error)) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if gaterAddr == (common.Address{}) {
+				writeError(w, &serveError{http.StatusServiceUnavailable, "no gating contract configured on deposit contract"})
+				return
+			}
+			result, err := handle(r)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, result)
+		}
+	}
+
+	// operation returns the handler of a POST endpoint sending a transaction. handle decodes
+	// the request body into its own value. Transactions are sent one at a time, so they never
+	// compete for the same nonce.
+	var txMutex sync.Mutex
+	operation := func(name string, handle func(decode func(body interface{}) error) (interface{}, error)) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !allowed[name] {
+				writeError(w, &serveError{http.StatusForbidden, fmt.Sprintf("operation %s is not allowed", name)})
+				return
+			}
+			raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 16384))
+			if err != nil {
+				writeError(w, &serveError{http.StatusBadRequest, "invalid request body"})
+				return
+			}
+			decode := func(body interface{}) error {
+				if err := json.Unmarshal(raw, body); err != nil {
+					return &serveError{http.StatusBadRequest, "invalid request body"}
+				}
+				return nil
+			}
+			txMutex.Lock()
+			result, err := handle(decode)
+			txMutex.Unlock()
+			if err != nil {
+				log.WithError(err).WithField("operation", name).Warn("API operation failed")
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, result)
+		}
+	}
+
+	// Admins are looked up from RoleGranted logs, scanned once and then only for new blocks
+	adminCandidates := newRoleCandidateScanner(gater.DefaultAdminRole, serveFromBlock, serveChunkSize)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		report, err := buildStatusReport(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, report)
+	})
+	mux.HandleFunc("GET /api/deposit-types", read(func(r *http.Request) (interface{}, error) {
+		configs := make([]gater.DepositGateConfig, len(knownDepositTypes))
+		calls := make([]*gater.Call, len(knownDepositTypes))
+		for i, dt := range knownDepositTypes {
+			calls[i] = gater.NewCall(&configs[i], "getDepositGateConfig", dt.typeID)
+		}
+		if err := gaterClient.Batch(r.Context(), calls); err != nil {
+			return nil, fmt.Errorf("failed to get deposit type configs: %w", err)
+		}
+		result := make([]serveDepositType, len(knownDepositTypes))
+		for i, dt := range knownDepositTypes {
+			result[i] = serveDepositType{formatPolicyDepositType(dt.typeID), configs[i].Blocked, configs[i].NoToken}
+		}
+		return result, nil
+	}))
+	mux.HandleFunc("GET /api/deposit-types/{type}", read(func(r *http.Request) (interface{}, error) {
+		depositType, err := parseDepositType(r.PathValue("type"))
+		if err != nil {
+			return nil, &serveError{http.StatusBadRequest, err.Error()}
+		}
+		config, err := gaterClient.DepositGateConfig(r.Context(), depositType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deposit type config: %w", err)
+		}
+		return serveDepositType{formatPolicyDepositType(depositType), config.Blocked, config.NoToken}, nil
+	}))
+	mux.HandleFunc("GET /api/balances/{address}", read(func(r *http.Request) (interface{}, error) {
+		address, err := parseServeAddress(r.PathValue("address"))
+		if err != nil {
+			return nil, err
+		}
+		balance, err := gaterClient.BalanceOf(r.Context(), address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance: %w", err)
+		}
+		return map[string]string{"address": address.Hex(), "balance": balance.String()}, nil
+	}))
+	mux.HandleFunc("GET /api/roles", read(func(r *http.Request) (interface{}, error) {
+		return fetchServeAdmins(r.Context(), adminCandidates)
+	}))
+	mux.HandleFunc("GET /api/roles/{address}", read(func(r *http.Request) (interface{}, error) {
+		address, err := parseServeAddress(r.PathValue("address"))
+		if err != nil {
+			return nil, err
+		}
+		return fetchServeRole(r.Context(), address)
+	}))
+
+	mux.HandleFunc("POST /api/mint", operation(serveOperationMint, func(decode func(body interface{}) error) (interface{}, error) {
+		var mintBody struct {
+			To     string `json:"to"`
+			Amount string `json:"amount"`
+		}
+		if err := decode(&mintBody); err != nil {
+			return nil, err
+		}
+		recipient, err := parseServeAddress(mintBody.To)
+		if err != nil {
+			return nil, err
+		}
+		amount, ok := new(big.Int).SetString(mintBody.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, &serveError{http.StatusBadRequest, fmt.Sprintf("invalid amount: %q", mintBody.Amount)}
+		}
+		receipt, err := mintTokens(ctx, recipient, amount)
+		if err != nil {
+			return nil, err
+		}
+		return serveReceipt(receipt), nil
+	}))
+
+	mux.HandleFunc("POST /api/setConfig", operation(serveOperationSetConfig, func(decode func(body interface{}) error) (interface{}, error) {
+		var setConfigBody struct {
+			DepositType string `json:"depositType"`
+			Blocked     *bool  `json:"blocked"`
+			NoToken     *bool  `json:"noToken"`
+		}
+		if err := decode(&setConfigBody); err != nil {
+			return nil, err
+		}
+		depositType, err := parseDepositType(setConfigBody.DepositType)
+		if err != nil {
+			return nil, &serveError{http.StatusBadRequest, err.Error()}
+		}
+		if setConfigBody.Blocked == nil && setConfigBody.NoToken == nil {
+			return nil, &serveError{http.StatusBadRequest, "nothing to change (set blocked and/or noToken)"}
+		}
+		current, err := gaterClient.DepositGateConfig(ctx, depositType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current config: %w", err)
+		}
+		config := current
+		if setConfigBody.Blocked != nil {
+			config.Blocked = *setConfigBody.Blocked
+		}
+		if setConfigBody.NoToken != nil {
+			config.NoToken = *setConfigBody.NoToken
+		}
+		receipt, err := sendDepositGateConfig(ctx, depositType, current, config)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := verifyDepositGateConfig(ctx, receipt.BlockNumber, depositType, config); err != nil {
+			return nil, err
+		}
+		return struct {
+			serveTxResponse
+			serveDepositType
+		}{serveReceipt(receipt), serveDepositType{formatPolicyDepositType(depositType), config.Blocked, config.NoToken}}, nil
+	}))
+
+	mux.HandleFunc("POST /api/grantAdmin", operation(serveOperationGrantAdmin, func(decode func(body interface{}) error) (interface{}, error) {
+		var grantBody struct {
+			Address string `json:"address"`
+		}
+		if err := decode(&grantBody); err != nil {
+			return nil, err
+		}
+		target, err := parseServeAddress(grantBody.Address)
+		if err != nil {
+			return nil, err
+		}
+		isAdmin, err := gaterClient.HasRole(ctx, gater.DefaultAdminRole, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing role: %w", err)
+		}
+		if isAdmin {
+			return nil, &serveError{http.StatusConflict, fmt.Sprintf("address %s already has admin role", target.Hex())}
+		}
+		receipt, err := grantAdminRole(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		return serveReceipt(receipt), nil
+	}))
+
+	mux.HandleFunc("POST /api/revokeAdmin", operation(serveOperationRevokeAdmin, func(decode func(body interface{}) error) (interface{}, error) {
+		var revokeBody struct {
+			Address string `json:"address"`
+		}
+		if err := decode(&revokeBody); err != nil {
+			return nil, err
+		}
+		target, err := parseServeAddress(revokeBody.Address)
+		if err != nil {
+			return nil, err
+		}
+		role, err := fetchServeRole(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if !role.Admin {
+			return nil, &serveError{http.StatusConflict, fmt.Sprintf("address %s does not have admin role", target.Hex())}
+		}
+		if role.Sticky {
+			return nil, &serveError{http.StatusConflict, fmt.Sprintf("cannot revoke admin role from %s: role is sticky", target.Hex())}
+		}
+		receipt, err := revokeAdminRole(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		return serveReceipt(receipt), nil
+	}))
+
+	if serveAuthToken == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+serveAuthToken)) != 1 {
+			writeError(w, &serveError{http.StatusUnauthorized, "missing or invalid authorization token"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// parseServeAddress parses an address given in an API request.
+func parseServeAddress(input string) (common.Address, error) {
+	if !common.IsHexAddress(input) {
+		return common.Address{}, &serveError{http.StatusBadRequest, fmt.Sprintf("invalid address: %q", input)}
+	}
+	return common.HexToAddress(input), nil
+}
+
+// serveReceipt returns the API response of a sent transaction.
+func serveReceipt(receipt *types.Receipt) serveTxResponse {
+	return serveTxResponse{TxHash: receipt.TxHash.Hex(), Block: receipt.BlockNumber.Uint64()}
+}
+
+// fetchServeRole returns the admin and sticky status of an account.
+func fetchServeRole(ctx context.Context, address common.Address) (*serveRole, error) {
+	role := &serveRole{Address: address.Hex()}
+	if err := gaterClient.Batch(ctx, []*gater.Call{
+		gater.NewCall(&role.Admin, "hasRole", gater.DefaultAdminRole, address),
+		gater.NewCall(&role.Sticky, "isStickyRole", gater.DefaultAdminRole, address),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to check admin role: %w", err)
+	}
+	return role, nil
+}
+
+// fetchServeAdmins returns all admins and their sticky status.
+func fetchServeAdmins(ctx context.Context, candidates *roleCandidateScanner) ([]serveRole, error) {
+	latest, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	accounts, err := candidates.scan(ctx, latest.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if signerAddress != (common.Address{}) {
+		accounts = append(accounts, signerAddress)
+	}
+	admins, err := checkRoleMembers(ctx, gater.DefaultAdminRole, accounts, latest.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	sortAddresses(admins)
+
+	isSticky := make([]bool, len(admins))
+	calls := make([]*gater.Call, len(admins))
+	for i, admin := range admins {
+		calls[i] = gater.NewCall(&isSticky[i], "isStickyRole", gater.DefaultAdminRole, admin)
+	}
+	if err := gaterClient.At(latest).Batch(ctx, calls); err != nil {
+		return nil, fmt.Errorf("failed to check sticky status of admins: %w", err)
+	}
+	roles := make([]serveRole, len(admins))
+	for i, admin := range admins {
+		roles[i] = serveRole{Address: admin.Hex(), Admin: true, Sticky: isSticky[i]}
+	}
+	return roles, nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+const serveTestToken = "secret"
+
+// callAPI sends an API request with the test token and decodes the JSON response into result.
+func callAPI(t *testing.T, method, url string, body interface{}, result interface{}) int {
+	t.Helper()
+	var raw []byte
+	if body != nil {
+		raw, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, url, bytes.NewReader(raw))
+	req.Header.Set("Authorization", "Bearer "+serveTestToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			t.Fatalf("invalid response of %s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServeRead(t *testing.T) {
+	env := newTestEnv(t)
+	if _, err := env.adminGater().Mint(t.Context(), env.chain.User, big.NewInt(4)); err != nil {
+		t.Fatalf("mint failed: %v", err)
+	}
+	baseURL := env.startServer(nil, "/api/deposit-types", "serve")
+
+	report := &statusReport{}
+	if status := callAPI(t, http.MethodGet, baseURL+"/api/status", nil, report); status != http.StatusOK || report.Gater != env.chain.Gater.Hex() {
+		t.Errorf("unexpected status %d: %+v", status, report)
+	}
+
+	var configs []serveDepositType
+	callAPI(t, http.MethodGet, baseURL+"/api/deposit-types", nil, &configs)
+	if len(configs) != len(knownDepositTypes) {
+		t.Errorf("got %d deposit types, want %d", len(configs), len(knownDepositTypes))
+	}
+	config := &serveDepositType{}
+	if status := callAPI(t, http.MethodGet, baseURL+"/api/deposit-types/compounding", nil, config); status != http.StatusOK || config.DepositType != "0x02" {
+		t.Errorf("unexpected deposit type %d: %+v", status, config)
+	}
+
+	balance := map[string]string{}
+	callAPI(t, http.MethodGet, baseURL+"/api/balances/"+env.chain.User.Hex(), nil, &balance)
+	if balance["balance"] != "4" {
+		t.Errorf("balance = %q, want 4", balance["balance"])
+	}
+	if status := callAPI(t, http.MethodGet, baseURL+"/api/balances/0xzz", nil, nil); status != http.StatusBadRequest {
+		t.Errorf("invalid address: status %d, want 400", status)
+	}
+
+	var admins []serveRole
+	callAPI(t, http.MethodGet, baseURL+"/api/roles", nil, &admins)
+	if len(admins) != 1 || admins[0].Address != env.chain.Admin.Hex() {
+		t.Errorf("unexpected admins: %+v", admins)
+	}
+
+	// Later requests scan the new blocks
+	if _, err := env.adminGater().GrantRole(t.Context(), gater.DefaultAdminRole, env.chain.User); err != nil {
+		t.Fatalf("grantRole failed: %v", err)
+	}
+	callAPI(t, http.MethodGet, baseURL+"/api/roles", nil, &admins)
+	if len(admins) != 2 {
+		t.Errorf("unexpected admins after grant: %+v", admins)
+	}
+
+	// Operations are refused without --allow
+	errResponse := map[string]string{}
+	status := callAPI(t, http.MethodPost, baseURL+"/api/mint", map[string]string{"to": env.chain.User.Hex(), "amount": "1"}, &errResponse)
+	if status != http.StatusForbidden || !strings.Contains(errResponse["error"], "operation mint is not allowed") {
+		t.Errorf("mint without --allow: status %d (%v)", status, errResponse)
+	}
+}
+
+func TestServeOperations(t *testing.T) {
+	env := newTestEnv(t)
+	baseURL := env.startServer(env.chain.AdminKey, "/", "serve", "--yes", "--auth-token", serveTestToken, "--allow", "mint,setConfig,grantAdmin")
+
+	// Requests without the token are refused
+	resp, err := http.Get(baseURL + "/api/status")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("request without token: status %d, want 401", resp.StatusCode)
+	}
+
+	tx := &serveTxResponse{}
+	if status := callAPI(t, http.MethodPost, baseURL+"/api/mint", map[string]string{"to": env.chain.User.Hex(), "amount": "3"}, tx); status != http.StatusOK || tx.TxHash == "" {
+		t.Errorf("unexpected mint response %d: %+v", status, tx)
+	}
+	if balance := env.balanceOf(env.chain.User); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("balance = %s, want 3", balance)
+	}
+
+	// Only the given fields change
+	callAPI(t, http.MethodPost, baseURL+"/api/setConfig", map[string]interface{}{"depositType": "0x02", "noToken": true}, nil)
+	config, err := env.gater().DepositGateConfig(t.Context(), 0x02)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if config != (gater.DepositGateConfig{NoToken: true}) {
+		t.Errorf("config of 0x0002 = %+v, want noToken", config)
+	}
+
+	// The change is recorded for setConfig --revert (read from the file, the CLI state belongs to the server)
+	configFile, _ := defaultConfigPath()
+	undo, err := configUndoStoreFile.load(filepath.Join(filepath.Dir(configFile), "setconfig-undo-"+gatertest.ChainID.String()+".json"))
+	if err != nil {
+		t.Fatalf("failed to load undo store: %v", err)
+	}
+	if entry := undo.find(env.chain.Gater, 0x02); entry == nil || entry.Previous != (depositTypePolicy{}) || entry.TxHash == "" {
+		t.Errorf("unexpected undo entry: %+v", entry)
+	}
+
+	callAPI(t, http.MethodPost, baseURL+"/api/grantAdmin", map[string]string{"address": env.chain.User.Hex()}, nil)
+	if !env.hasAdminRole(env.chain.User) {
+		t.Error("user is not admin after grantAdmin")
+	}
+	if status := callAPI(t, http.MethodPost, baseURL+"/api/grantAdmin", map[string]string{"address": env.chain.User.Hex()}, nil); status != http.StatusConflict {
+		t.Errorf("granting twice: status %d, want 409", status)
+	}
+	if status := callAPI(t, http.MethodPost, baseURL+"/api/revokeAdmin", map[string]string{"address": env.chain.User.Hex()}, nil); status != http.StatusForbidden {
+		t.Errorf("revokeAdmin not in --allow: status %d, want 403", status)
+	}
+	if status := callAPI(t, http.MethodPost, baseURL+"/api/mint", map[string]string{"to": common.Address{}.Hex(), "amount": "x"}, nil); status != http.StatusBadRequest {
+		t.Errorf("invalid amount: status %d, want 400", status)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.AdminKey, "serve", "--allow", "mint", "--yes")
+	assertError(t, err, "--allow requires --auth-token")
+
+	_, err = env.run(env.chain.AdminKey, "serve", "--allow", "burn", "--auth-token", serveTestToken, "--yes")
+	assertError(t, err, `unknown operation "burn"`)
+
+	_, err = env.run(nil, "serve", "--allow", "mint", "--auth-token", serveTestToken, "--yes")
+	assertError(t, err, "--allow requires a private key")
+
+	_, err = env.run(env.chain.UserKey, "serve", "--allow", "mint", "--auth-token", serveTestToken, "--yes")
+	assertError(t, err, "does not have admin role")
+
+	_, err = env.run(nil, "serve", "--listen", ":0")
+	assertError(t, err, "--auth-token is required to serve on :0")
+	if !isLoopbackListenAddress("127.0.0.1:8082") || !isLoopbackListenAddress("[::1]:8082") || !isLoopbackListenAddress("localhost:8082") || isLoopbackListenAddress("0.0.0.0:8082") {
+		t.Error("unexpected loopback detection")
+	}
+}
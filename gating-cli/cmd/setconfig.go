@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +16,7 @@ var (
 	configPrefix  string
 	configBlocked string
 	configNoToken string
+	configRevert  bool
 )
 
 var setConfigCmd = &cobra.Command{
@@ -31,6 +35,11 @@ Configuration options:
   --blocked    - If true, deposits of this type are completely blocked
   --no-token   - If true, deposits of this type don't require burning a token
 
+Every change records the config it replaced. --revert restores the previous
+config of --prefix, or of the most recently changed deposit type if no prefix is
+given, after showing the difference. Reverting is a change itself, so reverting
+twice restores the reverted config.
+
 Only accounts with admin role can modify configuration.`,
 	RunE: runSetConfig,
 }
@@ -39,16 +48,21 @@ func init() {
 	setConfigCmd.Flags().StringVarP(&configPrefix, "prefix", "p", "", "Deposit type prefix (e.g., 0x00, 0x01, 0x02, 0xffff)")
 	setConfigCmd.Flags().StringVarP(&configBlocked, "blocked", "b", "", "Block deposits of this type (true/false)")
 	setConfigCmd.Flags().StringVarP(&configNoToken, "no-token", "n", "", "Allow deposits without token (true/false)")
+	setConfigCmd.Flags().BoolVar(&configRevert, "revert", false, "Restore the config before the last change of the deposit type")
 }
 
 func runSetConfig(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
 	// Check admin role
 	if err := checkAdminRole(ctx); err != nil {
 		return err
 	}
 
+	if configRevert {
+		return runSetConfigRevert(ctx)
+	}
+
 	// Determine prefix
 	var depositType uint16
 	if configPrefix != "" {
@@ -72,10 +86,15 @@ func runSetConfig(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current config
-	currentBlocked, currentNoToken, err := getDepositGateConfig(ctx, depositType)
+	latest, err := gaterClient.AtLatest(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := latest.DepositGateConfig(ctx, depositType)
 	if err != nil {
 		return fmt.Errorf("failed to get current config: %w", err)
 	}
+	currentBlocked, currentNoToken := current.Blocked, current.NoToken
 
 	fmt.Printf("%sCurrent config for 0x%04x:%s\n", colorCyan, depositType, colorReset)
 	fmt.Printf("  Blocked:  %s\n", formatBool(currentBlocked))
@@ -137,22 +156,69 @@ func runSetConfig(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  NoToken:  %s\n", formatBool(newNoToken))
 	fmt.Println()
 
-	log.WithFields(map[string]interface{}{
-		"depositType": fmt.Sprintf("0x%04x", depositType),
-		"blocked":     newBlocked,
-		"noToken":     newNoToken,
-	}).Info("Setting deposit gate config")
+	return updateDepositGateConfig(ctx, depositType, current, gater.DepositGateConfig{Blocked: newBlocked, NoToken: newNoToken})
+}
+
+// runSetConfigRevert restores the config a deposit type had before its last recorded change.
+func runSetConfigRevert(ctx context.Context) error {
+	if configBlocked != "" || configNoToken != "" {
+		return fmt.Errorf("--revert can't be combined with --blocked or --no-token")
+	}
+
+	storePath, err := configUndoStorePath()
+	if err != nil {
+		return err
+	}
+	store, err := configUndoStoreFile.load(storePath)
+	if err != nil {
+		return err
+	}
+	var entry *configUndoEntry
+	if configPrefix != "" {
+		depositType, err := parseDepositType(configPrefix)
+		if err != nil {
+			return err
+		}
+		if entry = store.find(gaterAddr, depositType); entry == nil {
+			return fmt.Errorf("no previous config recorded for deposit type 0x%04x on %s", depositType, gaterAddr.Hex())
+		}
+	} else if entry = store.latest(gaterAddr); entry == nil {
+		return fmt.Errorf("no config change recorded on %s", gaterAddr.Hex())
+	}
 
-	// Pack transaction data
-	data, err := parsedABI.Pack("setDepositGateConfig", depositType, newBlocked, newNoToken)
+	latest, err := gaterClient.AtLatest(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := latest.DepositGateConfig(ctx, entry.DepositType)
 	if err != nil {
-		return fmt.Errorf("failed to pack setDepositGateConfig call: %w", err)
+		return fmt.Errorf("failed to get current config: %w", err)
+	}
+	previous := gater.DepositGateConfig{Blocked: entry.Previous.Blocked, NoToken: entry.Previous.NoToken}
+
+	fmt.Printf("%sRevert config for 0x%04x%s (changed %s in %s):\n", colorCyan, entry.DepositType, colorReset,
+		entry.Time.Local().Format("2006-01-02 15:04:05"), entry.TxHash)
+	fmt.Printf("  Blocked:  %s → %s\n", formatBool(current.Blocked), formatBool(previous.Blocked))
+	fmt.Printf("  NoToken:  %s → %s\n", formatBool(current.NoToken), formatBool(previous.NoToken))
+	fmt.Println()
+	if current != (gater.DepositGateConfig{Blocked: entry.Config.Blocked, NoToken: entry.Config.NoToken}) {
+		fmt.Printf("%sThe config was changed elsewhere since (expected blocked=%v noToken=%v).%s\n\n",
+			colorYellow, entry.Config.Blocked, entry.Config.NoToken, colorReset)
+	}
+
+	if current == previous {
+		printInfo("No changes to apply.")
+		return nil
 	}
+	return updateDepositGateConfig(ctx, entry.DepositType, current, previous)
+}
 
+// updateDepositGateConfig sends a config change and prints the verified result.
+func updateDepositGateConfig(ctx context.Context, depositType uint16, current, config gater.DepositGateConfig) error {
 	// Send transaction
-	receipt, err := sendTransaction(ctx, gaterAddr, data)
+	receipt, err := sendDepositGateConfig(ctx, depositType, current, config)
 	if err != nil {
-		return fmt.Errorf("setConfig failed: %w", err)
+		return err
 	}
 
 	printSuccess("Successfully updated config for deposit type 0x%04x", depositType)
@@ -160,19 +226,47 @@ func runSetConfig(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sGas used:%s    %d\n", colorCyan, colorReset, receipt.GasUsed)
 
 	// Verify the new config by reading it back from the contract
-	verifiedBlocked, verifiedNoToken, err := getDepositGateConfig(ctx, depositType)
+	verified, err := gaterClient.At(receipt.BlockNumber).DepositGateConfig(ctx, depositType)
 	if err != nil {
 		log.WithError(err).Warn("Failed to verify new config")
 	} else {
 		fmt.Println()
 		fmt.Printf("%sVerified config for 0x%04x:%s\n", colorGreen, depositType, colorReset)
-		fmt.Printf("  Blocked:  %s\n", formatBool(verifiedBlocked))
-		fmt.Printf("  NoToken:  %s\n", formatBool(verifiedNoToken))
+		fmt.Printf("  Blocked:  %s\n", formatBool(verified.Blocked))
+		fmt.Printf("  NoToken:  %s\n", formatBool(verified.NoToken))
 	}
 
 	return nil
 }
 
+// sendDepositGateConfig sets the config of a deposit type with the signer key and waits for the receipt.
+// The replaced config is recorded for --revert, so every config change can be undone, whether it
+// was made by setConfig, apply, a scheduled job or the API.
+func sendDepositGateConfig(ctx context.Context, depositType uint16, current, config gater.DepositGateConfig) (*types.Receipt, error) {
+	log.WithFields(map[string]interface{}{
+		"depositType": fmt.Sprintf("0x%04x", depositType),
+		"blocked":     config.Blocked,
+		"noToken":     config.NoToken,
+	}).Info("Setting deposit gate config")
+
+	receipt, err := gaterClient.SetDepositGateConfig(ctx, depositType, config)
+	if err != nil {
+		return nil, fmt.Errorf("setConfig failed: %w", err)
+	}
+
+	if err := recordConfigChange(&configUndoEntry{
+		Gater:       gaterAddr.Hex(),
+		DepositType: depositType,
+		Previous:    depositTypePolicy{Blocked: current.Blocked, NoToken: current.NoToken},
+		Config:      depositTypePolicy{Blocked: config.Blocked, NoToken: config.NoToken},
+		TxHash:      receipt.TxHash.Hex(),
+		Time:        time.Now().UTC(),
+	}); err != nil {
+		log.WithError(err).Warn("Failed to record the previous config, --revert won't restore it")
+	}
+	return receipt, nil
+}
+
 func parseDepositType(input string) (uint16, error) {
 	input = strings.TrimSpace(input)
 	input = strings.ToLower(input)
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+func TestSetConfig(t *testing.T) {
+	env := newTestEnv(t)
+
+	out := env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x01", "--blocked", "true", "--no-token", "false", "--yes")
+	assertContains(t, out, "Verified config for 0x0001:", "Blocked:  true")
+
+	// Only the given settings are changed
+	env.mustRun(env.chain.AdminKey, "setConfig", "-p", "0x01", "-n", "true", "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "-p", "0xffff", "-n", "true", "--yes")
+
+	for depositType, want := range map[uint16]gater.DepositGateConfig{
+		0x00:                   {},
+		0x01:                   {Blocked: true, NoToken: true},
+		gater.TopUpDepositType: {NoToken: true},
+	} {
+		config, err := env.gater().DepositGateConfig(t.Context(), depositType)
+		if err != nil {
+			t.Fatalf("failed to get config: %v", err)
+		}
+		if config != want {
+			t.Errorf("config of 0x%04x = %+v, want %+v", depositType, config, want)
+		}
+	}
+}
+
+func TestSetConfigErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.AdminKey, "setConfig", "--blocked", "true", "--yes")
+	assertError(t, err, "prefix is required")
+
+	_, err = env.run(env.chain.AdminKey, "setConfig", "--prefix", "bls2", "--blocked", "true", "--yes")
+	assertError(t, err, "invalid deposit type")
+
+	_, err = env.run(env.chain.AdminKey, "setConfig", "--prefix", "0x01", "--blocked", "maybe", "--yes")
+	assertError(t, err, "invalid blocked value")
+
+	_, err = env.run(env.chain.UserKey, "setConfig", "--prefix", "0x01", "--blocked", "true", "--yes")
+	assertError(t, err, "does not have admin role")
+
+	config, err := env.gater().DepositGateConfig(t.Context(), 0x01)
+	if err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if config.Blocked {
+		t.Error("failed setConfig changed the config")
+	}
+}
+
+func TestSetConfigRevert(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(env.chain.AdminKey, "setConfig", "--revert", "--yes")
+	assertError(t, err, "no config change recorded")
+
+	env.mustRun(env.chain.AdminKey, "setConfig", "-p", "0x01", "-b", "true", "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "-p", "0x02", "-n", "true", "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "-p", "0x02", "-b", "true", "--yes")
+
+	// Without a prefix, the most recent change is reverted, one step back
+	out := env.mustRun(env.chain.AdminKey, "setConfig", "--revert", "--yes")
+	assertContains(t, out, "Revert config for 0x0002", "Blocked:  "+formatBool(true)+" → "+formatBool(false))
+	out = env.mustRun(env.chain.AdminKey, "setConfig", "--revert", "-p", "0x01", "--yes")
+	assertContains(t, out, "Revert config for 0x0001")
+
+	for depositType, want := range map[uint16]gater.DepositGateConfig{
+		0x01: {},
+		0x02: {NoToken: true},
+	} {
+		config, err := env.gater().DepositGateConfig(t.Context(), depositType)
+		if err != nil {
+			t.Fatalf("failed to get config: %v", err)
+		}
+		if config != want {
+			t.Errorf("config of 0x%04x = %+v, want %+v", depositType, config, want)
+		}
+	}
+
+	// Reverting a revert restores the reverted config
+	env.mustRun(env.chain.AdminKey, "setConfig", "--revert", "-p", "0x01", "--yes")
+	if config, _ := env.gater().DepositGateConfig(t.Context(), 0x01); !config.Blocked {
+		t.Error("second revert did not restore the blocked config")
+	}
+
+	_, err = env.run(env.chain.AdminKey, "setConfig", "--revert", "-p", "0x03", "--yes")
+	assertError(t, err, "no previous config recorded for deposit type 0x0003")
+	_, err = env.run(env.chain.AdminKey, "setConfig", "--revert", "-p", "0x01", "-b", "false", "--yes")
+	assertError(t, err, "--revert can't be combined")
+}
+
+func TestSetConfigRevertApply(t *testing.T) {
+	env := newTestEnv(t)
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("version: 1\ndepositTypes:\n  0x02: {blocked: true, noToken: true}\n"), 0o600); err != nil {
+		t.Fatalf("failed to write policy: %v", err)
+	}
+	env.mustRun(env.chain.AdminKey, "apply", "-f", policyPath, "--yes")
+
+	// Config changes applied from a policy are recorded like any other change
+	out := env.mustRun(env.chain.AdminKey, "setConfig", "--revert", "--yes")
+	assertContains(t, out, "Revert config for 0x0002", "Blocked:  "+formatBool(true)+" → "+formatBool(false))
+	if strings.Contains(out, "changed elsewhere") {
+		t.Errorf("applied change was not recorded:\n%s", out)
+	}
+	if config, _ := env.gater().DepositGateConfig(t.Context(), 0x02); config != (gater.DepositGateConfig{}) {
+		t.Errorf("config of 0x0002 after revert = %+v, want the config before apply", config)
+	}
+}
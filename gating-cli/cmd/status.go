@@ -3,8 +3,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
 	"github.com/spf13/cobra"
 )
 
@@ -16,7 +18,11 @@ var statusCmd = &cobra.Command{
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
+
+	if outputFormat == "json" {
+		return printStatusJSON(ctx)
+	}
 
 	printHeader("═══ Gated Deposit Contract Status ═══")
 	fmt.Println()
@@ -38,40 +44,33 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("%sGating Contract:%s   %s\n", colorCyan, colorReset, gaterAddr.Hex())
 	fmt.Println()
 
-	// Token info
-	tokenName, err := getTokenName(ctx)
+	state, err := fetchStatusState(ctx)
 	if err != nil {
-		log.WithError(err).Debug("Failed to get token name")
-		tokenName = "Unknown"
+		log.WithError(err).Debug("Failed to read gater state")
 	}
 
-	tokenSymbol, err := getTokenSymbol(ctx)
-	if err != nil {
-		log.WithError(err).Debug("Failed to get token symbol")
-		tokenSymbol = "?"
+	// Token info
+	tokenName := state.tokenName
+	if state.tokenNameCall.Err != nil {
+		tokenName = "Unknown"
 	}
-
-	totalSupply, err := getTotalSupply(ctx)
-	if err != nil {
-		log.WithError(err).Debug("Failed to get total supply")
+	tokenSymbol := state.tokenSymbol
+	if state.tokenSymbolCall.Err != nil {
+		tokenSymbol = "?"
 	}
 
 	fmt.Printf("%sToken Name:%s        %s (%s)\n", colorCyan, colorReset, tokenName, tokenSymbol)
-	if totalSupply != nil {
-		fmt.Printf("%sTotal Supply:%s      %s\n", colorCyan, colorReset, totalSupply.String())
+	if state.totalSupplyCall.Err == nil {
+		fmt.Printf("%sTotal Supply:%s      %s\n", colorCyan, colorReset, state.totalSupply.String())
 	}
 	fmt.Println()
 
 	// Admin status
-	isAdmin, err := hasRole(ctx, DefaultAdminRole, signerAddress)
-	if err != nil {
-		log.WithError(err).Debug("Failed to check admin role")
-	} else {
+	if state.isAdminCall != nil && state.isAdminCall.Err == nil {
 		var adminStatus string
-		if isAdmin {
+		if state.isAdmin {
 			adminStatus = colorGreen + "Yes" + colorReset
-			isSticky, err := isStickyRole(ctx, DefaultAdminRole, signerAddress)
-			if err == nil && isSticky {
+			if state.isStickyCall.Err == nil && state.isSticky {
 				adminStatus = colorGreen + "Yes" + colorReset + " (sticky)"
 			}
 		} else {
@@ -81,20 +80,14 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Signer balance
-	balance, err := getBalanceOf(ctx, signerAddress)
-	if err != nil {
-		log.WithError(err).Debug("Failed to get balance")
-	} else {
-		fmt.Printf("%sSigner Balance:%s    %s tokens\n", colorCyan, colorReset, balance.String())
+	if state.balanceCall != nil && state.balanceCall.Err == nil {
+		fmt.Printf("%sSigner Balance:%s    %s tokens\n", colorCyan, colorReset, state.balance.String())
 	}
 	fmt.Println()
 
 	// Custom gater
-	customGater, err := getCustomGater(ctx)
-	if err != nil {
-		log.WithError(err).Debug("Failed to get custom gater")
-	} else if customGater != (common.Address{}) {
-		fmt.Printf("%sCustom Gater:%s      %s\n", colorCyan, colorReset, customGater.Hex())
+	if state.customGaterCall.Err == nil && state.customGater != (common.Address{}) {
+		fmt.Printf("%sCustom Gater:%s      %s\n", colorCyan, colorReset, state.customGater.Hex())
 		fmt.Println()
 	}
 
@@ -102,34 +95,22 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	printHeader("═══ Deposit Type Configurations ═══")
 	fmt.Println()
 
-	// Known deposit types
-	depositTypes := []struct {
-		typeID uint16
-		name   string
-	}{
-		{0x00, "BLS withdrawal credentials (0x00)"},
-		{0x01, "Execution withdrawal credentials (0x01)"},
-		{0x02, "Compounding credentials (0x02)"},
-		{0x03, "ePBS builder credentials (0x03)"},
-		{0xffff, "Top-up deposits (0xffff)"},
-	}
-
-	for _, dt := range depositTypes {
-		blocked, noToken, err := getDepositGateConfig(ctx, dt.typeID)
-		if err != nil {
+	for i, dt := range knownDepositTypes {
+		if err := state.configCalls[i].Err; err != nil {
 			log.WithError(err).WithField("type", dt.name).Debug("Failed to get config")
 			continue
 		}
+		config := state.configs[i]
 
 		var status string
-		if blocked {
+		if config.Blocked {
 			status = colorRed + "BLOCKED" + colorReset
 		} else {
 			status = colorGreen + "Allowed" + colorReset
 		}
 
 		var tokenReq string
-		if noToken {
+		if config.NoToken {
 			tokenReq = colorYellow + "No token required" + colorReset
 		} else {
 			tokenReq = "Requires token"
@@ -140,3 +121,128 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// statusState is the gater state shown by the status command.
+type statusState struct {
+	tokenName   string
+	tokenSymbol string
+	totalSupply *big.Int
+	isAdmin     bool
+	isSticky    bool
+	balance     *big.Int
+	customGater common.Address
+	configs     []gater.DepositGateConfig
+
+	tokenNameCall   *gater.Call
+	tokenSymbolCall *gater.Call
+	totalSupplyCall *gater.Call
+	isAdminCall     *gater.Call // nil without signer
+	isStickyCall    *gater.Call // nil without signer
+	balanceCall     *gater.Call // nil without signer
+	customGaterCall *gater.Call
+	configCalls     []*gater.Call
+}
+
+// fetchStatusState reads the gater state at the latest block in a single batch.
+// The error of each read is stored in its call; the first error is returned.
+func fetchStatusState(ctx context.Context) (*statusState, error) {
+	state := &statusState{configs: make([]gater.DepositGateConfig, len(knownDepositTypes))}
+	state.tokenNameCall = gater.NewCall(&state.tokenName, "name")
+	state.tokenSymbolCall = gater.NewCall(&state.tokenSymbol, "symbol")
+	state.totalSupplyCall = gater.NewCall(&state.totalSupply, "totalSupply")
+	state.customGaterCall = gater.NewCall(&state.customGater, "getCustomGater")
+	calls := []*gater.Call{state.tokenNameCall, state.tokenSymbolCall, state.totalSupplyCall, state.customGaterCall}
+
+	if signerAddress != (common.Address{}) {
+		state.isAdminCall = gater.NewCall(&state.isAdmin, "hasRole", gater.DefaultAdminRole, signerAddress)
+		state.isStickyCall = gater.NewCall(&state.isSticky, "isStickyRole", gater.DefaultAdminRole, signerAddress)
+		state.balanceCall = gater.NewCall(&state.balance, "balanceOf", signerAddress)
+		calls = append(calls, state.isAdminCall, state.isStickyCall, state.balanceCall)
+	}
+
+	for i, dt := range knownDepositTypes {
+		call := gater.NewCall(&state.configs[i], "getDepositGateConfig", dt.typeID)
+		state.configCalls = append(state.configCalls, call)
+		calls = append(calls, call)
+	}
+
+	// Pin the latest block to avoid cached responses
+	blockNum, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to get latest block: %w", err)
+		for _, call := range calls {
+			call.Err = err
+		}
+		return state, err
+	}
+	return state, gaterClient.At(blockNum).Batch(ctx, calls)
+}
+
+// statusReport is the JSON output of the status command.
+type statusReport struct {
+	ChainID         uint64                       `json:"chainId"`
+	DepositContract string                       `json:"depositContract"`
+	Signer          string                       `json:"signer,omitempty"`
+	Gater           string                       `json:"gater,omitempty"`
+	Token           *statusToken                 `json:"token,omitempty"`
+	SignerIsAdmin   bool                         `json:"signerIsAdmin"`
+	SignerIsSticky  bool                         `json:"signerIsSticky"`
+	SignerBalance   string                       `json:"signerBalance,omitempty"`
+	CustomGater     string                       `json:"customGater,omitempty"`
+	DepositTypes    map[string]depositTypePolicy `json:"depositTypes,omitempty"`
+}
+
+// statusToken describes the gater token in the status JSON output.
+type statusToken struct {
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	TotalSupply string `json:"totalSupply"`
+}
+
+// printStatusJSON prints the contract status as JSON.
+func printStatusJSON(ctx context.Context) error {
+	report, err := buildStatusReport(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(report)
+}
+
+// buildStatusReport reads the contract status for the JSON output.
+func buildStatusReport(ctx context.Context) (*statusReport, error) {
+	report := &statusReport{
+		ChainID:         chainID.Uint64(),
+		DepositContract: depositAddr.Hex(),
+	}
+	if signerAddress != (common.Address{}) {
+		report.Signer = signerAddress.Hex()
+	}
+	if gaterAddr == (common.Address{}) {
+		return report, nil
+	}
+	report.Gater = gaterAddr.Hex()
+
+	state, err := fetchStatusState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Token = &statusToken{
+		Name:        state.tokenName,
+		Symbol:      state.tokenSymbol,
+		TotalSupply: state.totalSupply.String(),
+	}
+	if report.Signer != "" {
+		report.SignerIsAdmin = state.isAdmin
+		report.SignerIsSticky = state.isSticky
+		report.SignerBalance = state.balance.String()
+	}
+	report.CustomGater = state.customGater.Hex()
+
+	report.DepositTypes = map[string]depositTypePolicy{}
+	for i, dt := range knownDepositTypes {
+		report.DepositTypes[formatPolicyDepositType(dt.typeID)] = depositTypePolicy(state.configs[i])
+	}
+
+	return report, nil
+}
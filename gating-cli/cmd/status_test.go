@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestStatus(t *testing.T) {
+	env := newTestEnv(t)
+
+	out := env.mustRun(env.chain.AdminKey, "status")
+	assertContains(t, out,
+		"Gating Contract:   "+env.chain.Gater.Hex(),
+		"Token Name:        Deposit Token (Deposit)",
+		"Signer is Admin:   Yes",
+		"Signer Balance:    0 tokens",
+		"Top-up deposits (0xffff):",
+	)
+
+	out = env.mustRun(env.chain.UserKey, "status")
+	assertContains(t, out, "Signer is Admin:   No")
+}
+
+func TestStatusJSON(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x00", "--blocked", "true", "--no-token", "false", "--yes")
+
+	out := env.mustRun(env.chain.AdminKey, "status", "--output", "json")
+	var report statusReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if report.ChainID != 1337 || report.Gater != env.chain.Gater.Hex() || report.DepositContract != env.chain.DepositContract.Hex() {
+		t.Errorf("unexpected contracts in report: %+v", report)
+	}
+	if !report.SignerIsAdmin || report.SignerIsSticky {
+		t.Errorf("signer role = admin %v, sticky %v, want admin and not sticky", report.SignerIsAdmin, report.SignerIsSticky)
+	}
+	if report.Token == nil || report.Token.Name != "Deposit Token" || report.Token.TotalSupply != "0" {
+		t.Errorf("unexpected token in report: %+v", report.Token)
+	}
+	if config := report.DepositTypes["0x00"]; !config.Blocked || config.NoToken {
+		t.Errorf("config of 0x00 = %+v, want blocked", config)
+	}
+}
+
+func TestStatusStickyAdmin(t *testing.T) {
+	env := newTestEnv(t)
+
+	out := env.mustRun(env.chain.AdminKey, "status", "--gater", gatertest.StickyGaterAddress.Hex(), "--output", "json")
+	var report statusReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if !report.SignerIsAdmin || !report.SignerIsSticky {
+		t.Errorf("signer role = admin %v, sticky %v, want sticky admin", report.SignerIsAdmin, report.SignerIsSticky)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// storeLockTimeout is how long to wait for another process to release a store file.
+const storeLockTimeout = 10 * time.Second
+
+// writeStoreFile writes a store file via a temporary file, so a crash never leaves a
+// truncated store behind.
+func writeStoreFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// lockStoreFile creates the lock file of a store file, waiting for other processes to release it.
+func lockStoreFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(storeLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked by another process (remove %s if no other gating-cli is running)", path, lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// jsonStore is a store file holding a JSON document of type T, e.g. the job or request store.
+type jsonStore[T any] struct {
+	// name of the store in errors, e.g. "job store"
+	name string
+	// empty returns the content of a missing store file
+	empty func() *T
+
+	// mutex serializes updates within the process, the lock file serializes them between processes
+	mutex sync.Mutex
+}
+
+// load reads the store. A missing file results in an empty store.
+func (store *jsonStore[T]) load(path string) (*T, error) {
+	content := store.empty()
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return content, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", store.name, err)
+	}
+	if err := json.Unmarshal(raw, content); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s: %w", store.name, path, err)
+	}
+	return content, nil
+}
+
+// save writes the store.
+func (store *jsonStore[T]) save(path string, content *T) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", store.name, err)
+	}
+	if err := writeStoreFile(path, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", store.name, err)
+	}
+	return nil
+}
+
+// update loads the store, applies update and saves the store, while holding the store lock.
+// The store is not saved if update fails.
+func (store *jsonStore[T]) update(path string, update func(content *T) error) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", store.name, err)
+	}
+	unlock, err := lockStoreFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	content, err := store.load(path)
+	if err != nil {
+		return err
+	}
+	if err := update(content); err != nil {
+		return err
+	}
+	return store.save(path, content)
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// testEnv runs CLI commands against a simulated chain.
+type testEnv struct {
+	t     *testing.T
+	chain *gatertest.Chain
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	chain := gatertest.NewChain(t)
+	chain.AutoCommit(t, 50*time.Millisecond)
+
+	// Isolate the CLI from the config file and environment of the user
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, name := range []string{"PRIVATE_KEY", "ETH_RPC_URL", "DEPOSIT_CONTRACT", "GATING_CLI_PROFILE", "GATING_CLI_OPERATOR"} {
+		t.Setenv(name, "")
+	}
+
+	// Keep the test output readable, errors are checked by the tests
+	log.SetOutput(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		rootCmd.SetErr(nil)
+	})
+
+	return &testEnv{t: t, chain: chain}
+}
+
+// run executes the CLI with the given signer key (nil for none) and arguments, connected to the
+// simulated chain (unless --rpc is given), and returns what the command printed to stdout.
+func (env *testEnv) run(key *ecdsa.PrivateKey, args ...string) (string, error) {
+	env.t.Helper()
+	return env.runContext(context.Background(), key, args...)
+}
+
+// runContext executes the CLI like run, stopping the command when the context is cancelled.
+func (env *testEnv) runContext(ctx context.Context, key *ecdsa.PrivateKey, args ...string) (string, error) {
+	env.t.Helper()
+	resetCommandState()
+
+	baseArgs := []string{"--deposit-contract", env.chain.DepositContract.Hex(), "--no-color", "--timeout", "30s"}
+	if !slices.Contains(args, "--rpc") {
+		baseArgs = append(baseArgs, "--rpc", env.chain.IPCPath)
+	}
+	if key != nil {
+		baseArgs = append(baseArgs, "--private-key", hex.EncodeToString(crypto.FromECDSA(key)))
+	}
+	rootCmd.SetArgs(append(baseArgs, args...))
+
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		env.t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = writer
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		output <- buf.String()
+	}()
+
+	err = ExecuteContext(ctx)
+	if ethClient != nil {
+		ethClient.Close()
+		ethClient = nil
+	}
+
+	writer.Close()
+	os.Stdout = stdout
+	return <-output, err
+}
+
+// mustRun runs the CLI and fails the test if the command fails.
+func (env *testEnv) mustRun(key *ecdsa.PrivateKey, args ...string) string {
+	env.t.Helper()
+	out, err := env.run(key, args...)
+	if err != nil {
+		env.t.Fatalf("gating-cli %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// startServer runs a serving command in the background with --listen set to a free port, and
+// returns its URL once readyPath responds. The command is stopped at the end of the test.
+func (env *testEnv) startServer(key *ecdsa.PrivateKey, readyPath string, args ...string) string {
+	env.t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		env.t.Fatalf("failed to find a free port: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := env.runContext(ctx, key, append(args, "--listen", address)...)
+		done <- err
+	}()
+	env.t.Cleanup(func() {
+		// Unused keep-alive connections would delay the graceful shutdown
+		http.DefaultClient.CloseIdleConnections()
+		cancel()
+		if err := <-done; err != nil {
+			env.t.Errorf("gating-cli %s failed: %v", strings.Join(args, " "), err)
+		}
+	})
+
+	baseURL := "http://" + address
+	for i := 0; i < 100; i++ {
+		if resp, err := http.Get(baseURL + readyPath); err == nil {
+			resp.Body.Close()
+			return baseURL
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	env.t.Fatalf("gating-cli %s did not start", strings.Join(args, " "))
+	return ""
+}
+
+// adminGater returns a client of the gater sending transactions as the admin. Its transactions
+// are mined by AutoCommit, like the transactions of the CLI.
+func (env *testEnv) adminGater() *gater.Client {
+	transactor := gater.NewTransactor(env.chain.Client, env.chain.AdminKey, gatertest.ChainID)
+	return env.gater().WithTransactor(transactor)
+}
+
+// gater returns a read-only client of the gater of the simulated chain.
+func (env *testEnv) gater() *gater.Client {
+	return env.chain.GaterClient(nil)
+}
+
+// hasAdminRole checks the admin role of an account on the simulated chain.
+func (env *testEnv) hasAdminRole(account common.Address) bool {
+	env.t.Helper()
+	isAdmin, err := env.gater().HasRole(context.Background(), gater.DefaultAdminRole, account)
+	if err != nil {
+		env.t.Fatalf("failed to check admin role: %v", err)
+	}
+	return isAdmin
+}
+
+// balanceOf returns the token balance of an account on the simulated chain.
+func (env *testEnv) balanceOf(account common.Address) *big.Int {
+	env.t.Helper()
+	balance, err := env.gater().BalanceOf(context.Background(), account)
+	if err != nil {
+		env.t.Fatalf("failed to get balance: %v", err)
+	}
+	return balance
+}
+
+// resetCommandState resets the flags and parsed globals left over from a previous run.
+func resetCommandState() {
+	var resetFlags func(c *cobra.Command)
+	resetFlags = func(c *cobra.Command) {
+		reset := func(flag *pflag.Flag) {
+			if slice, ok := flag.Value.(pflag.SliceValue); ok {
+				slice.Replace(nil)
+			} else {
+				flag.Value.Set(flag.DefValue)
+			}
+			flag.Changed = false
+		}
+		c.Flags().VisitAll(reset)
+		c.PersistentFlags().VisitAll(reset)
+		// Cobra keeps the context (with the command timeout) of a previous execution
+		c.SetContext(nil)
+		for _, sub := range c.Commands() {
+			resetFlags(sub)
+		}
+	}
+	resetFlags(rootCmd)
+
+	signerKey = nil
+	signerAddress = common.Address{}
+	transactor = nil
+	gaterClient = nil
+	cancelCommand = nil
+	batchConfirmed = false
+}
+
+// assertContains fails the test if the output doesn't contain all expected strings.
+func assertContains(t *testing.T, output string, expected ...string) {
+	t.Helper()
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("output does not contain %q:\n%s", s, output)
+		}
+	}
+}
+
+// assertError fails the test if err is nil or doesn't contain the expected message.
+func assertError(t *testing.T, err error, expected string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected error containing %q, got nil", expected)
+	}
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("expected error containing %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+// batchConfirmed is set once the user confirmed a batch of transactions, so the
+// transactions of the batch are not confirmed one by one.
+var batchConfirmed bool
+
+// stdinIsTerminal checks if stdin is an interactive terminal.
+func stdinIsTerminal() bool {
+	return readline.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// networkLabel returns the name and chain ID of the connected network.
+func networkLabel() string {
+	if network := findNetworkByChainID(chainID.Uint64()); network != nil {
+		return fmt.Sprintf("%s (chain ID %s)", network.Name, chainID.String())
+	}
+	return fmt.Sprintf("unknown network (chain ID %s)", chainID.String())
+}
+
+// printTransactionContext prints the network, signer and gater transactions are sent with.
+func printTransactionContext() {
+	fmt.Printf("%sNetwork:%s           %s%s%s\n", colorCyan, colorReset, colorBold, networkLabel(), colorReset)
+	fmt.Printf("%sSigner:%s            %s\n", colorCyan, colorReset, signerAddress.Hex())
+	fmt.Printf("%sGating Contract:%s   %s\n", colorCyan, colorReset, formatOptionalAddress(gaterAddr))
+}
+
+// requireConfirmation asks the user to confirm before sending. It returns false if the user declined.
+// Without a terminal, sending requires --yes.
+func requireConfirmation(label string) (bool, error) {
+	if assumeYes || batchConfirmed {
+		return true, nil
+	}
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("refusing to send transactions without confirmation: stdin is not a terminal (use --yes)")
+	}
+	return promptConfirm(label)
+}
+
+// confirmTransactionBatch asks once for a batch of transactions (e.g. a policy apply or
+// a deposit file). After confirmation, the transactions are sent without further prompts.
+func confirmTransactionBatch(count int, description string) (bool, error) {
+	if !assumeYes {
+		fmt.Println()
+		printHeader("═══ Confirm Transactions ═══")
+		printTransactionContext()
+		fmt.Printf("%sTransactions:%s      %d (%s)\n", colorCyan, colorReset, count, description)
+		fmt.Println()
+	}
+
+	confirmed, err := requireConfirmation(fmt.Sprintf("Send %d transaction(s) on %s", count, networkLabel()))
+	if err != nil || !confirmed {
+		return false, err
+	}
+	batchConfirmed = true
+	return true, nil
+}
+
+// endTransactionBatch ends a confirmed batch, so later transactions are confirmed again.
+func endTransactionBatch() {
+	batchConfirmed = false
+}
+
+// confirmTransaction shows a transaction with its decoded call and asks the user to confirm it.
+func confirmTransaction(to common.Address, value *big.Int, data []byte) error {
+	if !assumeYes && !batchConfirmed {
+		fmt.Println()
+		printHeader("═══ Confirm Transaction ═══")
+		printTransactionContext()
+		fmt.Printf("%sTarget:%s            %s\n", colorCyan, colorReset, describeTarget(to))
+		if value != nil && value.Sign() > 0 {
+			fmt.Printf("%sValue:%s             %s\n", colorCyan, colorReset, formatWei(value))
+		}
+		fmt.Printf("%sCall:%s              %s\n", colorCyan, colorReset, describeCall(to, data))
+		fmt.Println()
+	}
+
+	confirmed, err := requireConfirmation("Send transaction")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("transaction not confirmed")
+	}
+	return nil
+}
+
+// describeTarget returns a transaction target with a label for the known contracts.
+func describeTarget(to common.Address) string {
+	switch to {
+	case gaterAddr:
+		return fmt.Sprintf("%s (gating contract)", to.Hex())
+	case depositAddr:
+		return fmt.Sprintf("%s (deposit contract)", to.Hex())
+	default:
+		return to.Hex()
+	}
+}
+
+// describeCall decodes the call data of a transaction to the gater or deposit contract.
+func describeCall(to common.Address, data []byte) string {
+	if len(data) < 4 {
+		return "(no call data)"
+	}
+
+	contractABI := gater.ABI
+	if to == depositAddr {
+		contractABI = parsedDepositABI
+	}
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return fmt.Sprintf("unknown call 0x%x", data)
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Sprintf("%s(<invalid arguments>)", method.Name)
+	}
+
+	args := make([]string, len(values))
+	for i, value := range values {
+		args[i] = fmt.Sprintf("%s=%s", method.Inputs[i].Name, formatCallArgument(method.Inputs[i], value))
+	}
+	return fmt.Sprintf("%s(%s)", method.Name, strings.Join(args, ", "))
+}
+
+// formatCallArgument formats a decoded call argument, naming the well-known roles.
+func formatCallArgument(input abi.Argument, value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return shortHex(v)
+	case [32]byte:
+		return roleLabel(v)
+	case uint16:
+		if input.Name == "depositType" {
+			return fmt.Sprintf("0x%04x", v)
+		}
+		return fmt.Sprintf("%d", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,395 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchFromBlock      string
+	watchToBlock        uint64
+	watchConfirmations  uint64
+	watchPollInterval   time.Duration
+	watchChunkSize      uint64
+	watchWebhooks       []string
+	watchWebhookRetries int
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream gater and deposit contract events",
+	Long: `Streams decoded events of the gater and the deposit contract as they happen:
+
+  DepositGateConfigChanged  A deposit type config was changed
+  CustomGaterChanged        The custom gater was changed
+  RoleGranted, RoleRevoked  A role was granted or revoked
+  Mint                      Tokens were minted
+  Deposit                   A deposit was made to the deposit contract
+
+New blocks are received via subscription on WebSocket and IPC endpoints, and
+polled otherwise. A failed subscription is renewed at the --poll-interval, new
+blocks are polled in the meantime. Events are only emitted once their block has --confirmations
+confirmations, so short reorgs don't emit events that are later dropped.
+
+Events are printed as text, or as JSON lines with --output json, and posted to
+the --webhook URLs. Prefix a URL with 'slack:' or 'discord:' to post a
+Slack/Discord-compatible message instead of the JSON event.
+
+This command is read-only and doesn't require a private key.`,
+	Annotations: map[string]string{annotationSignerOptional: "true"},
+	RunE:        runWatch,
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchFromBlock, "from-block", "latest", "First block to stream events from (number or 'latest')")
+	watchCmd.Flags().Uint64Var(&watchToBlock, "to-block", 0, "Stop after this block (default: follow new blocks)")
+	watchCmd.Flags().Uint64Var(&watchConfirmations, "confirmations", 6, "Number of confirmations before an event is emitted")
+	watchCmd.Flags().DurationVar(&watchPollInterval, "poll-interval", 12*time.Second, "Interval to poll for new blocks (if subscriptions are not supported)")
+	watchCmd.Flags().Uint64Var(&watchChunkSize, "chunk-size", 10000, "Maximum number of blocks per eth_getLogs request")
+	watchCmd.Flags().StringSliceVar(&watchWebhooks, "webhook", nil, "Webhook URL to post events to, optionally prefixed with slack: or discord: (repeat for several)")
+	watchCmd.Flags().IntVar(&watchWebhookRetries, "webhook-retries", 3, "Number of retries of failed webhook requests")
+}
+
+// watchEvent is a decoded event emitted by the watch command.
+type watchEvent struct {
+	Event       string                 `json:"event"`
+	Contract    string                 `json:"contract"`
+	ChainID     uint64                 `json:"chainId"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	BlockHash   string                 `json:"blockHash"`
+	TxHash      string                 `json:"txHash"`
+	LogIndex    uint                   `json:"logIndex"`
+	Args        map[string]interface{} `json:"args"`
+	Summary     string                 `json:"summary"`
+}
+
+// eventWatcher streams the events of a block range that grows with the chain.
+type eventWatcher struct {
+	webhooks []*webhook
+	query    ethereum.FilterQuery
+
+	next     uint64      // first block not emitted yet
+	lastHash common.Hash // hash of the last emitted block, to detect reorgs deeper than the confirmations
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	webhooks, err := parseWebhooks(watchWebhooks)
+	if err != nil {
+		return err
+	}
+
+	watcher := &eventWatcher{
+		webhooks: webhooks,
+		query:    watchQuery(),
+	}
+	if watchFromBlock == "latest" {
+		latest, err := getLatestBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block: %w", err)
+		}
+		watcher.next = latest.Uint64() + 1
+		if watchConfirmations <= latest.Uint64() {
+			watcher.next = latest.Uint64() - watchConfirmations + 1
+		}
+	} else {
+		watcher.next, err = strconv.ParseUint(watchFromBlock, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid from-block: %s", watchFromBlock)
+		}
+	}
+
+	log.WithFields(map[string]interface{}{
+		"fromBlock":     watcher.next,
+		"confirmations": watchConfirmations,
+		"webhooks":      len(webhooks),
+	}).Info("Watching events")
+
+	// Wake up on new blocks, falling back to polling if subscriptions are not supported
+	heads := make(chan *types.Header, 16)
+	var sub ethereum.Subscription
+	var subErr <-chan error
+	subscribe := func() error {
+		var err error
+		if sub, err = ethClient.SubscribeNewHead(ctx, heads); err != nil {
+			return err
+		}
+		subErr = sub.Err()
+		return nil
+	}
+	if err := subscribe(); err != nil {
+		log.WithError(err).Debug("Subscriptions not supported, polling for new blocks")
+	}
+	resubscribe := sub != nil
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, err := watcher.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.WithError(err).Warn("Failed to fetch events")
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heads:
+		case <-ticker.C:
+			// Resubscribe after a failed subscription, polling until it succeeds
+			if resubscribe && sub == nil {
+				if err := subscribe(); err != nil {
+					log.WithError(err).Debug("Failed to resubscribe to new blocks")
+				} else {
+					log.Info("Resubscribed to new blocks")
+				}
+			}
+		case err := <-subErr:
+			log.WithError(err).Warn("Block subscription failed, polling for new blocks until resubscribed")
+			sub.Unsubscribe()
+			sub, subErr = nil, nil
+		}
+	}
+}
+
+// watchQuery returns the log filter for all watched events of the gater and the deposit contract.
+func watchQuery() ethereum.FilterQuery {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{depositAddr},
+		Topics:    [][]common.Hash{{parsedDepositABI.Events["DepositEvent"].ID}},
+	}
+	if gaterAddr != (common.Address{}) {
+		query.Addresses = append(query.Addresses, gaterAddr)
+		for _, name := range []string{"DepositGateConfigChanged", "CustomGaterChanged", "RoleGranted", "RoleRevoked", "Transfer"} {
+			query.Topics[0] = append(query.Topics[0], gater.ABI.Events[name].ID)
+		}
+	}
+	return query
+}
+
+// poll emits the events of all confirmed blocks since the previous poll.
+// It returns true once --to-block has been emitted.
+func (watcher *eventWatcher) poll(ctx context.Context) (bool, error) {
+	latest, err := getLatestBlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if latest.Uint64() < watchConfirmations {
+		return false, nil
+	}
+	confirmed := latest.Uint64() - watchConfirmations
+	if watchToBlock > 0 && confirmed > watchToBlock {
+		confirmed = watchToBlock
+	}
+	if watcher.next > confirmed {
+		return watchToBlock > 0 && watcher.next > watchToBlock, nil
+	}
+
+	// Detect reorgs deeper than the confirmation depth, the events of the dropped blocks can't be taken back
+	if watcher.lastHash != (common.Hash{}) {
+		header, err := ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(watcher.next-1))
+		if err != nil {
+			return false, fmt.Errorf("failed to get block %d: %w", watcher.next-1, err)
+		}
+		if header.Hash() != watcher.lastHash {
+			log.WithField("block", watcher.next-1).Warn("Reorg deeper than the confirmation depth, already emitted events may have been dropped")
+		}
+	}
+
+	logs, err := filterLogsChunked(ctx, watcher.query, watcher.next, confirmed, watchChunkSize)
+	if err != nil {
+		return false, err
+	}
+	header, err := ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(confirmed))
+	if err != nil {
+		return false, fmt.Errorf("failed to get block %d: %w", confirmed, err)
+	}
+
+	for _, vLog := range logs {
+		if vLog.Removed {
+			continue
+		}
+		event, err := decodeWatchEvent(vLog)
+		if err != nil {
+			log.WithError(err).WithField("txHash", vLog.TxHash.Hex()).Warn("Failed to decode event")
+			continue
+		}
+		if event != nil {
+			watcher.emit(ctx, event)
+		}
+	}
+
+	watcher.next = confirmed + 1
+	watcher.lastHash = header.Hash()
+	return watchToBlock > 0 && watcher.next > watchToBlock, nil
+}
+
+// emit prints an event and posts it to the webhooks.
+func (watcher *eventWatcher) emit(ctx context.Context, event *watchEvent) {
+	if outputFormat == "json" {
+		line, err := json.Marshal(event)
+		if err != nil {
+			log.WithError(err).Warn("Failed to encode event")
+			return
+		}
+		fmt.Println(string(line))
+	} else {
+		fmt.Printf("%s[block %d]%s %s%s%s: %s %s(tx %s)%s\n",
+			colorCyan, event.BlockNumber, colorReset,
+			colorBold, event.Event, colorReset,
+			event.Summary,
+			colorYellow, event.TxHash, colorReset,
+		)
+	}
+
+	if len(watcher.webhooks) > 0 {
+		message := &webhookMessage{
+			text: fmt.Sprintf("[%s] %s: %s (block %d, tx %s)", networkLabel(), event.Event, event.Summary, event.BlockNumber, event.TxHash),
+			data: event,
+		}
+		notifyWebhooks(ctx, watcher.webhooks, message, watchWebhookRetries)
+	}
+}
+
+// decodeWatchEvent decodes a log of the gater or the deposit contract.
+// It returns nil for logs that are not watched (e.g. token transfers other than mints).
+func decodeWatchEvent(vLog types.Log) (*watchEvent, error) {
+	event := &watchEvent{
+		Contract:    vLog.Address.Hex(),
+		ChainID:     chainID.Uint64(),
+		BlockNumber: vLog.BlockNumber,
+		BlockHash:   vLog.BlockHash.Hex(),
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+	}
+
+	if vLog.Address == depositAddr {
+		deposit, err := decodeDepositEvent(vLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = "Deposit"
+		event.Args = map[string]interface{}{
+			"index":                 deposit.Index,
+			"pubkey":                hexutil.Encode(deposit.Pubkey),
+			"withdrawalCredentials": hexutil.Encode(deposit.WithdrawalCredentials),
+			"amount":                deposit.Amount,
+			"depositType":           formatPolicyDepositType(deposit.DepositType),
+		}
+		event.Summary = fmt.Sprintf("Deposit #%d (%s) of %s for %s", deposit.Index, depositTypeLabel(deposit.DepositType), formatGwei(deposit.Amount), shortHex(deposit.Pubkey))
+		return event, nil
+	}
+
+	bindings := gaterClient.Bindings()
+	if len(vLog.Topics) == 0 {
+		return nil, nil
+	}
+	switch vLog.Topics[0] {
+	case gater.ABI.Events["DepositGateConfigChanged"].ID:
+		changed, err := bindings.ParseDepositGateConfigChanged(vLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = "DepositGateConfigChanged"
+		event.Args = map[string]interface{}{
+			"depositType": formatPolicyDepositType(changed.DepositType),
+			"blocked":     changed.Blocked,
+			"noToken":     changed.NoToken,
+		}
+		event.Summary = fmt.Sprintf("Deposit type %s set to blocked=%t noToken=%t", depositTypeLabel(changed.DepositType), changed.Blocked, changed.NoToken)
+
+	case gater.ABI.Events["CustomGaterChanged"].ID:
+		changed, err := bindings.ParseCustomGaterChanged(vLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = "CustomGaterChanged"
+		event.Args = map[string]interface{}{
+			"oldGater": changed.OldGater.Hex(),
+			"newGater": changed.NewGater.Hex(),
+		}
+		event.Summary = fmt.Sprintf("Custom gater changed from %s to %s", formatOptionalAddress(changed.OldGater), formatOptionalAddress(changed.NewGater))
+
+	case gater.ABI.Events["RoleGranted"].ID:
+		granted, err := bindings.ParseRoleGranted(vLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = "RoleGranted"
+		event.Args = map[string]interface{}{
+			"role":    roleLabel(granted.Role),
+			"account": granted.Account.Hex(),
+			"sender":  granted.Sender.Hex(),
+		}
+		event.Summary = fmt.Sprintf("%s granted to %s by %s", roleLabel(granted.Role), granted.Account.Hex(), granted.Sender.Hex())
+
+	case gater.ABI.Events["RoleRevoked"].ID:
+		revoked, err := bindings.ParseRoleRevoked(vLog)
+		if err != nil {
+			return nil, err
+		}
+		event.Event = "RoleRevoked"
+		event.Args = map[string]interface{}{
+			"role":    roleLabel(revoked.Role),
+			"account": revoked.Account.Hex(),
+			"sender":  revoked.Sender.Hex(),
+		}
+		event.Summary = fmt.Sprintf("%s revoked from %s by %s", roleLabel(revoked.Role), revoked.Account.Hex(), revoked.Sender.Hex())
+
+	case gater.ABI.Events["Transfer"].ID:
+		transfer, err := bindings.ParseTransfer(vLog)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.From != (common.Address{}) {
+			return nil, nil
+		}
+		event.Event = "Mint"
+		event.Args = map[string]interface{}{
+			"to":     transfer.To.Hex(),
+			"amount": transfer.Value.String(),
+		}
+		event.Summary = fmt.Sprintf("Minted %s tokens to %s", transfer.Value.String(), transfer.To.Hex())
+
+	default:
+		return nil, nil
+	}
+	return event, nil
+}
+
+// roleLabel names the well-known roles.
+func roleLabel(role common.Hash) string {
+	switch role {
+	case gater.DefaultAdminRole:
+		return "DEFAULT_ADMIN_ROLE"
+	case gater.DepositContractRole:
+		return "DEPOSIT_CONTRACT_ROLE"
+	}
+	return role.Hex()
+}
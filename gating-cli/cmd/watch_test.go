@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// watchAll runs the watch command over all blocks of the simulated chain.
+func (env *testEnv) watchAll(args ...string) string {
+	env.t.Helper()
+	latest, err := env.chain.Client.BlockNumber(env.t.Context())
+	if err != nil {
+		env.t.Fatalf("failed to get latest block: %v", err)
+	}
+	args = append([]string{"watch", "--from-block", "0", "--to-block", strconv.FormatUint(latest, 10), "--confirmations", "0", "--poll-interval", "100ms"}, args...)
+	return env.mustRun(nil, args...)
+}
+
+func TestWatch(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "grantAdmin", env.chain.User.Hex(), "--yes")
+	env.mustRun(env.chain.AdminKey, "mint", "5", "--to", env.chain.User.Hex(), "--yes")
+	env.mustRun(env.chain.AdminKey, "setConfig", "--prefix", "0x42", "--blocked", "true", "--yes")
+
+	out := env.watchAll("--output", "json")
+	var events []watchEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var event watchEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+
+	found := map[string]watchEvent{}
+	for _, event := range events {
+		found[event.Event] = event
+	}
+	if event, ok := found["RoleGranted"]; !ok || event.Args["account"] != env.chain.User.Hex() {
+		t.Errorf("RoleGranted event for %s not found: %+v", env.chain.User.Hex(), events)
+	}
+	if event, ok := found["Mint"]; !ok || event.Args["amount"] != "5" || event.Args["to"] != env.chain.User.Hex() {
+		t.Errorf("Mint event not found: %+v", events)
+	}
+	if event, ok := found["DepositGateConfigChanged"]; !ok || event.Args["depositType"] != "0x42" || event.Args["blocked"] != true {
+		t.Errorf("DepositGateConfigChanged event not found: %+v", events)
+	}
+	if event := found["Mint"]; event.Contract != env.chain.Gater.Hex() || event.ChainID != 1337 {
+		t.Errorf("unexpected Mint event metadata: %+v", event)
+	}
+}
+
+func TestWatchWebhooks(t *testing.T) {
+	env := newTestEnv(t)
+	env.mustRun(env.chain.AdminKey, "mint", "5", "--to", env.chain.User.Hex(), "--yes")
+
+	var mu sync.Mutex
+	var slackPayloads, jsonPayloads []string
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/slack":
+			slackPayloads = append(slackPayloads, string(body))
+		case "/json":
+			// Fail the first request to check the retry
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			jsonPayloads = append(jsonPayloads, string(body))
+		}
+	}))
+	defer server.Close()
+
+	out := env.watchAll("--webhook", "slack:"+server.URL+"/slack", "--webhook", server.URL+"/json")
+	assertContains(t, out, "Mint: Minted 5 tokens to "+env.chain.User.Hex())
+
+	// Deploying the gater emits role grants, so only the mint is checked
+	var slackMint string
+	for _, payload := range slackPayloads {
+		var slack map[string]string
+		if err := json.Unmarshal([]byte(payload), &slack); err != nil {
+			t.Fatalf("invalid slack payload %q: %v", payload, err)
+		}
+		if strings.Contains(slack["text"], "Mint:") {
+			slackMint = slack["text"]
+		}
+	}
+	assertContains(t, slackMint, "Mint: Minted 5 tokens to "+env.chain.User.Hex(), "(block ")
+
+	// The first event is retried after the failed request, so every event arrives once
+	if len(jsonPayloads) != len(slackPayloads) {
+		t.Fatalf("json webhook received %d payloads, want %d", len(jsonPayloads), len(slackPayloads))
+	}
+	var mints int
+	for _, payload := range jsonPayloads {
+		var event watchEvent
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			t.Fatalf("invalid json payload %q: %v", payload, err)
+		}
+		if event.Event == "Mint" && event.Args["amount"] == "5" {
+			mints++
+		}
+	}
+	if mints != 1 {
+		t.Errorf("json webhook received %d mint events, want 1", mints)
+	}
+}
+
+func TestWatchErrors(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.run(nil, "watch", "--webhook", "ftp://example.com")
+	assertError(t, err, "invalid webhook URL")
+
+	_, err = env.run(nil, "watch", "--from-block", "abc")
+	assertError(t, err, "invalid from-block: abc")
+}
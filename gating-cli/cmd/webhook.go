@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Webhook payload formats.
+const (
+	webhookFormatJSON    = "json"
+	webhookFormatSlack   = "slack"
+	webhookFormatDiscord = "discord"
+)
+
+// webhookTimeout is the timeout of a single webhook request.
+const webhookTimeout = 10 * time.Second
+
+// webhook is a URL notifications are posted to.
+type webhook struct {
+	format string
+	url    string
+}
+
+// webhookMessage is a notification posted to webhooks. JSON webhooks receive data,
+// Slack and Discord webhooks receive text.
+type webhookMessage struct {
+	text string
+	data interface{}
+}
+
+// parseWebhooks parses webhook URLs with an optional format prefix, e.g. "slack:https://hooks.slack.com/...".
+// URLs without prefix receive the generic JSON payload.
+func parseWebhooks(entries []string) ([]*webhook, error) {
+	webhooks := make([]*webhook, 0, len(entries))
+	for _, entry := range entries {
+		hook := &webhook{format: webhookFormatJSON, url: entry}
+		for _, format := range []string{webhookFormatJSON, webhookFormatSlack, webhookFormatDiscord} {
+			if strings.HasPrefix(entry, format+":") {
+				hook.format = format
+				hook.url = strings.TrimPrefix(entry, format+":")
+				break
+			}
+		}
+		if !strings.HasPrefix(hook.url, "http://") && !strings.HasPrefix(hook.url, "https://") {
+			return nil, fmt.Errorf("invalid webhook URL: %s (use [json:|slack:|discord:]http(s)://...)", entry)
+		}
+		webhooks = append(webhooks, hook)
+	}
+	return webhooks, nil
+}
+
+// String returns the webhook without the URL path, which often contains a secret token.
+func (hook *webhook) String() string {
+	parsed, err := url.Parse(hook.url)
+	if err != nil {
+		return hook.format + ":<invalid URL>"
+	}
+	return hook.format + ":" + parsed.Scheme + "://" + parsed.Host
+}
+
+// payload encodes a message in the format of the webhook.
+func (hook *webhook) payload(message *webhookMessage) ([]byte, error) {
+	switch hook.format {
+	case webhookFormatSlack:
+		return json.Marshal(map[string]string{"text": message.text})
+	case webhookFormatDiscord:
+		return json.Marshal(map[string]string{"content": message.text})
+	default:
+		return json.Marshal(message.data)
+	}
+}
+
+// post posts a message to the webhook, retrying failed requests (network errors, HTTP 429 and 5xx)
+// up to retries times with exponential backoff.
+func (hook *webhook) post(ctx context.Context, message *webhookMessage, retries int) error {
+	body, err := hook.payload(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		retryable, err := hook.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= retries {
+			return err
+		}
+		log.WithError(err).WithFields(map[string]interface{}{
+			"webhook": hook.String(),
+			"attempt": attempt + 1,
+		}).Debug("Webhook request failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send sends a single webhook request and reports if a failure can be retried.
+func (hook *webhook) send(ctx context.Context, body []byte) (bool, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, hook.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// Don't leak the URL in logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+}
+
+// notifyWebhooks posts a message to all webhooks. Failures are logged, as notifications
+// must not stop the command sending them.
+func notifyWebhooks(ctx context.Context, webhooks []*webhook, message *webhookMessage, retries int) {
+	for _, hook := range webhooks {
+		if err := hook.post(ctx, message, retries); err != nil {
+			log.WithError(err).WithField("webhook", hook.String()).Warn("Failed to post webhook")
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package gater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// multicallChunkSize is the maximum number of calls aggregated into one Multicall3 eth_call.
+	multicallChunkSize = 500
+	// rpcBatchChunkSize is the maximum number of requests per JSON-RPC batch (many providers cap batches at 100).
+	rpcBatchChunkSize = 100
+)
+
+// Multicall3Address is the address Multicall3 is deployed at on most chains.
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// multicall3ABI contains the aggregate3 function of Multicall3.
+const multicall3ABI = `[
+	{
+		"inputs": [{"components": [{"internalType": "address", "name": "target", "type": "address"}, {"internalType": "bool", "name": "allowFailure", "type": "bool"}, {"internalType": "bytes", "name": "callData", "type": "bytes"}], "internalType": "struct Multicall3.Call3[]", "name": "calls", "type": "tuple[]"}],
+		"name": "aggregate3",
+		"outputs": [{"components": [{"internalType": "bool", "name": "success", "type": "bool"}, {"internalType": "bytes", "name": "returnData", "type": "bytes"}], "internalType": "struct Multicall3.Result[]", "name": "returnData", "type": "tuple[]"}],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+var parsedMulticallABI abi.ABI
+
+func init() {
+	var err error
+	parsedMulticallABI, err = abi.JSON(strings.NewReader(multicall3ABI))
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse Multicall3 ABI: %v", err))
+	}
+}
+
+// BatchCaller is implemented by backends that can send JSON-RPC batches.
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, elems []rpc.BatchElem) error
+}
+
+// multicall3Call is a call of the Multicall3 aggregate3 function.
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicall3Result is a result of the Multicall3 aggregate3 function.
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// multicallCacheBlocks is the number of blocks multicallCache keeps the availability of.
+// Long-running commands read at every new block, so older blocks are evicted.
+const multicallCacheBlocks = 2
+
+// multicallCache caches if Multicall3 is deployed at the most recent blocks read. Only blocks
+// pinned by number are cached: the latest and pending state can change with every block.
+type multicallCache struct {
+	mu     sync.Mutex
+	blocks map[uint64]bool
+}
+
+func newMulticallCache() *multicallCache {
+	return &multicallCache{blocks: map[uint64]bool{}}
+}
+
+// cacheableBlock reports if the availability at a block can be cached, i.e. if the block is
+// given by number and not as nil (latest) or a negative block tag (pending, latest, ...).
+func cacheableBlock(block *big.Int) bool {
+	return block != nil && block.Sign() >= 0
+}
+
+// get returns the cached availability at a block.
+func (cache *multicallCache) get(block *big.Int) (available bool, ok bool) {
+	if !cacheableBlock(block) {
+		return false, false
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	available, ok = cache.blocks[block.Uint64()]
+	return available, ok
+}
+
+// set caches the availability at a block, evicting the oldest blocks.
+func (cache *multicallCache) set(block *big.Int, available bool) {
+	if !cacheableBlock(block) {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.blocks[block.Uint64()] = available
+	for len(cache.blocks) > multicallCacheBlocks {
+		oldest := block.Uint64()
+		for number := range cache.blocks {
+			oldest = min(oldest, number)
+		}
+		delete(cache.blocks, oldest)
+	}
+}
+
+// Call is a view call of the gater that is executed as part of a batch.
+type Call struct {
+	Method string
+	Args   []interface{}
+	// Output receives the unpacked result (a pointer, or a pointer to a struct for multiple outputs).
+	Output interface{}
+	// Err is set if the call failed.
+	Err error
+}
+
+// NewCall creates a view call of the gater that unpacks its result into output.
+func NewCall(output interface{}, method string, args ...interface{}) *Call {
+	return &Call{Method: method, Args: args, Output: output}
+}
+
+// Batch executes view calls of the gater in as few round trips as possible.
+//
+// The calls are aggregated into Multicall3 calls if Multicall3 is deployed at the block read,
+// and sent as JSON-RPC batches otherwise (if the backend implements BatchCaller). If the RPC
+// doesn't support batches, the calls are sent one by one. The error of each call is stored in
+// the call; the error of the first failed call is returned.
+func (c *Client) Batch(ctx context.Context, calls []*Call) error {
+	calldata := make([][]byte, len(calls))
+	for i, call := range calls {
+		data, err := ABI.Pack(call.Method, call.Args...)
+		if err != nil {
+			return fmt.Errorf("failed to pack %s call: %w", call.Method, err)
+		}
+		calldata[i] = data
+	}
+
+	results, errs, err := c.batchViewCalls(ctx, calldata)
+	if err != nil {
+		for _, call := range calls {
+			call.Err = err
+		}
+		return err
+	}
+
+	var firstErr error
+	for i, call := range calls {
+		switch {
+		case errs[i] != nil:
+			call.Err = fmt.Errorf("failed to call %s: %w", call.Method, errs[i])
+		default:
+			if err := ABI.UnpackIntoInterface(call.Output, call.Method, results[i]); err != nil {
+				call.Err = fmt.Errorf("failed to unpack %s result: %w", call.Method, err)
+			}
+		}
+		if call.Err != nil && firstErr == nil {
+			firstErr = call.Err
+		}
+	}
+	return firstErr
+}
+
+// batchViewCalls executes eth_calls to the gater and returns their results and errors.
+func (c *Client) batchViewCalls(ctx context.Context, calldata [][]byte) ([][]byte, []error, error) {
+	results := make([][]byte, len(calldata))
+	errs := make([]error, len(calldata))
+	if len(calldata) == 0 {
+		return results, errs, nil
+	}
+
+	// A single call doesn't need batching
+	if len(calldata) == 1 {
+		results[0], errs[0] = c.backend.CallContract(ctx, ethereum.CallMsg{To: &c.address, Data: calldata[0]}, c.block)
+		return results, errs, nil
+	}
+
+	useMulticall, err := c.hasMulticall(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if useMulticall {
+		err := c.multicallViewCalls(ctx, calldata, results, errs)
+		if err == nil || ctx.Err() != nil {
+			return results, errs, err
+		}
+		// Fall back to JSON-RPC batches
+	}
+
+	batchCaller, canBatch := c.backend.(BatchCaller)
+	for start := 0; start < len(calldata); start += rpcBatchChunkSize {
+		end := min(start+rpcBatchChunkSize, len(calldata))
+		if canBatch {
+			err := c.batchChunkViewCalls(ctx, batchCaller, calldata[start:end], results[start:end], errs[start:end])
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil, nil, err
+			}
+			// The RPC doesn't support batches, send the remaining calls one by one
+			canBatch = false
+		}
+		for i := start; i < end; i++ {
+			results[i], errs[i] = c.backend.CallContract(ctx, ethereum.CallMsg{To: &c.address, Data: calldata[i]}, c.block)
+		}
+	}
+	return results, errs, nil
+}
+
+// hasMulticall checks if Multicall3 is deployed at the block read.
+func (c *Client) hasMulticall(ctx context.Context) (bool, error) {
+	if available, ok := c.multicall.get(c.block); ok {
+		return available, nil
+	}
+
+	code, err := c.backend.CodeAt(ctx, Multicall3Address, c.block)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for Multicall3: %w", err)
+	}
+
+	c.multicall.set(c.block, len(code) > 0)
+	return len(code) > 0, nil
+}
+
+// multicallViewCalls aggregates eth_calls into Multicall3 aggregate3 calls that allow failures.
+func (c *Client) multicallViewCalls(ctx context.Context, calldata [][]byte, results [][]byte, errs []error) error {
+	for start := 0; start < len(calldata); start += multicallChunkSize {
+		end := min(start+multicallChunkSize, len(calldata))
+
+		calls := make([]multicall3Call, 0, end-start)
+		for _, data := range calldata[start:end] {
+			calls = append(calls, multicall3Call{Target: c.address, AllowFailure: true, CallData: data})
+		}
+		data, err := parsedMulticallABI.Pack("aggregate3", calls)
+		if err != nil {
+			return fmt.Errorf("failed to pack aggregate3 call: %w", err)
+		}
+
+		result, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &Multicall3Address, Data: data}, c.block)
+		if err != nil {
+			return fmt.Errorf("failed to call aggregate3: %w", err)
+		}
+		var returnData []multicall3Result
+		if err := parsedMulticallABI.UnpackIntoInterface(&returnData, "aggregate3", result); err != nil {
+			return fmt.Errorf("failed to unpack aggregate3 result: %w", err)
+		}
+		if len(returnData) != end-start {
+			return fmt.Errorf("aggregate3 returned %d results for %d calls", len(returnData), end-start)
+		}
+
+		for i, callResult := range returnData {
+			if callResult.Success {
+				results[start+i] = callResult.ReturnData
+			} else {
+				errs[start+i] = errors.New("execution reverted")
+			}
+		}
+	}
+	return nil
+}
+
+// batchChunkViewCalls sends eth_calls as a single JSON-RPC batch.
+func (c *Client) batchChunkViewCalls(ctx context.Context, batchCaller BatchCaller, calldata [][]byte, results [][]byte, errs []error) error {
+	block := "latest"
+	if c.block != nil {
+		block = hexutil.EncodeBig(c.block)
+	}
+
+	elems := make([]rpc.BatchElem, len(calldata))
+	callResults := make([]hexutil.Bytes, len(calldata))
+	for i, data := range calldata {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{"to": c.address, "data": hexutil.Bytes(data)},
+				block,
+			},
+			Result: &callResults[i],
+		}
+	}
+	if err := batchCaller.BatchCallContext(ctx, elems); err != nil {
+		return err
+	}
+	for i, elem := range elems {
+		results[i] = callResults[i]
+		errs[i] = elem.Error
+	}
+	return nil
+}
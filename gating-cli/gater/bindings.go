@@ -0,0 +1,1985 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package gater
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TokenDepositGaterMetaData contains all meta data concerning the TokenDepositGater contract.
+var TokenDepositGaterMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"AccessControlBadConfirmation\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"neededRole\",\"type\":\"bytes32\"}],\"name\":\"AccessControlUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"allowance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientAllowance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"balance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"}],\"name\":\"ERC20InsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC20InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"ERC20InvalidSpender\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"oldGater\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newGater\",\"type\":\"address\"}],\"name\":\"CustomGaterChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint16\",\"name\":\"depositType\",\"type\":\"uint16\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"blocked\",\"type\":\"bool\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"noToken\",\"type\":\"bool\"}],\"name\":\"DepositGateConfigChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"previousAdminRole\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"newAdminRole\",\"type\":\"bytes32\"}],\"name\":\"RoleAdminChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"DEFAULT_ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DEPOSIT_CONTRACT_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"TOPUP_DEPOSIT_TYPE\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"pubkey\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"withdrawal_credentials\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"check_deposit\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCustomGater\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"gater\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint16\",\"name\":\"depositType\",\"type\":\"uint16\"}],\"name\":\"getDepositGateConfig\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"blocked\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"noToken\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"name\":\"getRoleAdmin\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"hasAdminRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"isStickyRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"renounceRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"gater\",\"type\":\"address\"}],\"name\":\"setCustomGater\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint16\",\"name\":\"depositType\",\"type\":\"uint16\"},{\"internalType\":\"bool\",\"name\":\"blocked\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"noToken\",\"type\":\"bool\"}],\"name\":\"setDepositGateConfig\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b506040518060400160405280600d81526020016c2232b837b9b4ba102a37b5b2b760991b8152506040518060400160405280600781526020016611195c1bdcda5d60ca1b8152508160039081610066919061026d565b506004610073828261026d565b5061009191506001600160a01b0362acce5560e81b019050336100be565b6100b96001600160a01b0361606f60f11b016f219ab540356cbb839cbe05303d7705fa6100be565b61034f565b60006100c983610155565b604080516001600160a01b0319831660208201526001600160601b0319606086901b16602c820152919250600091016040516020818303038152906040526101109061032b565b600181556040519091506000906001600160a01b0385169086907f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d908490a450505050565b6000816001600160a01b031981166101c85760405162461bcd60e51b815260206004820152602c60248201527f53696d706c65416363657373436f6e74726f6c3a207a65726f2070726566697860448201526b081b9bdd08185b1b1bddd95960a21b606482015260840160405180910390fd5b92915050565b634e487b7160e01b600052604160045260246000fd5b600181811c908216806101f857607f821691505b60208210810361021857634e487b7160e01b600052602260045260246000fd5b50919050565b601f82111561026857806000526020600020601f840160051c810160208510156102455750805b601f840160051c820191505b818110156102655760008155600101610251565b50505b505050565b81516001600160401b03811115610286576102866101ce565b61029a8161029484546101e4565b8461021e565b6020601f8211600181146102ce57600083156102b65750848201515b600019600385901b1c1916600184901b178455610265565b600084815260208120601f198516915b828110156102fe57878501518255602094850194600190920191016102de565b508482101561031c5786840151600019600387901b60f8161c191681555b50505050600190811b01905550565b805160208083015191908110156102185760001960209190910360031b1b16919050565b61191d8061035e6000396000f3fe608060405234801561001057600080fd5b50600436106101a35760003560e01c80638bd99e8a116100ee578063afcde0ed11610097578063d547741f11610071578063d547741f146103aa578063dd62ed3e146103bd578063f3f52e26146103f6578063fbe5943c1461042057600080fd5b8063afcde0ed1461035e578063c174892814610384578063c395fcb31461039757600080fd5b8063a217fddf116100c8578063a217fddf14610311578063a9059cbb14610338578063aa93e3ac1461034b57600080fd5b80638bd99e8a146102da57806391d14854146102f657806395d89b411461030957600080fd5b8063313ce567116101505780634c7b79ec1161012a5780634c7b79ec1461028b57806370a082311461029e5780637abc4957146102c757600080fd5b8063313ce5671461025657806336568abe1461026557806340c10f191461027857600080fd5b806323b872dd1161018157806323b872dd146101fb578063248a9ca31461020e5780632f2ff15d1461024157600080fd5b806306fdde03146101a8578063095ea7b3146101c657806318160ddd146101e9575b600080fd5b6101b0610447565b6040516101bd91906114e9565b60405180910390f35b6101d96101d4366004611553565b6104d9565b60405190151581526020016101bd565b6002545b6040519081526020016101bd565b6101d961020936600461157d565b6104f3565b6101ed61021c3660046115ba565b507facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff90565b61025461024f3660046115d3565b610519565b005b604051600081526020016101bd565b6102546102733660046115d3565b610663565b610254610286366004611553565b610789565b6102546102993660046115ff565b61080d565b6101ed6102ac3660046115ff565b6001600160a01b031660009081526020819052604090205490565b6101d96102d53660046115d3565b6108f0565b6102e361ffff81565b60405161ffff90911681526020016101bd565b6101d96103043660046115d3565b610962565b6101b06109d5565b6101ed7facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff81565b6101d9610346366004611553565b6109e4565b61025461035936600461163d565b6109f2565b6831bab9ba33b0ba32b960b91b546040516001600160a01b0390911681526020016101bd565b6101d96103923660046116cf565b610aed565b6101d96103a53660046115ff565b610de5565b6102546103b83660046115d3565b610e11565b6101ed6103cb36600461178d565b6001600160a01b03918216600090815260016020908152604080832093909416825291909152205490565b6104096104043660046117b7565b610f2a565b6040805192151583529015156020830152016101bd565b6101ed7fc0de00000000000000000000ffffffffffffffffffffffffffffffffffffffff81565b606060038054610456906117d2565b80601f0160208091040260200160405190810160405280929190818152602001828054610482906117d2565b80156104cf5780601f106104a4576101008083540402835291602001916104cf565b820191906000526020600020905b8154815290600101906020018083116104b257829003601f168201915b5050505050905090565b6000336104e7818585610f4f565b60019150505b92915050565b600033610501858285610f61565b61050c858585610ff9565b60019150505b9392505050565b6105437facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff33610962565b6105ba5760405162461bcd60e51b815260206004820152603260248201527f53696d706c65416363657373436f6e74726f6c3a206d7573742068617665206160448201527f646d696e20726f6c6520746f206772616e74000000000000000000000000000060648201526084015b60405180910390fd5b60006105c58361108a565b6040805173ffffffffffffffffffffffffffffffffffffffff19831660208201526bffffffffffffffffffffffff19606086901b16602c8201529192506000910160405160208183030381529060405261061e9061180c565b6001815560405190915033906001600160a01b0385169086907f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d90600090a450505050565b6001600160a01b03811633146106e15760405162461bcd60e51b815260206004820152603560248201527f53696d706c65416363657373436f6e74726f6c3a2063616e206f6e6c7920726560448201527f6e6f756e636520726f6c657320666f722073656c66000000000000000000000060648201526084016105b1565b60006106ec8361108a565b6040805173ffffffffffffffffffffffffffffffffffffffff19831660208201526bffffffffffffffffffffffff19606086901b16602c820152919250600091016040516020818303038152906040526107459061180c565b600080825560405191925033916001600160a01b0386169187917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a450505050565b6107b37facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff33610962565b6107ff5760405162461bcd60e51b815260206004820152601360248201527f4f6e6c792061646d696e2063616e206d696e740000000000000000000000000060448201526064016105b1565b6108098282611117565b5050565b61081633610de5565b6108885760405162461bcd60e51b815260206004820152603460248201527f53696d706c65416363657373436f6e74726f6c3a2063616c6c657220646f657360448201527f206e6f7420686176652061646d696e20726f6c6500000000000000000000000060648201526084016105b1565b600061089e6831bab9ba33b0ba32b960b91b5490565b6831bab9ba33b0ba32b960b91b838155604051919250906001600160a01b0380851691908416907f562b492461e43f0be67564dc401859d11f5720aeb034d95f3baa17eb371e5d8690600090a3505050565b6000806108fc8461108a565b6040805173ffffffffffffffffffffffffffffffffffffffff19831660208201526bffffffffffffffffffffffff19606087901b16602c820152919250600091016040516020818303038152906040526109559061180c565b5460021495945050505050565b60008061096e8461108a565b6040805173ffffffffffffffffffffffffffffffffffffffff19831660208201526bffffffffffffffffffffffff19606087901b16602c820152919250600091016040516020818303038152906040526109c79061180c565b546001111595945050505050565b606060048054610456906117d2565b6000336104e7818585610ff9565b6109fb33610de5565b610a6d5760405162461bcd60e51b815260206004820152603460248201527f53696d706c65416363657373436f6e74726f6c3a2063616c6c657220646f657360448201527f206e6f7420686176652061646d696e20726f6c6500000000000000000000000060648201526084016105b1565b6000610a7884611166565b9050600082610a88576000610a8b565b60025b84610a97576000610a9a565b60015b1760ff16808355604080518615158152851515602082015291925061ffff8716917f0c188bc85a1c8d8aed6ffac0a86d3aff3160b6d8c014d1c30342899aec77afb9910160405180910390a25050505050565b6000610b197fc0de00000000000000000000ffffffffffffffffffffffffffffffffffffffff33610962565b610b8b5760405162461bcd60e51b815260206004820152602c60248201527f4f6e6c79206465706f73697420636f6e74726163742063616e2063616c6c207460448201527f6869732066756e6374696f6e000000000000000000000000000000000000000060648201526084016105b1565b6000610ba16831bab9ba33b0ba32b960b91b5490565b90506001600160a01b03811615610c57576040517fc17489280000000000000000000000000000000000000000000000000000000081526001600160a01b0382169063c174892890610c05908d908d908d908d908d908d908d908d9060040161185b565b6020604051808303816000875af1158015610c24573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610c4891906118bc565b15610c57576001915050610dd9565b6000610c65868660606111d8565b8015610c785750610c78888860206111d8565b905060008115610c8b575061ffff610cfb565b6001881015610cdc5760405162461bcd60e51b815260206004820152601e60248201527f496e76616c6964207769746864726177616c2063726564656e7469616c73000060448201526064016105b1565b88886000818110610cef57610cef6118d9565b919091013560f81c9150505b600080610d0783610f2a565b915091508115610d595760405162461bcd60e51b815260206004820152601760248201527f4465706f736974207479706520697320626c6f636b656400000000000000000060448201526064016105b1565b80610dcf576001600160a01b038e1660009081526020819052604081205411610dc45760405162461bcd60e51b815260206004820152601160248201527f4e6f7420656e6f75676820746f6b656e7300000000000000000000000000000060448201526064016105b1565b610dcf8e6001611250565b6001955050505050505b98975050505050505050565b60006104ed7facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff83610962565b610e3b7facce55000000000000000000ffffffffffffffffffffffffffffffffffffffff33610962565b610ead5760405162461bcd60e51b815260206004820152603360248201527f53696d706c65416363657373436f6e74726f6c3a206d7573742068617665206160448201527f646d696e20726f6c6520746f207265766f6b650000000000000000000000000060648201526084016105b1565b610eb782826108f0565b156106e15760405162461bcd60e51b815260206004820152602e60248201527f53696d706c65416363657373436f6e74726f6c3a2063616e6e6f74207265766f60448201527f6b6520737469636b7920726f6c6500000000000000000000000000000000000060648201526084016105b1565b6000806000610f3884611166565b546001811615159560029091161515945092505050565b610f5c838383600161129f565b505050565b6001600160a01b03838116600090815260016020908152604080832093861683529290522054600019811015610ff35781811015610fe4576040517ffb8f41b20000000000000000000000000000000000000000000000000000000081526001600160a01b038416600482015260248101829052604481018390526064016105b1565b610ff38484848403600061129f565b50505050565b6001600160a01b03831661103c576040517f96c6fd1e000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b6001600160a01b03821661107f576040517fec442f05000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b610f5c8383836113a6565b60008173ffffffffffffffffffffffffffffffffffffffff1981166104ed5760405162461bcd60e51b815260206004820152602c60248201527f53696d706c65416363657373436f6e74726f6c3a207a65726f2070726566697860448201527f206e6f7420616c6c6f776564000000000000000000000000000000000000000060648201526084016105b1565b6001600160a01b03821661115a576040517fec442f05000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b610809600083836113a6565b604080517f676174650000000000000000000000000000000000000000000000000000000060208201527fffff00000000000000000000000000000000000000000000000000000000000060f084901b16603e820152600091016040516020818303038152906040526104ed9061180c565b60008282146111e957506000610512565b60005b8381101561124557848482818110611206576112066118d9565b909101357fff000000000000000000000000000000000000000000000000000000000000001615905061123d576000915050610512565b6001016111ec565b506001949350505050565b6001600160a01b038216611293576040517f96c6fd1e000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b610809826000836113a6565b6001600160a01b0384166112e2576040517fe602df05000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b6001600160a01b038316611325576040517f94280d62000000000000000000000000000000000000000000000000000000008152600060048201526024016105b1565b6001600160a01b0380851660009081526001602090815260408083209387168352929052208290558015610ff357826001600160a01b0316846001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9258460405161139891815260200190565b60405180910390a350505050565b6001600160a01b0383166113d15780600260008282546113c691906118ef565b9091555061145c9050565b6001600160a01b0383166000908152602081905260409020548181101561143d576040517fe450d38c0000000000000000000000000000000000000000000000000000000081526001600160a01b038516600482015260248101829052604481018390526064016105b1565b6001600160a01b03841660009081526020819052604090209082900390555b6001600160a01b03821661147857600280548290039055611497565b6001600160a01b03821660009081526020819052604090208054820190555b816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040516114dc91815260200190565b60405180910390a3505050565b602081526000825180602084015260005b8181101561151757602081860181015160408684010152016114fa565b506000604082850101526040601f19601f83011684010191505092915050565b80356001600160a01b038116811461154e57600080fd5b919050565b6000806040838503121561156657600080fd5b61156f83611537565b946020939093013593505050565b60008060006060848603121561159257600080fd5b61159b84611537565b92506115a960208501611537565b929592945050506040919091013590565b6000602082840312156115cc57600080fd5b5035919050565b600080604083850312156115e657600080fd5b823591506115f660208401611537565b90509250929050565b60006020828403121561161157600080fd5b61051282611537565b803561ffff8116811461154e57600080fd5b801515811461163a57600080fd5b50565b60008060006060848603121561165257600080fd5b61165b8461161a565b9250602084013561166b8161162c565b9150604084013561167b8161162c565b809150509250925092565b60008083601f84011261169857600080fd5b50813567ffffffffffffffff8111156116b057600080fd5b6020830191508360208285010111156116c857600080fd5b9250929050565b60008060008060008060008060a0898b0312156116eb57600080fd5b6116f489611537565b9750602089013567ffffffffffffffff81111561171057600080fd5b61171c8b828c01611686565b909850965050604089013567ffffffffffffffff81111561173c57600080fd5b6117488b828c01611686565b909650945050606089013567ffffffffffffffff81111561176857600080fd5b6117748b828c01611686565b999c989b50969995989497949560800135949350505050565b600080604083850312156117a057600080fd5b6117a983611537565b91506115f660208401611537565b6000602082840312156117c957600080fd5b6105128261161a565b600181811c908216806117e657607f821691505b60208210810361180657634e487b7160e01b600052602260045260246000fd5b50919050565b805160208083015191908110156118065760001960209190910360031b1b16919050565b818352818160208501375060006020828401015260006020601f19601f840116840101905092915050565b6001600160a01b038916815260a06020820152600061187e60a08301898b611830565b828103604084015261189181888a611830565b905082810360608401526118a6818688611830565b9150508260808301529998505050505050505050565b6000602082840312156118ce57600080fd5b81516105128161162c565b634e487b7160e01b600052603260045260246000fd5b808201808211156104ed57634e487b7160e01b600052601160045260246000fdfea164736f6c634300081e000a",
+}
+
+// TokenDepositGaterABI is the input ABI used to generate the binding from.
+// Deprecated: Use TokenDepositGaterMetaData.ABI instead.
+var TokenDepositGaterABI = TokenDepositGaterMetaData.ABI
+
+// TokenDepositGaterBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use TokenDepositGaterMetaData.Bin instead.
+var TokenDepositGaterBin = TokenDepositGaterMetaData.Bin
+
+// DeployTokenDepositGater deploys a new Ethereum contract, binding an instance of TokenDepositGater to it.
+func DeployTokenDepositGater(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *TokenDepositGater, error) {
+	parsed, err := TokenDepositGaterMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TokenDepositGaterBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &TokenDepositGater{TokenDepositGaterCaller: TokenDepositGaterCaller{contract: contract}, TokenDepositGaterTransactor: TokenDepositGaterTransactor{contract: contract}, TokenDepositGaterFilterer: TokenDepositGaterFilterer{contract: contract}}, nil
+}
+
+// TokenDepositGater is an auto generated Go binding around an Ethereum contract.
+type TokenDepositGater struct {
+	TokenDepositGaterCaller     // Read-only binding to the contract
+	TokenDepositGaterTransactor // Write-only binding to the contract
+	TokenDepositGaterFilterer   // Log filterer for contract events
+}
+
+// TokenDepositGaterCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TokenDepositGaterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenDepositGaterTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TokenDepositGaterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenDepositGaterFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TokenDepositGaterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TokenDepositGaterSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TokenDepositGaterSession struct {
+	Contract     *TokenDepositGater // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// TokenDepositGaterCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TokenDepositGaterCallerSession struct {
+	Contract *TokenDepositGaterCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// TokenDepositGaterTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TokenDepositGaterTransactorSession struct {
+	Contract     *TokenDepositGaterTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// TokenDepositGaterRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TokenDepositGaterRaw struct {
+	Contract *TokenDepositGater // Generic contract binding to access the raw methods on
+}
+
+// TokenDepositGaterCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TokenDepositGaterCallerRaw struct {
+	Contract *TokenDepositGaterCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TokenDepositGaterTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TokenDepositGaterTransactorRaw struct {
+	Contract *TokenDepositGaterTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTokenDepositGater creates a new instance of TokenDepositGater, bound to a specific deployed contract.
+func NewTokenDepositGater(address common.Address, backend bind.ContractBackend) (*TokenDepositGater, error) {
+	contract, err := bindTokenDepositGater(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGater{TokenDepositGaterCaller: TokenDepositGaterCaller{contract: contract}, TokenDepositGaterTransactor: TokenDepositGaterTransactor{contract: contract}, TokenDepositGaterFilterer: TokenDepositGaterFilterer{contract: contract}}, nil
+}
+
+// NewTokenDepositGaterCaller creates a new read-only instance of TokenDepositGater, bound to a specific deployed contract.
+func NewTokenDepositGaterCaller(address common.Address, caller bind.ContractCaller) (*TokenDepositGaterCaller, error) {
+	contract, err := bindTokenDepositGater(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterCaller{contract: contract}, nil
+}
+
+// NewTokenDepositGaterTransactor creates a new write-only instance of TokenDepositGater, bound to a specific deployed contract.
+func NewTokenDepositGaterTransactor(address common.Address, transactor bind.ContractTransactor) (*TokenDepositGaterTransactor, error) {
+	contract, err := bindTokenDepositGater(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterTransactor{contract: contract}, nil
+}
+
+// NewTokenDepositGaterFilterer creates a new log filterer instance of TokenDepositGater, bound to a specific deployed contract.
+func NewTokenDepositGaterFilterer(address common.Address, filterer bind.ContractFilterer) (*TokenDepositGaterFilterer, error) {
+	contract, err := bindTokenDepositGater(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterFilterer{contract: contract}, nil
+}
+
+// bindTokenDepositGater binds a generic wrapper to an already deployed contract.
+func bindTokenDepositGater(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TokenDepositGaterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenDepositGater *TokenDepositGaterRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenDepositGater.Contract.TokenDepositGaterCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenDepositGater *TokenDepositGaterRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.TokenDepositGaterTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenDepositGater *TokenDepositGaterRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.TokenDepositGaterTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TokenDepositGater *TokenDepositGaterCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TokenDepositGater.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TokenDepositGater *TokenDepositGaterTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TokenDepositGater *TokenDepositGaterTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.contract.Transact(opts, method, params...)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCaller) DEFAULTADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "DEFAULT_ADMIN_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _TokenDepositGater.Contract.DEFAULTADMINROLE(&_TokenDepositGater.CallOpts)
+}
+
+// DEFAULTADMINROLE is a free data retrieval call binding the contract method 0xa217fddf.
+//
+// Solidity: function DEFAULT_ADMIN_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) DEFAULTADMINROLE() ([32]byte, error) {
+	return _TokenDepositGater.Contract.DEFAULTADMINROLE(&_TokenDepositGater.CallOpts)
+}
+
+// DEPOSITCONTRACTROLE is a free data retrieval call binding the contract method 0xfbe5943c.
+//
+// Solidity: function DEPOSIT_CONTRACT_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCaller) DEPOSITCONTRACTROLE(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "DEPOSIT_CONTRACT_ROLE")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DEPOSITCONTRACTROLE is a free data retrieval call binding the contract method 0xfbe5943c.
+//
+// Solidity: function DEPOSIT_CONTRACT_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterSession) DEPOSITCONTRACTROLE() ([32]byte, error) {
+	return _TokenDepositGater.Contract.DEPOSITCONTRACTROLE(&_TokenDepositGater.CallOpts)
+}
+
+// DEPOSITCONTRACTROLE is a free data retrieval call binding the contract method 0xfbe5943c.
+//
+// Solidity: function DEPOSIT_CONTRACT_ROLE() view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) DEPOSITCONTRACTROLE() ([32]byte, error) {
+	return _TokenDepositGater.Contract.DEPOSITCONTRACTROLE(&_TokenDepositGater.CallOpts)
+}
+
+// TOPUPDEPOSITTYPE is a free data retrieval call binding the contract method 0x8bd99e8a.
+//
+// Solidity: function TOPUP_DEPOSIT_TYPE() view returns(uint16)
+func (_TokenDepositGater *TokenDepositGaterCaller) TOPUPDEPOSITTYPE(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "TOPUP_DEPOSIT_TYPE")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// TOPUPDEPOSITTYPE is a free data retrieval call binding the contract method 0x8bd99e8a.
+//
+// Solidity: function TOPUP_DEPOSIT_TYPE() view returns(uint16)
+func (_TokenDepositGater *TokenDepositGaterSession) TOPUPDEPOSITTYPE() (uint16, error) {
+	return _TokenDepositGater.Contract.TOPUPDEPOSITTYPE(&_TokenDepositGater.CallOpts)
+}
+
+// TOPUPDEPOSITTYPE is a free data retrieval call binding the contract method 0x8bd99e8a.
+//
+// Solidity: function TOPUP_DEPOSIT_TYPE() view returns(uint16)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) TOPUPDEPOSITTYPE() (uint16, error) {
+	return _TokenDepositGater.Contract.TOPUPDEPOSITTYPE(&_TokenDepositGater.CallOpts)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCaller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TokenDepositGater.Contract.Allowance(&_TokenDepositGater.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _TokenDepositGater.Contract.Allowance(&_TokenDepositGater.CallOpts, owner, spender)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCaller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TokenDepositGater.Contract.BalanceOf(&_TokenDepositGater.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _TokenDepositGater.Contract.BalanceOf(&_TokenDepositGater.CallOpts, account)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TokenDepositGater *TokenDepositGaterCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TokenDepositGater *TokenDepositGaterSession) Decimals() (uint8, error) {
+	return _TokenDepositGater.Contract.Decimals(&_TokenDepositGater.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) Decimals() (uint8, error) {
+	return _TokenDepositGater.Contract.Decimals(&_TokenDepositGater.CallOpts)
+}
+
+// GetCustomGater is a free data retrieval call binding the contract method 0xafcde0ed.
+//
+// Solidity: function getCustomGater() view returns(address gater)
+func (_TokenDepositGater *TokenDepositGaterCaller) GetCustomGater(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "getCustomGater")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetCustomGater is a free data retrieval call binding the contract method 0xafcde0ed.
+//
+// Solidity: function getCustomGater() view returns(address gater)
+func (_TokenDepositGater *TokenDepositGaterSession) GetCustomGater() (common.Address, error) {
+	return _TokenDepositGater.Contract.GetCustomGater(&_TokenDepositGater.CallOpts)
+}
+
+// GetCustomGater is a free data retrieval call binding the contract method 0xafcde0ed.
+//
+// Solidity: function getCustomGater() view returns(address gater)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) GetCustomGater() (common.Address, error) {
+	return _TokenDepositGater.Contract.GetCustomGater(&_TokenDepositGater.CallOpts)
+}
+
+// GetDepositGateConfig is a free data retrieval call binding the contract method 0xf3f52e26.
+//
+// Solidity: function getDepositGateConfig(uint16 depositType) view returns(bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterCaller) GetDepositGateConfig(opts *bind.CallOpts, depositType uint16) (struct {
+	Blocked bool
+	NoToken bool
+}, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "getDepositGateConfig", depositType)
+
+	outstruct := new(struct {
+		Blocked bool
+		NoToken bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Blocked = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.NoToken = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetDepositGateConfig is a free data retrieval call binding the contract method 0xf3f52e26.
+//
+// Solidity: function getDepositGateConfig(uint16 depositType) view returns(bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterSession) GetDepositGateConfig(depositType uint16) (struct {
+	Blocked bool
+	NoToken bool
+}, error) {
+	return _TokenDepositGater.Contract.GetDepositGateConfig(&_TokenDepositGater.CallOpts, depositType)
+}
+
+// GetDepositGateConfig is a free data retrieval call binding the contract method 0xf3f52e26.
+//
+// Solidity: function getDepositGateConfig(uint16 depositType) view returns(bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) GetDepositGateConfig(depositType uint16) (struct {
+	Blocked bool
+	NoToken bool
+}, error) {
+	return _TokenDepositGater.Contract.GetDepositGateConfig(&_TokenDepositGater.CallOpts, depositType)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCaller) GetRoleAdmin(opts *bind.CallOpts, role [32]byte) ([32]byte, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "getRoleAdmin", role)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _TokenDepositGater.Contract.GetRoleAdmin(&_TokenDepositGater.CallOpts, role)
+}
+
+// GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
+//
+// Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) GetRoleAdmin(role [32]byte) ([32]byte, error) {
+	return _TokenDepositGater.Contract.GetRoleAdmin(&_TokenDepositGater.CallOpts, role)
+}
+
+// HasAdminRole is a free data retrieval call binding the contract method 0xc395fcb3.
+//
+// Solidity: function hasAdminRole(address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCaller) HasAdminRole(opts *bind.CallOpts, account common.Address) (bool, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "hasAdminRole", account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasAdminRole is a free data retrieval call binding the contract method 0xc395fcb3.
+//
+// Solidity: function hasAdminRole(address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) HasAdminRole(account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.HasAdminRole(&_TokenDepositGater.CallOpts, account)
+}
+
+// HasAdminRole is a free data retrieval call binding the contract method 0xc395fcb3.
+//
+// Solidity: function hasAdminRole(address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) HasAdminRole(account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.HasAdminRole(&_TokenDepositGater.CallOpts, account)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCaller) HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "hasRole", role, account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.HasRole(&_TokenDepositGater.CallOpts, role, account)
+}
+
+// HasRole is a free data retrieval call binding the contract method 0x91d14854.
+//
+// Solidity: function hasRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) HasRole(role [32]byte, account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.HasRole(&_TokenDepositGater.CallOpts, role, account)
+}
+
+// IsStickyRole is a free data retrieval call binding the contract method 0x7abc4957.
+//
+// Solidity: function isStickyRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCaller) IsStickyRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "isStickyRole", role, account)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsStickyRole is a free data retrieval call binding the contract method 0x7abc4957.
+//
+// Solidity: function isStickyRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) IsStickyRole(role [32]byte, account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.IsStickyRole(&_TokenDepositGater.CallOpts, role, account)
+}
+
+// IsStickyRole is a free data retrieval call binding the contract method 0x7abc4957.
+//
+// Solidity: function isStickyRole(bytes32 role, address account) view returns(bool)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) IsStickyRole(role [32]byte, account common.Address) (bool, error) {
+	return _TokenDepositGater.Contract.IsStickyRole(&_TokenDepositGater.CallOpts, role, account)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterSession) Name() (string, error) {
+	return _TokenDepositGater.Contract.Name(&_TokenDepositGater.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) Name() (string, error) {
+	return _TokenDepositGater.Contract.Name(&_TokenDepositGater.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterSession) Symbol() (string, error) {
+	return _TokenDepositGater.Contract.Symbol(&_TokenDepositGater.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) Symbol() (string, error) {
+	return _TokenDepositGater.Contract.Symbol(&_TokenDepositGater.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _TokenDepositGater.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterSession) TotalSupply() (*big.Int, error) {
+	return _TokenDepositGater.Contract.TotalSupply(&_TokenDepositGater.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_TokenDepositGater *TokenDepositGaterCallerSession) TotalSupply() (*big.Int, error) {
+	return _TokenDepositGater.Contract.TotalSupply(&_TokenDepositGater.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactor) Approve(opts *bind.TransactOpts, spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "approve", spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Approve(&_TokenDepositGater.TransactOpts, spender, value)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) Approve(spender common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Approve(&_TokenDepositGater.TransactOpts, spender, value)
+}
+
+// CheckDeposit is a paid mutator transaction binding the contract method 0xc1748928.
+//
+// Solidity: function check_deposit(address sender, bytes pubkey, bytes withdrawal_credentials, bytes signature, uint256 amount) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactor) CheckDeposit(opts *bind.TransactOpts, sender common.Address, pubkey []byte, withdrawal_credentials []byte, signature []byte, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "check_deposit", sender, pubkey, withdrawal_credentials, signature, amount)
+}
+
+// CheckDeposit is a paid mutator transaction binding the contract method 0xc1748928.
+//
+// Solidity: function check_deposit(address sender, bytes pubkey, bytes withdrawal_credentials, bytes signature, uint256 amount) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) CheckDeposit(sender common.Address, pubkey []byte, withdrawal_credentials []byte, signature []byte, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.CheckDeposit(&_TokenDepositGater.TransactOpts, sender, pubkey, withdrawal_credentials, signature, amount)
+}
+
+// CheckDeposit is a paid mutator transaction binding the contract method 0xc1748928.
+//
+// Solidity: function check_deposit(address sender, bytes pubkey, bytes withdrawal_credentials, bytes signature, uint256 amount) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) CheckDeposit(sender common.Address, pubkey []byte, withdrawal_credentials []byte, signature []byte, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.CheckDeposit(&_TokenDepositGater.TransactOpts, sender, pubkey, withdrawal_credentials, signature, amount)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "grantRole", role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.GrantRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// GrantRole is a paid mutator transaction binding the contract method 0x2f2ff15d.
+//
+// Solidity: function grantRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) GrantRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.GrantRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) Mint(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "mint", to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Mint(&_TokenDepositGater.TransactOpts, to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Mint(&_TokenDepositGater.TransactOpts, to, amount)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) RenounceRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "renounceRole", role, account)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) RenounceRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.RenounceRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// RenounceRole is a paid mutator transaction binding the contract method 0x36568abe.
+//
+// Solidity: function renounceRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) RenounceRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.RenounceRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "revokeRole", role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.RevokeRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// RevokeRole is a paid mutator transaction binding the contract method 0xd547741f.
+//
+// Solidity: function revokeRole(bytes32 role, address account) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) RevokeRole(role [32]byte, account common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.RevokeRole(&_TokenDepositGater.TransactOpts, role, account)
+}
+
+// SetCustomGater is a paid mutator transaction binding the contract method 0x4c7b79ec.
+//
+// Solidity: function setCustomGater(address gater) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) SetCustomGater(opts *bind.TransactOpts, gater common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "setCustomGater", gater)
+}
+
+// SetCustomGater is a paid mutator transaction binding the contract method 0x4c7b79ec.
+//
+// Solidity: function setCustomGater(address gater) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) SetCustomGater(gater common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.SetCustomGater(&_TokenDepositGater.TransactOpts, gater)
+}
+
+// SetCustomGater is a paid mutator transaction binding the contract method 0x4c7b79ec.
+//
+// Solidity: function setCustomGater(address gater) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) SetCustomGater(gater common.Address) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.SetCustomGater(&_TokenDepositGater.TransactOpts, gater)
+}
+
+// SetDepositGateConfig is a paid mutator transaction binding the contract method 0xaa93e3ac.
+//
+// Solidity: function setDepositGateConfig(uint16 depositType, bool blocked, bool noToken) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactor) SetDepositGateConfig(opts *bind.TransactOpts, depositType uint16, blocked bool, noToken bool) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "setDepositGateConfig", depositType, blocked, noToken)
+}
+
+// SetDepositGateConfig is a paid mutator transaction binding the contract method 0xaa93e3ac.
+//
+// Solidity: function setDepositGateConfig(uint16 depositType, bool blocked, bool noToken) returns()
+func (_TokenDepositGater *TokenDepositGaterSession) SetDepositGateConfig(depositType uint16, blocked bool, noToken bool) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.SetDepositGateConfig(&_TokenDepositGater.TransactOpts, depositType, blocked, noToken)
+}
+
+// SetDepositGateConfig is a paid mutator transaction binding the contract method 0xaa93e3ac.
+//
+// Solidity: function setDepositGateConfig(uint16 depositType, bool blocked, bool noToken) returns()
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) SetDepositGateConfig(depositType uint16, blocked bool, noToken bool) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.SetDepositGateConfig(&_TokenDepositGater.TransactOpts, depositType, blocked, noToken)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "transfer", to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Transfer(&_TokenDepositGater.TransactOpts, to, value)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) Transfer(to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.Transfer(&_TokenDepositGater.TransactOpts, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.contract.Transact(opts, "transferFrom", from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.TransferFrom(&_TokenDepositGater.TransactOpts, from, to, value)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 value) returns(bool)
+func (_TokenDepositGater *TokenDepositGaterTransactorSession) TransferFrom(from common.Address, to common.Address, value *big.Int) (*types.Transaction, error) {
+	return _TokenDepositGater.Contract.TransferFrom(&_TokenDepositGater.TransactOpts, from, to, value)
+}
+
+// TokenDepositGaterApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the TokenDepositGater contract.
+type TokenDepositGaterApprovalIterator struct {
+	Event *TokenDepositGaterApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterApproval represents a Approval event raised by the TokenDepositGater contract.
+type TokenDepositGaterApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*TokenDepositGaterApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterApprovalIterator{contract: _TokenDepositGater.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterApproval)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseApproval(log types.Log) (*TokenDepositGaterApproval, error) {
+	event := new(TokenDepositGaterApproval)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterCustomGaterChangedIterator is returned from FilterCustomGaterChanged and is used to iterate over the raw logs and unpacked data for CustomGaterChanged events raised by the TokenDepositGater contract.
+type TokenDepositGaterCustomGaterChangedIterator struct {
+	Event *TokenDepositGaterCustomGaterChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterCustomGaterChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterCustomGaterChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterCustomGaterChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterCustomGaterChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterCustomGaterChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterCustomGaterChanged represents a CustomGaterChanged event raised by the TokenDepositGater contract.
+type TokenDepositGaterCustomGaterChanged struct {
+	OldGater common.Address
+	NewGater common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterCustomGaterChanged is a free log retrieval operation binding the contract event 0x562b492461e43f0be67564dc401859d11f5720aeb034d95f3baa17eb371e5d86.
+//
+// Solidity: event CustomGaterChanged(address indexed oldGater, address indexed newGater)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterCustomGaterChanged(opts *bind.FilterOpts, oldGater []common.Address, newGater []common.Address) (*TokenDepositGaterCustomGaterChangedIterator, error) {
+
+	var oldGaterRule []interface{}
+	for _, oldGaterItem := range oldGater {
+		oldGaterRule = append(oldGaterRule, oldGaterItem)
+	}
+	var newGaterRule []interface{}
+	for _, newGaterItem := range newGater {
+		newGaterRule = append(newGaterRule, newGaterItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "CustomGaterChanged", oldGaterRule, newGaterRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterCustomGaterChangedIterator{contract: _TokenDepositGater.contract, event: "CustomGaterChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchCustomGaterChanged is a free log subscription operation binding the contract event 0x562b492461e43f0be67564dc401859d11f5720aeb034d95f3baa17eb371e5d86.
+//
+// Solidity: event CustomGaterChanged(address indexed oldGater, address indexed newGater)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchCustomGaterChanged(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterCustomGaterChanged, oldGater []common.Address, newGater []common.Address) (event.Subscription, error) {
+
+	var oldGaterRule []interface{}
+	for _, oldGaterItem := range oldGater {
+		oldGaterRule = append(oldGaterRule, oldGaterItem)
+	}
+	var newGaterRule []interface{}
+	for _, newGaterItem := range newGater {
+		newGaterRule = append(newGaterRule, newGaterItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "CustomGaterChanged", oldGaterRule, newGaterRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterCustomGaterChanged)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "CustomGaterChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCustomGaterChanged is a log parse operation binding the contract event 0x562b492461e43f0be67564dc401859d11f5720aeb034d95f3baa17eb371e5d86.
+//
+// Solidity: event CustomGaterChanged(address indexed oldGater, address indexed newGater)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseCustomGaterChanged(log types.Log) (*TokenDepositGaterCustomGaterChanged, error) {
+	event := new(TokenDepositGaterCustomGaterChanged)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "CustomGaterChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterDepositGateConfigChangedIterator is returned from FilterDepositGateConfigChanged and is used to iterate over the raw logs and unpacked data for DepositGateConfigChanged events raised by the TokenDepositGater contract.
+type TokenDepositGaterDepositGateConfigChangedIterator struct {
+	Event *TokenDepositGaterDepositGateConfigChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterDepositGateConfigChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterDepositGateConfigChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterDepositGateConfigChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterDepositGateConfigChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterDepositGateConfigChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterDepositGateConfigChanged represents a DepositGateConfigChanged event raised by the TokenDepositGater contract.
+type TokenDepositGaterDepositGateConfigChanged struct {
+	DepositType uint16
+	Blocked     bool
+	NoToken     bool
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositGateConfigChanged is a free log retrieval operation binding the contract event 0x0c188bc85a1c8d8aed6ffac0a86d3aff3160b6d8c014d1c30342899aec77afb9.
+//
+// Solidity: event DepositGateConfigChanged(uint16 indexed depositType, bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterDepositGateConfigChanged(opts *bind.FilterOpts, depositType []uint16) (*TokenDepositGaterDepositGateConfigChangedIterator, error) {
+
+	var depositTypeRule []interface{}
+	for _, depositTypeItem := range depositType {
+		depositTypeRule = append(depositTypeRule, depositTypeItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "DepositGateConfigChanged", depositTypeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterDepositGateConfigChangedIterator{contract: _TokenDepositGater.contract, event: "DepositGateConfigChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositGateConfigChanged is a free log subscription operation binding the contract event 0x0c188bc85a1c8d8aed6ffac0a86d3aff3160b6d8c014d1c30342899aec77afb9.
+//
+// Solidity: event DepositGateConfigChanged(uint16 indexed depositType, bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchDepositGateConfigChanged(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterDepositGateConfigChanged, depositType []uint16) (event.Subscription, error) {
+
+	var depositTypeRule []interface{}
+	for _, depositTypeItem := range depositType {
+		depositTypeRule = append(depositTypeRule, depositTypeItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "DepositGateConfigChanged", depositTypeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterDepositGateConfigChanged)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "DepositGateConfigChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositGateConfigChanged is a log parse operation binding the contract event 0x0c188bc85a1c8d8aed6ffac0a86d3aff3160b6d8c014d1c30342899aec77afb9.
+//
+// Solidity: event DepositGateConfigChanged(uint16 indexed depositType, bool blocked, bool noToken)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseDepositGateConfigChanged(log types.Log) (*TokenDepositGaterDepositGateConfigChanged, error) {
+	event := new(TokenDepositGaterDepositGateConfigChanged)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "DepositGateConfigChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterRoleAdminChangedIterator is returned from FilterRoleAdminChanged and is used to iterate over the raw logs and unpacked data for RoleAdminChanged events raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleAdminChangedIterator struct {
+	Event *TokenDepositGaterRoleAdminChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterRoleAdminChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterRoleAdminChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterRoleAdminChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterRoleAdminChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterRoleAdminChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterRoleAdminChanged represents a RoleAdminChanged event raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleAdminChanged struct {
+	Role              [32]byte
+	PreviousAdminRole [32]byte
+	NewAdminRole      [32]byte
+	Raw               types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleAdminChanged is a free log retrieval operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterRoleAdminChanged(opts *bind.FilterOpts, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (*TokenDepositGaterRoleAdminChangedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterRoleAdminChangedIterator{contract: _TokenDepositGater.contract, event: "RoleAdminChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleAdminChanged is a free log subscription operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchRoleAdminChanged(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterRoleAdminChanged, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var previousAdminRoleRule []interface{}
+	for _, previousAdminRoleItem := range previousAdminRole {
+		previousAdminRoleRule = append(previousAdminRoleRule, previousAdminRoleItem)
+	}
+	var newAdminRoleRule []interface{}
+	for _, newAdminRoleItem := range newAdminRole {
+		newAdminRoleRule = append(newAdminRoleRule, newAdminRoleItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "RoleAdminChanged", roleRule, previousAdminRoleRule, newAdminRoleRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterRoleAdminChanged)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleAdminChanged is a log parse operation binding the contract event 0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff.
+//
+// Solidity: event RoleAdminChanged(bytes32 indexed role, bytes32 indexed previousAdminRole, bytes32 indexed newAdminRole)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseRoleAdminChanged(log types.Log) (*TokenDepositGaterRoleAdminChanged, error) {
+	event := new(TokenDepositGaterRoleAdminChanged)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterRoleGrantedIterator is returned from FilterRoleGranted and is used to iterate over the raw logs and unpacked data for RoleGranted events raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleGrantedIterator struct {
+	Event *TokenDepositGaterRoleGranted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterRoleGrantedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterRoleGranted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterRoleGranted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterRoleGrantedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterRoleGrantedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterRoleGranted represents a RoleGranted event raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleGranted struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleGranted is a free log retrieval operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*TokenDepositGaterRoleGrantedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterRoleGrantedIterator{contract: _TokenDepositGater.contract, event: "RoleGranted", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleGranted is a free log subscription operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterRoleGranted, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "RoleGranted", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterRoleGranted)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleGranted is a log parse operation binding the contract event 0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d.
+//
+// Solidity: event RoleGranted(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseRoleGranted(log types.Log) (*TokenDepositGaterRoleGranted, error) {
+	event := new(TokenDepositGaterRoleGranted)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterRoleRevokedIterator is returned from FilterRoleRevoked and is used to iterate over the raw logs and unpacked data for RoleRevoked events raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleRevokedIterator struct {
+	Event *TokenDepositGaterRoleRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterRoleRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterRoleRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterRoleRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterRoleRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterRoleRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterRoleRevoked represents a RoleRevoked event raised by the TokenDepositGater contract.
+type TokenDepositGaterRoleRevoked struct {
+	Role    [32]byte
+	Account common.Address
+	Sender  common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRoleRevoked is a free log retrieval operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*TokenDepositGaterRoleRevokedIterator, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterRoleRevokedIterator{contract: _TokenDepositGater.contract, event: "RoleRevoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRoleRevoked is a free log subscription operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterRoleRevoked, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error) {
+
+	var roleRule []interface{}
+	for _, roleItem := range role {
+		roleRule = append(roleRule, roleItem)
+	}
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "RoleRevoked", roleRule, accountRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterRoleRevoked)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRoleRevoked is a log parse operation binding the contract event 0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b.
+//
+// Solidity: event RoleRevoked(bytes32 indexed role, address indexed account, address indexed sender)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseRoleRevoked(log types.Log) (*TokenDepositGaterRoleRevoked, error) {
+	event := new(TokenDepositGaterRoleRevoked)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenDepositGaterTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the TokenDepositGater contract.
+type TokenDepositGaterTransferIterator struct {
+	Event *TokenDepositGaterTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TokenDepositGaterTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TokenDepositGaterTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TokenDepositGaterTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TokenDepositGaterTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TokenDepositGaterTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TokenDepositGaterTransfer represents a Transfer event raised by the TokenDepositGater contract.
+type TokenDepositGaterTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*TokenDepositGaterTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenDepositGaterTransferIterator{contract: _TokenDepositGater.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *TokenDepositGaterTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _TokenDepositGater.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TokenDepositGaterTransfer)
+				if err := _TokenDepositGater.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_TokenDepositGater *TokenDepositGaterFilterer) ParseTransfer(log types.Log) (*TokenDepositGaterTransfer, error) {
+	event := new(TokenDepositGaterTransfer)
+	if err := _TokenDepositGater.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,274 @@
+// Package gater is a Go client for the TokenDepositGater contract.
+//
+// It wraps the generated contract bindings (bindings.go, see 'make bindings') with typed
+// structs for the gate configuration and roles, batched reads via Multicall3 or JSON-RPC
+// batches, and a transactor that signs, sends and awaits transactions.
+//
+//	client := gater.NewClient(ethClient, gaterAddress)
+//	config, err := client.DepositGateConfig(ctx, 0x01)
+//
+//	transactor := gater.NewTransactor(ethClient, key, chainID)
+//	receipt, err := client.WithTransactor(transactor).Mint(ctx, recipient, big.NewInt(5))
+package gater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Role constants from TokenDepositGater.sol
+var (
+	DefaultAdminRole    = common.HexToHash("0xacce55000000000000000000ffffffffffffffffffffffffffffffffffffffff")
+	DepositContractRole = common.HexToHash("0xc0de00000000000000000000ffffffffffffffffffffffffffffffffffffffff")
+)
+
+// TopUpDepositType is the deposit type the gater assigns to top-up deposits.
+const TopUpDepositType uint16 = 0xffff
+
+// ABI is the parsed ABI of the TokenDepositGater contract.
+var ABI abi.ABI
+
+// ErrNoTransactor is returned by write methods of a client without transactor.
+var ErrNoTransactor = errors.New("no transactor configured")
+
+func init() {
+	parsed, err := TokenDepositGaterMetaData.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse TokenDepositGater ABI: %v", err))
+	}
+	ABI = *parsed
+}
+
+// Backend is the chain backend used by the client, e.g. *ethclient.Client or a simulated backend.
+// Backends that also implement BatchCaller get their reads batched as JSON-RPC batches.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// DepositGateConfig is the gate configuration of a deposit type.
+type DepositGateConfig struct {
+	Blocked bool
+	NoToken bool
+}
+
+// RoleStatus is the status of a role assignment.
+type RoleStatus struct {
+	Granted bool
+	Sticky  bool
+}
+
+// TokenInfo describes the deposit token of the gater.
+type TokenInfo struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+}
+
+// Client reads and writes the state of a TokenDepositGater contract.
+// Clients are immutable; At and WithTransactor return modified copies.
+type Client struct {
+	backend    Backend
+	address    common.Address
+	contract   *TokenDepositGater
+	block      *big.Int
+	transactor *Transactor
+	multicall  *multicallCache
+}
+
+// NewClient creates a client for the gater at the given address.
+func NewClient(backend Backend, address common.Address) *Client {
+	contract, err := NewTokenDepositGater(address, backend)
+	if err != nil {
+		// Only fails if the embedded ABI is invalid, which is checked in init
+		panic(fmt.Sprintf("failed to bind TokenDepositGater: %v", err))
+	}
+	return &Client{
+		backend:   backend,
+		address:   address,
+		contract:  contract,
+		multicall: newMulticallCache(),
+	}
+}
+
+// Address returns the address of the gater.
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// Backend returns the backend of the client.
+func (c *Client) Backend() Backend {
+	return c.backend
+}
+
+// Bindings returns the generated contract bindings, e.g. to filter or watch events.
+func (c *Client) Bindings() *TokenDepositGater {
+	return c.contract
+}
+
+// Block returns the block reads are pinned to (nil for the latest block).
+func (c *Client) Block() *big.Int {
+	return c.block
+}
+
+// At returns a copy of the client that reads the state at a block (nil for the latest block).
+func (c *Client) At(block *big.Int) *Client {
+	pinned := *c
+	pinned.block = block
+	return &pinned
+}
+
+// AtLatest returns a copy of the client that reads the state at the current head block.
+// Pinning the block number avoids stale responses from RPC proxies caching 'latest' calls.
+func (c *Client) AtLatest(ctx context.Context) (*Client, error) {
+	header, err := c.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	return c.At(header.Number), nil
+}
+
+// WithTransactor returns a copy of the client that sends transactions with the given transactor.
+func (c *Client) WithTransactor(transactor *Transactor) *Client {
+	withTransactor := *c
+	withTransactor.transactor = transactor
+	return &withTransactor
+}
+
+// Transactor returns the transactor of the client (nil if none is configured).
+func (c *Client) Transactor() *Transactor {
+	return c.transactor
+}
+
+func (c *Client) callOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx, BlockNumber: c.block}
+}
+
+// Call calls a view function and unpacks the result into output.
+func (c *Client) Call(ctx context.Context, output interface{}, method string, args ...interface{}) error {
+	return c.Batch(ctx, []*Call{NewCall(output, method, args...)})
+}
+
+// HasRole checks if an account has a role.
+func (c *Client) HasRole(ctx context.Context, role common.Hash, account common.Address) (bool, error) {
+	granted, err := c.contract.HasRole(c.callOpts(ctx), role, account)
+	if err != nil {
+		return false, fmt.Errorf("failed to call hasRole: %w", err)
+	}
+	return granted, nil
+}
+
+// IsStickyRole checks if a role assignment is sticky (cannot be revoked).
+func (c *Client) IsStickyRole(ctx context.Context, role common.Hash, account common.Address) (bool, error) {
+	sticky, err := c.contract.IsStickyRole(c.callOpts(ctx), role, account)
+	if err != nil {
+		return false, fmt.Errorf("failed to call isStickyRole: %w", err)
+	}
+	return sticky, nil
+}
+
+// RoleStatus returns if an account has a role and if the assignment is sticky.
+func (c *Client) RoleStatus(ctx context.Context, role common.Hash, account common.Address) (RoleStatus, error) {
+	var status RoleStatus
+	err := c.Batch(ctx, []*Call{
+		NewCall(&status.Granted, "hasRole", role, account),
+		NewCall(&status.Sticky, "isStickyRole", role, account),
+	})
+	return status, err
+}
+
+// DepositGateConfig returns the gate configuration of a deposit type.
+func (c *Client) DepositGateConfig(ctx context.Context, depositType uint16) (DepositGateConfig, error) {
+	config, err := c.contract.GetDepositGateConfig(c.callOpts(ctx), depositType)
+	if err != nil {
+		return DepositGateConfig{}, fmt.Errorf("failed to call getDepositGateConfig: %w", err)
+	}
+	return DepositGateConfig{Blocked: config.Blocked, NoToken: config.NoToken}, nil
+}
+
+// CustomGater returns the address of the custom gater (zero if none is set).
+func (c *Client) CustomGater(ctx context.Context) (common.Address, error) {
+	customGater, err := c.contract.GetCustomGater(c.callOpts(ctx))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call getCustomGater: %w", err)
+	}
+	return customGater, nil
+}
+
+// BalanceOf returns the token balance of an account.
+func (c *Client) BalanceOf(ctx context.Context, account common.Address) (*big.Int, error) {
+	balance, err := c.contract.BalanceOf(c.callOpts(ctx), account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call balanceOf: %w", err)
+	}
+	return balance, nil
+}
+
+// TotalSupply returns the total token supply.
+func (c *Client) TotalSupply(ctx context.Context) (*big.Int, error) {
+	supply, err := c.contract.TotalSupply(c.callOpts(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call totalSupply: %w", err)
+	}
+	return supply, nil
+}
+
+// TokenInfo returns the name, symbol, decimals and total supply of the deposit token.
+func (c *Client) TokenInfo(ctx context.Context) (*TokenInfo, error) {
+	info := &TokenInfo{}
+	err := c.Batch(ctx, []*Call{
+		NewCall(&info.Name, "name"),
+		NewCall(&info.Symbol, "symbol"),
+		NewCall(&info.Decimals, "decimals"),
+		NewCall(&info.TotalSupply, "totalSupply"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Transact sends a transaction calling a function of the gater and waits for its receipt.
+func (c *Client) Transact(ctx context.Context, method string, args ...interface{}) (*types.Receipt, error) {
+	if c.transactor == nil {
+		return nil, ErrNoTransactor
+	}
+	data, err := ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+	return c.transactor.Send(ctx, c.address, nil, data)
+}
+
+// Mint mints deposit tokens to an account. Requires the admin role.
+func (c *Client) Mint(ctx context.Context, to common.Address, amount *big.Int) (*types.Receipt, error) {
+	return c.Transact(ctx, "mint", to, amount)
+}
+
+// GrantRole grants a role to an account. Requires the admin role.
+func (c *Client) GrantRole(ctx context.Context, role common.Hash, account common.Address) (*types.Receipt, error) {
+	return c.Transact(ctx, "grantRole", role, account)
+}
+
+// RevokeRole revokes a role from an account. Requires the admin role; sticky roles cannot be revoked.
+func (c *Client) RevokeRole(ctx context.Context, role common.Hash, account common.Address) (*types.Receipt, error) {
+	return c.Transact(ctx, "revokeRole", role, account)
+}
+
+// SetDepositGateConfig sets the gate configuration of a deposit type. Requires the admin role.
+func (c *Client) SetDepositGateConfig(ctx context.Context, depositType uint16, config DepositGateConfig) (*types.Receipt, error) {
+	return c.Transact(ctx, "setDepositGateConfig", depositType, config.Blocked, config.NoToken)
+}
+
+// SetCustomGater sets the custom gater (zero address to remove it). Requires the admin role.
+func (c *Client) SetCustomGater(ctx context.Context, customGater common.Address) (*types.Receipt, error) {
+	return c.Transact(ctx, "setCustomGater", customGater)
+}
@@ -0,0 +1,256 @@
+package gater_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater/gatertest"
+)
+
+func TestTokenInfo(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+
+	info, err := chain.GaterClient(nil).TokenInfo(ctx)
+	if err != nil {
+		t.Fatalf("TokenInfo failed: %v", err)
+	}
+	if info.Name != "Deposit Token" || info.Symbol != "Deposit" || info.Decimals != 0 || info.TotalSupply.Sign() != 0 {
+		t.Errorf("unexpected token info: %+v", info)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+	client := chain.GaterClient(chain.AdminKey)
+
+	status, err := client.RoleStatus(ctx, gater.DefaultAdminRole, chain.Admin)
+	if err != nil {
+		t.Fatalf("RoleStatus failed: %v", err)
+	}
+	if !status.Granted || status.Sticky {
+		t.Errorf("deployer role status = %+v, want granted and not sticky", status)
+	}
+	isDepositContract, err := client.HasRole(ctx, gater.DepositContractRole, chain.DepositContract)
+	if err != nil {
+		t.Fatalf("HasRole failed: %v", err)
+	}
+	if !isDepositContract {
+		t.Error("deposit contract does not have the deposit contract role")
+	}
+
+	if _, err := client.GrantRole(ctx, gater.DefaultAdminRole, chain.User); err != nil {
+		t.Fatalf("GrantRole failed: %v", err)
+	}
+	if isAdmin, _ := client.HasRole(ctx, gater.DefaultAdminRole, chain.User); !isAdmin {
+		t.Error("user is not admin after GrantRole")
+	}
+
+	if _, err := client.RevokeRole(ctx, gater.DefaultAdminRole, chain.User); err != nil {
+		t.Fatalf("RevokeRole failed: %v", err)
+	}
+	if isAdmin, _ := client.HasRole(ctx, gater.DefaultAdminRole, chain.User); isAdmin {
+		t.Error("user is still admin after RevokeRole")
+	}
+}
+
+func TestRevokeStickyRole(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+	client := gater.NewClient(chain.Client, gatertest.StickyGaterAddress).WithTransactor(chain.Transactor(chain.AdminKey))
+
+	status, err := client.RoleStatus(ctx, gater.DefaultAdminRole, chain.Admin)
+	if err != nil {
+		t.Fatalf("RoleStatus failed: %v", err)
+	}
+	if !status.Granted || !status.Sticky {
+		t.Fatalf("admin role status = %+v, want granted and sticky", status)
+	}
+
+	if _, err := client.RevokeRole(ctx, gater.DefaultAdminRole, chain.Admin); err == nil {
+		t.Fatal("revoking a sticky role succeeded")
+	}
+	if isAdmin, _ := client.HasRole(ctx, gater.DefaultAdminRole, chain.Admin); !isAdmin {
+		t.Error("sticky role was revoked")
+	}
+}
+
+func TestDepositGateConfig(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+	client := chain.GaterClient(chain.AdminKey)
+
+	before, err := client.AtLatest(ctx)
+	if err != nil {
+		t.Fatalf("AtLatest failed: %v", err)
+	}
+
+	want := gater.DepositGateConfig{Blocked: true, NoToken: true}
+	receipt, err := client.SetDepositGateConfig(ctx, gater.TopUpDepositType, want)
+	if err != nil {
+		t.Fatalf("SetDepositGateConfig failed: %v", err)
+	}
+
+	config, err := client.At(receipt.BlockNumber).DepositGateConfig(ctx, gater.TopUpDepositType)
+	if err != nil {
+		t.Fatalf("DepositGateConfig failed: %v", err)
+	}
+	if config != want {
+		t.Errorf("config = %+v, want %+v", config, want)
+	}
+
+	// Reads pinned to an earlier block return the earlier state
+	config, err = before.DepositGateConfig(ctx, gater.TopUpDepositType)
+	if err != nil {
+		t.Fatalf("DepositGateConfig failed: %v", err)
+	}
+	if config != (gater.DepositGateConfig{}) {
+		t.Errorf("config before update = %+v, want zero config", config)
+	}
+}
+
+func TestMint(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+
+	if _, err := chain.GaterClient(chain.AdminKey).Mint(ctx, chain.User, big.NewInt(3)); err != nil {
+		t.Fatalf("Mint failed: %v", err)
+	}
+	balance, err := chain.GaterClient(nil).BalanceOf(ctx, chain.User)
+	if err != nil {
+		t.Fatalf("BalanceOf failed: %v", err)
+	}
+	if balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("balance = %s, want 3", balance)
+	}
+
+	// Minting requires the admin role
+	if _, err := chain.GaterClient(chain.UserKey).Mint(ctx, chain.User, big.NewInt(1)); err == nil {
+		t.Error("mint by non-admin succeeded")
+	}
+}
+
+func TestBatch(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+
+	var isAdmin, isUserAdmin, invalidRole bool
+	var supply *big.Int
+	calls := []*gater.Call{
+		gater.NewCall(&isAdmin, "hasRole", gater.DefaultAdminRole, chain.Admin),
+		gater.NewCall(&isUserAdmin, "hasRole", gater.DefaultAdminRole, chain.User),
+		// Roles with a zero prefix revert
+		gater.NewCall(&invalidRole, "hasRole", common.Hash{}, chain.Admin),
+		gater.NewCall(&supply, "totalSupply"),
+	}
+	err := chain.GaterClient(nil).Batch(ctx, calls)
+	if err == nil {
+		t.Fatal("batch with a reverting call returned no error")
+	}
+	if calls[2].Err == nil {
+		t.Error("reverting call has no error")
+	}
+	for _, i := range []int{0, 1, 3} {
+		if calls[i].Err != nil {
+			t.Errorf("call %d failed: %v", i, calls[i].Err)
+		}
+	}
+	if !isAdmin || isUserAdmin || supply == nil || supply.Sign() != 0 {
+		t.Errorf("unexpected results: isAdmin=%v isUserAdmin=%v supply=%v", isAdmin, isUserAdmin, supply)
+	}
+}
+
+// codeCountingBackend counts the eth_getCode requests of a backend.
+type codeCountingBackend struct {
+	gater.Backend
+	codeRequests int
+}
+
+func (b *codeCountingBackend) CodeAt(ctx context.Context, account common.Address, block *big.Int) ([]byte, error) {
+	b.codeRequests++
+	return b.Backend.CodeAt(ctx, account, block)
+}
+
+func TestBatchMulticallCache(t *testing.T) {
+	chain := gatertest.NewChain(t)
+	ctx := context.Background()
+	backend := &codeCountingBackend{Backend: chain.Client}
+	client := gater.NewClient(backend, chain.Gater)
+
+	batch := func(client *gater.Client) {
+		t.Helper()
+		var supply *big.Int
+		var isAdmin bool
+		if err := client.Batch(ctx, []*gater.Call{
+			gater.NewCall(&supply, "totalSupply"),
+			gater.NewCall(&isAdmin, "hasRole", gater.DefaultAdminRole, chain.Admin),
+		}); err != nil {
+			t.Fatalf("batch failed: %v", err)
+		}
+	}
+
+	// Multicall3 may be deployed at any later block, its availability at the latest block isn't cached
+	batch(client)
+	batch(client)
+	if backend.codeRequests != 2 {
+		t.Errorf("expected 2 code requests at the latest block, got %d", backend.codeRequests)
+	}
+
+	pinned, err := client.AtLatest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.codeRequests = 0
+	batch(pinned)
+	batch(pinned)
+	if backend.codeRequests != 1 {
+		t.Errorf("expected 1 code request at a pinned block, got %d", backend.codeRequests)
+	}
+}
+
+func TestUnconfirmedTransaction(t *testing.T) {
+	chain := gatertest.NewChain(t)
+
+	// The transaction is sent, but not mined before the context expires
+	transactor := gater.NewTransactor(chain.Client, chain.AdminKey, gatertest.ChainID)
+	var doneErr error
+	transactor.Done = func(tx *types.Transaction, receipt *types.Receipt, err error) {
+		if receipt != nil {
+			t.Errorf("Done called with receipt of unconfirmed transaction")
+		}
+		doneErr = err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := chain.GaterClient(nil).WithTransactor(transactor).Mint(ctx, chain.User, big.NewInt(1))
+	var unconfirmed *gater.UnconfirmedError
+	if !errors.As(err, &unconfirmed) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want UnconfirmedError", err)
+	}
+	if !errors.Is(doneErr, context.DeadlineExceeded) {
+		t.Errorf("Done called with error %v, want the error of waiting", doneErr)
+	}
+
+	// It is still mined later
+	chain.Backend.Commit()
+	receipt, err := chain.Client.TransactionReceipt(context.Background(), unconfirmed.Tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("unconfirmed transaction was not mined: %v", err)
+	}
+}
+
+func TestNoTransactor(t *testing.T) {
+	chain := gatertest.NewChain(t)
+
+	_, err := chain.GaterClient(nil).Mint(context.Background(), chain.User, big.NewInt(1))
+	if !errors.Is(err, gater.ErrNoTransactor) {
+		t.Errorf("error = %v, want ErrNoTransactor", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Package gatertest provides a simulated chain with the gated deposit contract and its
+// TokenDepositGater deployed from the compiled contracts in contract-json, for offline tests.
+//
+//	chain := gatertest.NewChain(t)
+//	client := chain.GaterClient(chain.AdminKey)
+//	receipt, err := client.Mint(ctx, chain.User, big.NewInt(1))
+package gatertest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/pk910/gated-deposit-contract/gating-cli/gater"
+)
+
+// ChainID is the chain ID of the simulated chain.
+var ChainID = big.NewInt(1337)
+
+// StickyGaterAddress is the address of a second gater, allocated in the genesis block, on which
+// the admin has a sticky admin role. Sticky roles cannot be granted by transactions.
+var StickyGaterAddress = common.HexToAddress("0x5717c4000000000000000000000000000000a11c")
+
+// Chain is a simulated chain with the deposit contract and gater deployed.
+type Chain struct {
+	Backend *simulated.Backend
+	Client  simulated.Client
+	// IPCPath is the IPC endpoint of the simulated node, e.g. for the --rpc flag of the CLI.
+	IPCPath string
+
+	// AdminKey deployed the contracts and has the (non-sticky) admin role on the gater.
+	AdminKey *ecdsa.PrivateKey
+	Admin    common.Address
+	// UserKey is a funded account without roles.
+	UserKey *ecdsa.PrivateKey
+	User    common.Address
+
+	Gater           common.Address
+	DepositContract common.Address
+}
+
+// artifact is a compiled contract from contract-json.
+type artifact struct {
+	ABI              json.RawMessage `json:"abi"`
+	Bytecode         string          `json:"bytecode"`
+	DeployedBytecode string          `json:"deployedBytecode"`
+}
+
+// loadArtifact loads a compiled contract from the contract-json directory of the repository.
+func loadArtifact(t testing.TB, name string) (abi.ABI, *artifact) {
+	t.Helper()
+	_, file, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(file), "..", "..", "..", "contract-json", name+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read contract artifact: %v", err)
+	}
+	var contract artifact
+	if err := json.Unmarshal(data, &contract); err != nil {
+		t.Fatalf("failed to parse contract artifact %s: %v", name, err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(contract.ABI)))
+	if err != nil {
+		t.Fatalf("failed to parse ABI of %s: %v", name, err)
+	}
+	return parsed, &contract
+}
+
+// roleKey returns the storage key of a role assignment (role prefix followed by the account).
+func roleKey(role common.Hash, account common.Address) common.Hash {
+	return common.BytesToHash(append(common.CopyBytes(role[:12]), account.Bytes()...))
+}
+
+// NewChain starts a simulated chain and deploys the deposit contract with a TokenDepositGater.
+// The deposit contract has the deposit contract role on the gater. The chain is closed when the
+// test finishes.
+func NewChain(t testing.TB) *Chain {
+	t.Helper()
+
+	adminKey, _ := crypto.GenerateKey()
+	userKey, _ := crypto.GenerateKey()
+	chain := &Chain{
+		AdminKey: adminKey,
+		Admin:    crypto.PubkeyToAddress(adminKey.PublicKey),
+		UserKey:  userKey,
+		User:     crypto.PubkeyToAddress(userKey.PublicKey),
+	}
+
+	// Short temp dir, unix socket paths are limited to ~100 characters
+	dir, err := os.MkdirTemp("", "gatertest")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	chain.IPCPath = filepath.Join(dir, "sim.ipc")
+
+	_, gaterArtifact := loadArtifact(t, "TokenDepositGater")
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	alloc := types.GenesisAlloc{
+		chain.Admin: {Balance: funds},
+		chain.User:  {Balance: funds},
+		StickyGaterAddress: {
+			Code: common.FromHex(gaterArtifact.DeployedBytecode),
+			Storage: map[common.Hash]common.Hash{
+				roleKey(gater.DefaultAdminRole, chain.Admin): common.BigToHash(big.NewInt(2)),
+			},
+		},
+	}
+	chain.Backend = simulated.NewBackend(alloc, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		nodeConf.IPCPath = chain.IPCPath
+		// Indexing the logs of every block is slow (especially with -race) and makes eth_getLogs
+		// wait for the indexer; the few blocks of a test chain are searched quickly without it
+		ethConf.LogNoHistory = true
+	})
+	t.Cleanup(func() { chain.Backend.Close() })
+	chain.Client = chain.Backend.Client()
+
+	// Deploy the gater and the deposit contract
+	opts, err := bind.NewKeyedTransactorWithChainID(adminKey, ChainID)
+	if err != nil {
+		t.Fatalf("failed to create transactor: %v", err)
+	}
+	gaterAddr, tx, _, err := gater.DeployTokenDepositGater(opts, chain.Client)
+	if err != nil {
+		t.Fatalf("failed to deploy TokenDepositGater: %v", err)
+	}
+	chain.mustMine(t, tx)
+	chain.Gater = gaterAddr
+
+	depositABI, depositArtifact := loadArtifact(t, "DepositContract")
+	depositAddr, tx, _, err := bind.DeployContract(opts, depositABI, common.FromHex(depositArtifact.Bytecode), chain.Client, gaterAddr)
+	if err != nil {
+		t.Fatalf("failed to deploy DepositContract: %v", err)
+	}
+	chain.mustMine(t, tx)
+	chain.DepositContract = depositAddr
+
+	if _, err := chain.GaterClient(adminKey).GrantRole(context.Background(), gater.DepositContractRole, depositAddr); err != nil {
+		t.Fatalf("failed to grant deposit contract role: %v", err)
+	}
+
+	return chain
+}
+
+// mustMine mines a transaction and fails the test if it reverted.
+func (c *Chain) mustMine(t testing.TB, tx *types.Transaction) {
+	t.Helper()
+	c.Backend.Commit()
+	receipt, err := c.Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to get receipt of %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction %s reverted", tx.Hash().Hex())
+	}
+}
+
+// Transactor returns a transactor signing with the given key that mines its transactions immediately.
+func (c *Chain) Transactor(key *ecdsa.PrivateKey) *gater.Transactor {
+	transactor := gater.NewTransactor(c.Client, key, ChainID)
+	transactor.Sent = func(*types.Transaction) {
+		c.Backend.Commit()
+	}
+	return transactor
+}
+
+// GaterClient returns a client of the gater sending transactions with the given key (nil for read-only).
+func (c *Chain) GaterClient(key *ecdsa.PrivateKey) *gater.Client {
+	client := gater.NewClient(c.Client, c.Gater)
+	if key != nil {
+		client = client.WithTransactor(c.Transactor(key))
+	}
+	return client
+}
+
+// AutoCommit mines a block at the given interval until the test finishes, so transactions
+// sent by other clients (e.g. the CLI via IPCPath) get mined. Don't combine with Transactor.
+func (c *Chain) AutoCommit(t testing.TB, interval time.Duration) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				c.Backend.Commit()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+	})
+}
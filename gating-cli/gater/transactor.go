@@ -0,0 +1,125 @@
+package gater
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrTransactionFailed is returned when a transaction was mined but reverted.
+var ErrTransactionFailed = errors.New("transaction failed")
+
+// UnconfirmedError is returned when a transaction was sent, but waiting for its receipt failed
+// (e.g. a timeout). The transaction may still be mined.
+type UnconfirmedError struct {
+	Tx  *types.Transaction
+	Err error
+}
+
+func (e *UnconfirmedError) Error() string {
+	return fmt.Sprintf("failed to wait for transaction %s: %v", e.Tx.Hash().Hex(), e.Err)
+}
+
+func (e *UnconfirmedError) Unwrap() error {
+	return e.Err
+}
+
+// Transactor signs transactions with a private key, sends them and waits for their receipts.
+type Transactor struct {
+	backend Backend
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	chainID *big.Int
+
+	// Confirm is called before a transaction is signed. Returning an error aborts the transaction.
+	Confirm func(to common.Address, value *big.Int, data []byte) error
+	// Sent is called after a transaction was submitted, before waiting for its receipt.
+	Sent func(tx *types.Transaction)
+	// Done is called after waiting for the receipt of a submitted transaction, with the receipt
+	// or, if the transaction wasn't confirmed, the error of waiting for it.
+	Done func(tx *types.Transaction, receipt *types.Receipt, err error)
+}
+
+// NewTransactor creates a transactor signing with the given key for the given chain.
+func NewTransactor(backend Backend, key *ecdsa.PrivateKey, chainID *big.Int) *Transactor {
+	return &Transactor{
+		backend: backend,
+		key:     key,
+		from:    crypto.PubkeyToAddress(key.PublicKey),
+		chainID: chainID,
+	}
+}
+
+// From returns the address transactions are sent from.
+func (t *Transactor) From() common.Address {
+	return t.from
+}
+
+// Send sends a transaction transferring value (in wei, may be nil) to the target and waits
+// until it is mined. If the transaction reverted, the receipt is returned with ErrTransactionFailed.
+// If it was sent but not confirmed, an *UnconfirmedError is returned.
+func (t *Transactor) Send(ctx context.Context, to common.Address, value *big.Int, data []byte) (*types.Receipt, error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+	if t.Confirm != nil {
+		if err := t.Confirm(to, value, data); err != nil {
+			return nil, err
+		}
+	}
+
+	nonce, err := t.backend.PendingNonceAt(ctx, t.from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	gasPrice, err := t.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	gasLimit, err := t.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:  t.from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	tx := types.NewTransaction(nonce, to, value, gasLimit, gasPrice, data)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(t.chainID), t.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := t.backend.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	if t.Sent != nil {
+		t.Sent(signedTx)
+	}
+
+	receipt, err := bind.WaitMined(ctx, t.backend, signedTx)
+	if t.Done != nil {
+		t.Done(signedTx, receipt, err)
+	}
+	if err != nil {
+		return nil, &UnconfirmedError{Tx: signedTx, Err: err}
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		return receipt, ErrTransactionFailed
+	}
+
+	return receipt, nil
+}